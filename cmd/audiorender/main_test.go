@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/audio"
+)
+
+func TestParseSFXList(t *testing.T) {
+	got, err := parseSFXList("laser@0.5, explosion@1.25,powerup,")
+	if err != nil {
+		t.Fatalf("parseSFXList: %v", err)
+	}
+	want := []sfxEvent{{"laser", 0.5}, {"explosion", 1.25}, {"powerup", 0}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"laser@soon", "laser@-1", "@1"} {
+		if _, err := parseSFXList(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.wav")
+	if err := run([]string{"-genre", "fantasy", "-duration", "0.5", "-music", "0.5", "-sfx", "powerup@0.1", "-o", out}); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	pcm, err := audio.ReadWAV(f)
+	if err != nil {
+		t.Fatalf("ReadWAV: %v", err)
+	}
+	if len(pcm) != int(0.5*audio.SampleRate)*audio.BytesPerSample {
+		t.Errorf("unexpected render length %d", len(pcm))
+	}
+
+	if err := run([]string{"-sfx", "laser@x", "-o", out}); err == nil {
+		t.Error("expected a bad sound list to fail")
+	}
+}
@@ -3,6 +3,7 @@ package audio
 
 import (
 	"encoding/binary"
+	"io"
 	"math"
 	"sync"
 )
@@ -13,6 +14,9 @@ const (
 	SampleRate = 44100
 	// BytesPerSample is the number of bytes per sample (16-bit stereo).
 	BytesPerSample = 4
+	// MaxQueuedSFX is the number of sounds that can be queued between
+	// updates; the mixer decides which of them actually play.
+	MaxQueuedSFX = 128
 )
 
 // Pentatonic scale note frequencies in Hz.
@@ -39,6 +43,12 @@ type Manager struct {
 
 	intensity    float64 // Music intensity level (0.0-1.0)
 	musicPlaying bool
+	music        *Sequencer
+	seed         int64 // Seeds music and SFX variation
+
+	sfx           *SFXSynth // Built on first use from genreID and seed
+	mixer         *Mixer
+	mixerStreamed bool // Whether the mixer has been handed to the backend
 
 	sfxQueue []SFXRequest
 	sfxMu    sync.Mutex
@@ -46,6 +56,10 @@ type Manager struct {
 	playerX float64
 	playerY float64
 
+	listener Listener
+	spatial  SpatialConfig
+	occluder func(x1, y1, x2, y2 float64) bool
+
 	// audioBackend is the platform-specific audio backend (Ebiten or stub)
 	audioBackend AudioBackend
 }
@@ -54,6 +68,9 @@ type Manager struct {
 type AudioBackend interface {
 	// PlayBytes plays raw PCM audio data.
 	PlayBytes(data []byte)
+	// PlayStream plays PCM audio read from r until it returns io.EOF,
+	// replacing any stream already playing.
+	PlayStream(r io.Reader)
 	// Initialize initializes the audio backend.
 	Initialize()
 }
@@ -62,6 +79,7 @@ type AudioBackend interface {
 type SFXRequest struct {
 	Name    string
 	X, Y    float64 // Position for spatial audio
+	VX, VY  float64 // Velocity for Doppler
 	Spatial bool    // Whether to apply positional audio
 }
 
@@ -73,14 +91,35 @@ func NewManager() *Manager {
 		musicVolume:  0.6,
 		sfxVolume:    0.8,
 		sfxQueue:     make([]SFXRequest, 0, 16),
+		mixer:        NewMixer(),
+		spatial:      DefaultSpatialConfig(),
 		audioBackend: newAudioBackend(),
 	}
+	m.applyVolumes()
 	return m
 }
 
-// SetGenre switches audio assets to match the given genre.
+// SetGenre switches audio assets to match the given genre. Music already
+// playing keeps its genre until it is restarted.
 func (m *Manager) SetGenre(genreID string) {
 	m.genreID = genreID
+	m.sfx = nil
+}
+
+// SetSeed sets the seed music and sound effect variations are generated from.
+func (m *Manager) SetSeed(seed int64) {
+	m.seed = seed
+	m.sfx = nil
+}
+
+// synth returns the sound effect synthesizer for the current genre and
+// seed. It is built on first use, so setting both the genre and the seed
+// renders the preset bank once rather than once per setter.
+func (m *Manager) synth() *SFXSynth {
+	if m.sfx == nil {
+		m.sfx = NewSFXSynth(m.genreID, m.seed)
+	}
+	return m.sfx
 }
 
 // SetVolumes sets the master, music, and SFX volume levels.
@@ -88,11 +127,54 @@ func (m *Manager) SetVolumes(master, music, sfx float64) {
 	m.masterVolume = clampVolume(master)
 	m.musicVolume = clampVolume(music)
 	m.sfxVolume = clampVolume(sfx)
+	m.applyVolumes()
+}
+
+// applyVolumes pushes the volume settings to the mixer. UI and voice sounds
+// follow the SFX volume.
+func (m *Manager) applyVolumes() {
+	m.mixer.SetMasterVolume(m.masterVolume)
+	m.mixer.SetBusVolume(BusMusic, m.musicVolume)
+	m.mixer.SetBusVolume(BusSFX, m.sfxVolume)
+	m.mixer.SetBusVolume(BusUI, m.sfxVolume)
+	m.mixer.SetBusVolume(BusVoice, m.sfxVolume)
+}
+
+// SetBusVolume sets the volume of a single mixer bus.
+func (m *Manager) SetBusVolume(bus Bus, volume float64) {
+	m.mixer.SetBusVolume(bus, volume)
+}
+
+// Duck lowers the music by depth (0-1) for hold seconds so a big event
+// cuts through.
+func (m *Manager) Duck(depth, hold float64) {
+	m.mixer.Duck(depth, hold)
+}
+
+// Mixer returns the mixer all audio is played through.
+func (m *Manager) Mixer() *Mixer {
+	return m.mixer
 }
 
 // SetIntensity sets the music intensity level (0.0-1.0).
 func (m *Manager) SetIntensity(intensity float64) {
 	m.intensity = clampVolume(intensity)
+	if m.music != nil {
+		m.music.SetIntensity(m.intensity)
+	}
+}
+
+// CueMusic signals a game event to the music, which transitions on the
+// next bar.
+func (m *Manager) CueMusic(cue MusicCue) {
+	if m.music != nil {
+		m.music.Cue(cue)
+	}
+}
+
+// Music returns the playing sequencer, or nil when music is stopped.
+func (m *Manager) Music() *Sequencer {
+	return m.music
 }
 
 // SetPlayerPosition updates player position for spatial audio.
@@ -101,6 +183,30 @@ func (m *Manager) SetPlayerPosition(x, y float64) {
 	m.playerY = y
 }
 
+// SetListener moves the point positional sounds are heard from, usually
+// the center of the camera, and sets its velocity for Doppler.
+func (m *Manager) SetListener(x, y, vx, vy float64) {
+	m.listener = Listener{X: x, Y: y, VX: vx, VY: vy}
+}
+
+// SetSpatialConfig sets how positional sounds are attenuated, panned,
+// pitched and filtered.
+func (m *Manager) SetSpatialConfig(c SpatialConfig) {
+	m.spatial = c
+}
+
+// SpatialConfig returns the positional audio settings.
+func (m *Manager) SpatialConfig() SpatialConfig {
+	return m.spatial
+}
+
+// SetOccluder sets a function reporting whether the line between two points
+// is blocked, muffling positional sounds behind obstacles; nil disables
+// occlusion.
+func (m *Manager) SetOccluder(fn func(x1, y1, x2, y2 float64) bool) {
+	m.occluder = fn
+}
+
 // PlaySFX plays a named sound effect.
 func (m *Manager) PlaySFX(name string) {
 	m.PlaySFXAt(name, m.playerX, m.playerY, false)
@@ -111,8 +217,9 @@ func (m *Manager) PlaySFXAt(name string, x, y float64, spatial bool) {
 	m.sfxMu.Lock()
 	defer m.sfxMu.Unlock()
 
-	// Limit queue size to prevent memory issues
-	if len(m.sfxQueue) < 32 {
+	// Limit queue size to prevent memory issues; voice limits are
+	// enforced by the mixer
+	if len(m.sfxQueue) < MaxQueuedSFX {
 		m.sfxQueue = append(m.sfxQueue, SFXRequest{
 			Name:    name,
 			X:       x,
@@ -122,13 +229,43 @@ func (m *Manager) PlaySFXAt(name string, x, y float64, spatial bool) {
 	}
 }
 
-// PlayMusic starts adaptive background music.
+// PlaySFXMoving plays a positional sound effect from a source moving with
+// velocity (vx, vy), so it is Doppler shifted against the listener.
+func (m *Manager) PlaySFXMoving(name string, x, y, vx, vy float64) {
+	m.sfxMu.Lock()
+	defer m.sfxMu.Unlock()
+
+	if len(m.sfxQueue) < MaxQueuedSFX {
+		m.sfxQueue = append(m.sfxQueue, SFXRequest{
+			Name:    name,
+			X:       x,
+			Y:       y,
+			VX:      vx,
+			VY:      vy,
+			Spatial: true,
+		})
+	}
+}
+
+// PlayMusic starts adaptive background music for the current genre,
+// restarting it if already playing.
 func (m *Manager) PlayMusic() {
+	if m.music != nil {
+		m.music.Stop()
+	}
+	m.music = NewSequencer(m.genreID, m.seed)
+	m.music.SetIntensity(m.intensity)
+	m.mixer.SetMusic(m.music)
 	m.musicPlaying = true
 }
 
 // StopMusic stops background music.
 func (m *Manager) StopMusic() {
+	if m.music != nil {
+		m.music.Stop()
+		m.music = nil
+	}
+	m.mixer.SetMusic(nil)
 	m.musicPlaying = false
 }
 
@@ -137,6 +274,10 @@ func (m *Manager) Update() {
 	// Lazy-initialize audio backend on first update
 	if m.audioBackend != nil {
 		m.audioBackend.Initialize()
+		if !m.mixerStreamed {
+			m.audioBackend.PlayStream(m.mixer)
+			m.mixerStreamed = true
+		}
 	}
 
 	// Process any queued SFX
@@ -152,29 +293,24 @@ func (m *Manager) Update() {
 	}
 }
 
-// playSFXNow plays a sound effect immediately using the audio backend.
+// playSFXNow starts a sound effect in the mixer. Bus and master volumes are
+// applied by the mixer, so only position affects the voice.
 func (m *Manager) playSFXNow(req SFXRequest) {
-	if m.audioBackend == nil {
-		return
+	params := VoiceParams{Volume: 1, Pitch: 1}
+	if req.Spatial {
+		src := SoundSource{X: req.X, Y: req.Y, VX: req.VX, VY: req.VY}
+		occluded := m.occluder != nil && m.occluder(m.listener.X, m.listener.Y, req.X, req.Y)
+		params = m.spatial.Compute(m.listener, src, occluded)
+		if params.Volume <= 0 {
+			return
+		}
 	}
 
-	// Get the raw PCM data for this SFX
-	data := GetSFXData(req.Name)
+	data := m.synth().Render(req.Name)
 	if len(data) == 0 {
 		return
 	}
-
-	// Apply spatial audio if requested
-	if req.Spatial {
-		vol, pan := CalculateSpatialVolume(m.playerX, m.playerY, req.X, req.Y, 500.0)
-		data = ApplySpatialAudio(data, vol*m.sfxVolume*m.masterVolume, pan)
-	} else {
-		// Apply volume scaling for non-spatial audio
-		data = ApplySpatialAudio(data, m.sfxVolume*m.masterVolume, 0)
-	}
-
-	// Play the audio data
-	m.audioBackend.PlayBytes(data)
+	m.mixer.PlayVoice(req.Name, data, params)
 }
 
 // GenerateTone creates PCM audio data for a simple tone.
@@ -199,133 +335,38 @@ func GenerateTone(frequency, duration float64) []byte {
 	return buf
 }
 
-// GenerateLaserSFX creates PCM data for a laser sound.
-func GenerateLaserSFX() []byte {
-	duration := 0.1
-	numSamples := int(duration * SampleRate)
-	buf := make([]byte, numSamples*BytesPerSample)
-
-	baseFreq := 880.0 // A5
-
-	for i := 0; i < numSamples; i++ {
-		t := float64(i) / SampleRate
-		progress := float64(i) / float64(numSamples)
-
-		// Frequency sweep down
-		freq := baseFreq * (1.0 - progress*0.5)
-
-		// Square wave for laser sound
-		sample := squareWave(2 * math.Pi * freq * t)
+// defaultSFXBank holds the unstyled presets used by the package-level
+// generators.
+var defaultSFXBank = NewSFXBank("scifi")
 
-		// Apply envelope
-		envelope := applyEnvelope(i, numSamples)
-		sample *= envelope * 0.3
-
-		intSample := int16(sample * 32767)
-		binary.LittleEndian.PutUint16(buf[i*4:], uint16(intSample))
-		binary.LittleEndian.PutUint16(buf[i*4+2:], uint16(intSample))
-	}
+// defaultSFXSynth caches the renders returned by GetSFXData.
+var defaultSFXSynth = NewSFXSynth("scifi", 0)
 
-	return buf
+// GenerateLaserSFX creates PCM data for a laser sound.
+func GenerateLaserSFX() []byte {
+	return Synthesize(defaultSFXBank.Preset("laser"))
 }
 
 // GenerateExplosionSFX creates PCM data for an explosion sound.
 func GenerateExplosionSFX() []byte {
-	duration := 0.3
-	numSamples := int(duration * SampleRate)
-	buf := make([]byte, numSamples*BytesPerSample)
-
-	for i := 0; i < numSamples; i++ {
-		progress := float64(i) / float64(numSamples)
-
-		// White noise burst with exponential decay
-		noise := (pseudoRandom(uint32(i)) - 0.5) * 2.0
-		envelope := math.Exp(-progress * 5.0)
-
-		// Add low frequency rumble
-		t := float64(i) / SampleRate
-		rumble := math.Sin(2*math.Pi*60*t) * 0.3
-
-		sample := (noise*0.7 + rumble) * envelope * 0.4
-
-		intSample := int16(clamp(sample, -1, 1) * 32767)
-		binary.LittleEndian.PutUint16(buf[i*4:], uint16(intSample))
-		binary.LittleEndian.PutUint16(buf[i*4+2:], uint16(intSample))
-	}
-
-	return buf
+	return Synthesize(defaultSFXBank.Preset("explosion"))
 }
 
 // GeneratePowerupSFX creates PCM data for a powerup collect sound.
 func GeneratePowerupSFX() []byte {
-	duration := 0.2
-	numSamples := int(duration * SampleRate)
-	buf := make([]byte, numSamples*BytesPerSample)
-
-	baseFreq := 440.0
-
-	for i := 0; i < numSamples; i++ {
-		t := float64(i) / SampleRate
-		progress := float64(i) / float64(numSamples)
-
-		// Rising frequency sweep
-		freq := baseFreq * (1.0 + progress*1.5)
-
-		// Sine wave for pleasing sound
-		sample := math.Sin(2 * math.Pi * freq * t)
-
-		envelope := applyEnvelope(i, numSamples)
-		sample *= envelope * 0.4
-
-		intSample := int16(sample * 32767)
-		binary.LittleEndian.PutUint16(buf[i*4:], uint16(intSample))
-		binary.LittleEndian.PutUint16(buf[i*4+2:], uint16(intSample))
-	}
-
-	return buf
+	return Synthesize(defaultSFXBank.Preset("powerup"))
 }
 
 // GenerateMenuSelectSFX creates PCM data for a menu selection sound.
 func GenerateMenuSelectSFX() []byte {
-	duration := 0.05
-	numSamples := int(duration * SampleRate)
-	buf := make([]byte, numSamples*BytesPerSample)
-
-	freq := 660.0 // E5
-
-	for i := 0; i < numSamples; i++ {
-		t := float64(i) / SampleRate
-		sample := math.Sin(2 * math.Pi * freq * t)
-
-		envelope := applyEnvelope(i, numSamples)
-		sample *= envelope * 0.3
-
-		intSample := int16(sample * 32767)
-		binary.LittleEndian.PutUint16(buf[i*4:], uint16(intSample))
-		binary.LittleEndian.PutUint16(buf[i*4+2:], uint16(intSample))
-	}
-
-	return buf
+	return Synthesize(defaultSFXBank.Preset("menu_select"))
 }
 
-// GetSFXData returns PCM data for a named sound effect.
+// GetSFXData returns PCM data for a named sound effect from the default
+// preset bank. Unknown names play DefaultSFXPreset. Each sound is rendered
+// once and the returned slice is shared, so it must not be modified.
 func GetSFXData(name string) []byte {
-	switch name {
-	case "laser", "fire", "shoot":
-		return GenerateLaserSFX()
-	case "explosion", "death", "enemy_death":
-		return GenerateExplosionSFX()
-	case "powerup", "pickup", "collect":
-		return GeneratePowerupSFX()
-	case "menu_select", "select", "ui_click":
-		return GenerateMenuSelectSFX()
-	case "wave_start":
-		return GenerateTone(440.0, 0.2)
-	case "wave_complete":
-		return GeneratePowerupSFX()
-	default:
-		return GenerateTone(440.0, 0.1)
-	}
+	return defaultSFXSynth.Variant(name, 0)
 }
 
 // CalculateSpatialVolume returns volume and pan based on distance and angle.
@@ -3,13 +3,21 @@
 package audio
 
 import (
+	"io"
+	"time"
+
 	"github.com/hajimehoshi/ebiten/v2/audio"
 )
 
+// StreamBufferDuration is how far ahead streamed music is buffered. Short
+// buffers let intensity changes and cues be heard promptly.
+const StreamBufferDuration = 100 * time.Millisecond
+
 // ebitenBackend implements AudioBackend using Ebitengine's audio system.
 type ebitenBackend struct {
 	context     *audio.Context
 	initialized bool
+	stream      *audio.Player
 }
 
 // newAudioBackend creates the Ebiten-based audio backend.
@@ -34,3 +42,21 @@ func (b *ebitenBackend) PlayBytes(data []byte) {
 	player := b.context.NewPlayerFromBytes(data)
 	player.Play()
 }
+
+// PlayStream streams PCM audio from r, closing any previous stream.
+func (b *ebitenBackend) PlayStream(r io.Reader) {
+	if b.context == nil {
+		return
+	}
+	if b.stream != nil {
+		b.stream.Close()
+		b.stream = nil
+	}
+	player, err := b.context.NewPlayer(r)
+	if err != nil {
+		return
+	}
+	player.SetBufferSize(StreamBufferDuration)
+	player.Play()
+	b.stream = player
+}
@@ -2,6 +2,8 @@
 
 package audio
 
+import "io"
+
 // stubBackend implements AudioBackend as a no-op for headless/test environments.
 type stubBackend struct{}
 
@@ -15,3 +17,6 @@ func (b *stubBackend) Initialize() {}
 
 // PlayBytes is a no-op for the stub backend.
 func (b *stubBackend) PlayBytes(data []byte) {}
+
+// PlayStream is a no-op for the stub backend.
+func (b *stubBackend) PlayStream(r io.Reader) {}
@@ -174,6 +174,14 @@ func TestGetSFXData(t *testing.T) {
 	}
 }
 
+func TestGetSFXData_Cached(t *testing.T) {
+	a := GetSFXData("laser")
+	b := GetSFXData("laser")
+	if len(a) == 0 || &a[0] != &b[0] {
+		t.Error("expected repeated calls to return the cached render")
+	}
+}
+
 func TestCalculateSpatialVolume(t *testing.T) {
 	tests := []struct {
 		name       string
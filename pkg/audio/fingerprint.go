@@ -0,0 +1,132 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"math/cmplx"
+)
+
+// Spectral fingerprint constants.
+const (
+	// FingerprintWindow is the number of frames analyzed per fingerprint row.
+	FingerprintWindow = 4096
+	// FingerprintBands is the number of log-spaced frequency bands per row.
+	FingerprintBands = 16
+	// FingerprintMinFreq and FingerprintMaxFreq bound the analyzed spectrum in Hz.
+	FingerprintMinFreq = 50.0
+	FingerprintMaxFreq = 16000.0
+	// FingerprintFloor is the level in dB that silence is clamped to.
+	FingerprintFloor = -90.0
+)
+
+// Fingerprint summarizes audio as band energies over time, in dB relative
+// to a full-scale sine. It changes when the sound's timing, pitch or timbre
+// changes but tolerates tiny floating-point differences between platforms,
+// which makes it suitable for golden-file tests.
+type Fingerprint struct {
+	Rows [][]float64 `json:"rows"` // One row of FingerprintBands levels per window
+}
+
+// SpectralFingerprint computes the fingerprint of 16-bit stereo PCM. The
+// channels are mixed to mono and each FingerprintWindow frames are analyzed
+// with a Hann-windowed FFT; a trailing partial window is ignored.
+func SpectralFingerprint(pcm []byte) Fingerprint {
+	frames := len(pcm) / BytesPerSample
+	edges := fingerprintBandEdges()
+
+	window := make([]float64, FingerprintWindow)
+	for i := range window {
+		window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(FingerprintWindow))
+	}
+	// Energy of a full-scale sine across its bins: by Parseval, N/2 times
+	// the windowed signal's power (mean Hann² is 3/8, mean sine² is 1/2)
+	norm := float64(FingerprintWindow) * FingerprintWindow * 3 / 32
+
+	var fp Fingerprint
+	buf := make([]complex128, FingerprintWindow)
+	for start := 0; start+FingerprintWindow <= frames; start += FingerprintWindow {
+		for i := range buf {
+			off := (start + i) * BytesPerSample
+			l := float64(int16(binary.LittleEndian.Uint16(pcm[off:]))) / 32768
+			r := float64(int16(binary.LittleEndian.Uint16(pcm[off+2:]))) / 32768
+			buf[i] = complex((l+r)/2*window[i], 0)
+		}
+		fft(buf)
+
+		row := make([]float64, FingerprintBands)
+		for b := range row {
+			energy := 0.0
+			for k := edges[b]; k < edges[b+1]; k++ {
+				energy += math.Pow(cmplx.Abs(buf[k]), 2)
+			}
+			row[b] = math.Max(10*math.Log10(energy/norm+1e-30), FingerprintFloor)
+		}
+		fp.Rows = append(fp.Rows, row)
+	}
+	return fp
+}
+
+// fingerprintBandEdges returns the FFT bins bounding each band. Every band
+// covers at least one bin.
+func fingerprintBandEdges() []int {
+	binHz := float64(SampleRate) / FingerprintWindow
+	edges := make([]int, FingerprintBands+1)
+	for b := range edges {
+		f := FingerprintMinFreq * math.Pow(FingerprintMaxFreq/FingerprintMinFreq, float64(b)/FingerprintBands)
+		edges[b] = int(math.Round(f / binHz))
+		if b > 0 && edges[b] <= edges[b-1] {
+			edges[b] = edges[b-1] + 1
+		}
+	}
+	return edges
+}
+
+// Compare returns the mean and largest absolute difference in dB between
+// two fingerprints. Fingerprints of different lengths differ infinitely.
+func (f Fingerprint) Compare(other Fingerprint) (mean, worst float64) {
+	if len(f.Rows) != len(other.Rows) {
+		return math.Inf(1), math.Inf(1)
+	}
+	n := 0
+	for i, row := range f.Rows {
+		if len(row) != len(other.Rows[i]) {
+			return math.Inf(1), math.Inf(1)
+		}
+		for b, v := range row {
+			d := math.Abs(v - other.Rows[i][b])
+			mean += d
+			worst = math.Max(worst, d)
+			n++
+		}
+	}
+	if n > 0 {
+		mean /= float64(n)
+	}
+	return mean, worst
+}
+
+// fft performs an in-place radix-2 FFT; len(x) must be a power of two.
+func fft(x []complex128) {
+	n := len(x)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Rect(1, -2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				a, b := x[start+k], x[start+k+size/2]*w
+				x[start+k], x[start+k+size/2] = a+b, a-b
+				w *= step
+			}
+		}
+	}
+}
@@ -0,0 +1,396 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+)
+
+// Bus groups sounds that share a volume control.
+type Bus int
+
+// Mixer buses.
+const (
+	BusMusic Bus = iota
+	BusSFX
+	BusUI
+	BusVoice
+	BusCount
+)
+
+// Mixer constants.
+const (
+	// MaxVoices is the number of sounds that can play at once across all buses.
+	MaxVoices = 24
+	// MixChunkFrames is the number of frames mixed per pass.
+	MixChunkFrames = 512
+	// DuckAttack is how quickly music ducks when a big event fires, in seconds.
+	DuckAttack = 0.02
+	// DuckRelease is how long ducked music takes to recover, in seconds.
+	DuckRelease = 0.5
+	// LimiterThreshold is the master level above which the limiter reduces gain.
+	LimiterThreshold = 0.8
+	// LimiterRelease is how long the limiter takes to recover after a peak, in seconds.
+	LimiterRelease = 0.15
+)
+
+// SoundPolicy controls how a named sound competes for voices.
+type SoundPolicy struct {
+	Bus       Bus
+	Priority  int // Higher priorities steal voices from lower ones
+	MaxVoices int // Instances of this sound allowed at once
+}
+
+// soundPolicies lists the policies of the game's sounds. Unlisted sounds use
+// defaultSoundPolicy.
+var soundPolicies = map[string]SoundPolicy{
+	"laser":         {Bus: BusSFX, Priority: 1, MaxVoices: 6},
+	"fire":          {Bus: BusSFX, Priority: 1, MaxVoices: 6},
+	"shoot":         {Bus: BusSFX, Priority: 1, MaxVoices: 6},
+	"explosion":     {Bus: BusSFX, Priority: 2, MaxVoices: 5},
+	"death":         {Bus: BusSFX, Priority: 2, MaxVoices: 5},
+	"enemy_death":   {Bus: BusSFX, Priority: 2, MaxVoices: 5},
+	"hit":           {Bus: BusSFX, Priority: 3, MaxVoices: 2},
+	"powerup":       {Bus: BusSFX, Priority: 3, MaxVoices: 2},
+	"pickup":        {Bus: BusSFX, Priority: 3, MaxVoices: 2},
+	"collect":       {Bus: BusSFX, Priority: 3, MaxVoices: 2},
+	"wave_start":    {Bus: BusUI, Priority: 4, MaxVoices: 1},
+	"wave_complete": {Bus: BusUI, Priority: 4, MaxVoices: 1},
+	"menu_select":   {Bus: BusUI, Priority: 5, MaxVoices: 2},
+	"select":        {Bus: BusUI, Priority: 5, MaxVoices: 2},
+	"ui_click":      {Bus: BusUI, Priority: 5, MaxVoices: 2},
+}
+
+// defaultSoundPolicy applies to sounds without an entry in soundPolicies.
+var defaultSoundPolicy = SoundPolicy{Bus: BusSFX, Priority: 1, MaxVoices: 4}
+
+// GetSoundPolicy returns the bus, priority and voice limit for a sound.
+func GetSoundPolicy(name string) SoundPolicy {
+	if p, ok := soundPolicies[name]; ok {
+		return p
+	}
+	return defaultSoundPolicy
+}
+
+// VoiceParams controls how a single sound is played.
+type VoiceParams struct {
+	Volume  float64
+	Pan     float64 // -1 left to 1 right
+	Pitch   float64 // Playback rate; 0 plays at normal speed
+	LowPass float64 // Cutoff in Hz; 0 disables
+}
+
+// mixVoice is a sound playing in the mixer.
+type mixVoice struct {
+	name     string
+	samples  []float32 // Interleaved stereo, -1 to 1
+	pos      float64   // Next frame to play
+	pitch    float64
+	left     float64
+	right    float64
+	lowPass  float64 // One-pole filter coefficient; 0 bypasses
+	filtered [2]float64
+	policy   SoundPolicy
+	started  uint64 // Play order, for stealing the oldest voice
+}
+
+// Mixer mixes music and sound effects into a single 16-bit stereo stream.
+// Each bus has its own volume, sounds compete for a fixed number of voices
+// by priority, big events can duck the music, and a limiter on the master
+// bus keeps many simultaneous sounds from clipping. Mixer implements
+// io.Reader and never runs dry.
+type Mixer struct {
+	mu      sync.Mutex
+	master  float64
+	buses   [BusCount]float64
+	music   *Sequencer
+	voices  []mixVoice
+	plays   uint64
+	dropped int
+
+	duckDepth  float64 // Requested music reduction (0-1)
+	duckHold   int     // Frames left before the duck releases
+	duckLevel  float64 // Current music reduction (0-1)
+	limitGain  float64 // Current limiter gain (0-1)
+	musicChunk []float64
+	mixChunk   []float64
+}
+
+// NewMixer creates a mixer with every bus at full volume.
+func NewMixer() *Mixer {
+	m := &Mixer{
+		master:     1,
+		limitGain:  1,
+		voices:     make([]mixVoice, 0, MaxVoices),
+		musicChunk: make([]float64, MixChunkFrames),
+		mixChunk:   make([]float64, MixChunkFrames*2),
+	}
+	for i := range m.buses {
+		m.buses[i] = 1
+	}
+	return m
+}
+
+// SetMasterVolume sets the volume applied after all buses are mixed.
+func (m *Mixer) SetMasterVolume(volume float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.master = clampVolume(volume)
+}
+
+// SetBusVolume sets the volume of one bus.
+func (m *Mixer) SetBusVolume(bus Bus, volume float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.buses[bus] = clampVolume(volume)
+}
+
+// BusVolume returns the volume of one bus.
+func (m *Mixer) BusVolume(bus Bus) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.buses[bus]
+}
+
+// SetMusic sets the sequencer played on the music bus; nil silences it.
+func (m *Mixer) SetMusic(s *Sequencer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.music = s
+}
+
+// Play starts a sound from 16-bit stereo PCM at the given volume and pan
+// (-1 left to 1 right). It returns false if the sound lost out to its voice
+// limit or to higher-priority sounds.
+func (m *Mixer) Play(name string, pcm []byte, volume, pan float64) bool {
+	return m.PlayVoice(name, pcm, VoiceParams{Volume: volume, Pan: pan, Pitch: 1})
+}
+
+// PlayVoice starts a sound from 16-bit stereo PCM with pitch and filtering,
+// as computed for positional sounds by SpatialConfig.Compute.
+func (m *Mixer) PlayVoice(name string, pcm []byte, params VoiceParams) bool {
+	policy := GetSoundPolicy(name)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Too many of this sound: replace its oldest instance so rapid fire
+	// keeps sounding fresh without stacking up
+	if idx, count := m.oldestVoice(func(v *mixVoice) bool { return v.name == name }); count >= policy.MaxVoices {
+		if policy.MaxVoices == 0 {
+			m.dropped++
+			return false
+		}
+		m.removeVoice(idx)
+	}
+
+	// No free voices: steal the oldest voice of the lowest priority, if it
+	// is not more important than this sound
+	if len(m.voices) >= MaxVoices {
+		lowest := policy.Priority
+		for _, v := range m.voices {
+			if v.policy.Priority < lowest {
+				lowest = v.policy.Priority
+			}
+		}
+		idx, count := m.oldestVoice(func(v *mixVoice) bool { return v.policy.Priority == lowest })
+		if count == 0 {
+			m.dropped++
+			return false
+		}
+		m.removeVoice(idx)
+	}
+
+	m.plays++
+	pan := clamp(params.Pan, -1, 1)
+	pitch := params.Pitch
+	if pitch <= 0 {
+		pitch = 1
+	}
+	lowPass := 0.0
+	if params.LowPass > 0 && params.LowPass < SampleRate/2 {
+		lowPass = 1 - math.Exp(-2*math.Pi*params.LowPass/SampleRate)
+	}
+	m.voices = append(m.voices, mixVoice{
+		name:    name,
+		samples: decodePCM(pcm),
+		pitch:   pitch,
+		left:    params.Volume * (1 - math.Max(0, pan)),
+		right:   params.Volume * (1 + math.Min(0, pan)),
+		lowPass: lowPass,
+		policy:  policy,
+		started: m.plays,
+	})
+	return true
+}
+
+// oldestVoice returns the index of the oldest voice matching fn and how
+// many voices match; m.mu must be held.
+func (m *Mixer) oldestVoice(fn func(v *mixVoice) bool) (int, int) {
+	idx, count := -1, 0
+	for i := range m.voices {
+		if !fn(&m.voices[i]) {
+			continue
+		}
+		if idx < 0 || m.voices[i].started < m.voices[idx].started {
+			idx = i
+		}
+		count++
+	}
+	return idx, count
+}
+
+// removeVoice stops a voice; m.mu must be held.
+func (m *Mixer) removeVoice(i int) {
+	m.voices = append(m.voices[:i], m.voices[i+1:]...)
+}
+
+// Duck lowers the music by depth (0-1) for hold seconds, then lets it
+// recover. Overlapping ducks keep the deepest depth and longest hold.
+func (m *Mixer) Duck(depth, hold float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.duckDepth = math.Max(m.duckDepth, clampVolume(depth))
+	if frames := int(hold * SampleRate); frames > m.duckHold {
+		m.duckHold = frames
+	}
+}
+
+// DuckLevel returns how far the music is currently ducked (0-1).
+func (m *Mixer) DuckLevel() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.duckLevel
+}
+
+// ActiveVoices returns the number of sounds playing.
+func (m *Mixer) ActiveVoices() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.voices)
+}
+
+// Dropped returns how many sounds were refused a voice.
+func (m *Mixer) Dropped() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.dropped
+}
+
+// Read fills p with the mix as interleaved 16-bit little-endian stereo.
+func (m *Mixer) Read(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	frames := len(p) / BytesPerSample
+	for done := 0; done < frames; {
+		n := frames - done
+		if n > MixChunkFrames {
+			n = MixChunkFrames
+		}
+		m.mix(n)
+		for i := 0; i < n; i++ {
+			off := (done + i) * BytesPerSample
+			binary.LittleEndian.PutUint16(p[off:], uint16(int16(m.mixChunk[2*i]*32767)))
+			binary.LittleEndian.PutUint16(p[off+2:], uint16(int16(m.mixChunk[2*i+1]*32767)))
+		}
+		done += n
+	}
+	return frames * BytesPerSample, nil
+}
+
+// mix renders n frames into m.mixChunk; m.mu must be held.
+func (m *Mixer) mix(n int) {
+	out := m.mixChunk[:2*n]
+	for i := range out {
+		out[i] = 0
+	}
+
+	// Music, ducked under big events
+	music := m.musicChunk[:n]
+	if m.music != nil {
+		m.music.render(music)
+	} else {
+		for i := range music {
+			music[i] = 0
+		}
+	}
+	attack := 1 / (DuckAttack * SampleRate)
+	release := 1 / (DuckRelease * SampleRate)
+	for i := 0; i < n; i++ {
+		if m.duckHold > 0 {
+			m.duckHold--
+			m.duckLevel = math.Min(m.duckDepth, m.duckLevel+attack)
+		} else {
+			m.duckDepth = 0
+			m.duckLevel = math.Max(0, m.duckLevel-release)
+		}
+		v := music[i] * m.buses[BusMusic] * (1 - m.duckLevel)
+		out[2*i] += v
+		out[2*i+1] += v
+	}
+
+	// Sound effects, resampled for pitch and optionally low-passed
+	alive := m.voices[:0]
+	for _, v := range m.voices {
+		bus := m.buses[v.policy.Bus]
+		gain := [2]float64{v.left * bus, v.right * bus}
+		last := float64(len(v.samples)/2 - 1)
+		for i := 0; i < n && v.pos < last; i++ {
+			frame := int(v.pos)
+			frac := v.pos - float64(frame)
+			for ch := 0; ch < 2; ch++ {
+				a := float64(v.samples[2*frame+ch])
+				s := a + (float64(v.samples[2*frame+2+ch])-a)*frac
+				if v.lowPass > 0 {
+					v.filtered[ch] += v.lowPass * (s - v.filtered[ch])
+					s = v.filtered[ch]
+				}
+				out[2*i+ch] += s * gain[ch]
+			}
+			v.pos += v.pitch
+		}
+		if v.pos < last {
+			alive = append(alive, v)
+		}
+	}
+	for i := len(alive); i < len(m.voices); i++ {
+		m.voices[i] = mixVoice{}
+	}
+	m.voices = alive
+
+	// Master volume and limiter: gain drops at once to hold peaks at the
+	// threshold and recovers smoothly, with a soft clip catching the rest
+	recover := 1 / (LimiterRelease * SampleRate)
+	for i := 0; i < n; i++ {
+		l, r := out[2*i]*m.master, out[2*i+1]*m.master
+		peak := math.Max(math.Abs(l), math.Abs(r))
+		if target := LimiterThreshold / math.Max(peak, LimiterThreshold); target < m.limitGain {
+			m.limitGain = target
+		} else {
+			m.limitGain = math.Min(1, m.limitGain+recover)
+		}
+		out[2*i] = softClip(l * m.limitGain)
+		out[2*i+1] = softClip(r * m.limitGain)
+	}
+}
+
+// softClip passes quiet signals unchanged and bends anything above the
+// limiter threshold smoothly toward full scale.
+func softClip(x float64) float64 {
+	a := math.Abs(x)
+	if a <= LimiterThreshold {
+		return x
+	}
+	knee := 1 - LimiterThreshold
+	return math.Copysign(LimiterThreshold+knee*math.Tanh((a-LimiterThreshold)/knee), x)
+}
+
+// decodePCM converts 16-bit little-endian stereo PCM to interleaved floats.
+func decodePCM(pcm []byte) []float32 {
+	samples := make([]float32, len(pcm)/2)
+	for i := range samples {
+		samples[i] = float32(int16(binary.LittleEndian.Uint16(pcm[2*i:]))) / 32768
+	}
+	return samples
+}
@@ -0,0 +1,226 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// constantPCM returns seconds of 16-bit stereo PCM holding a constant level.
+func constantPCM(level, seconds float64) []byte {
+	frames := int(seconds * SampleRate)
+	buf := make([]byte, frames*BytesPerSample)
+	v := uint16(int16(level * 32767))
+	for i := 0; i < len(buf); i += 2 {
+		binary.LittleEndian.PutUint16(buf[i:], v)
+	}
+	return buf
+}
+
+// peak returns the largest absolute sample in pcm (0-1).
+func peak(pcm []byte) float64 {
+	p := 0.0
+	for i := 0; i+1 < len(pcm); i += 2 {
+		p = math.Max(p, math.Abs(float64(int16(binary.LittleEndian.Uint16(pcm[i:])))/32768))
+	}
+	return p
+}
+
+func TestMixer_SilentWhenIdle(t *testing.T) {
+	m := NewMixer()
+	if p := peak(readSeconds(t, m, 0.1)); p != 0 {
+		t.Errorf("expected silence from an idle mixer, got peak %f", p)
+	}
+}
+
+func TestMixer_PerSoundVoiceLimit(t *testing.T) {
+	m := NewMixer()
+	limit := GetSoundPolicy("explosion").MaxVoices
+	for i := 0; i < 50; i++ {
+		m.Play("explosion", constantPCM(0.1, 1), 1, 0)
+	}
+	if got := m.ActiveVoices(); got != limit {
+		t.Errorf("expected %d explosion voices, got %d", limit, got)
+	}
+}
+
+func TestMixer_PriorityStealing(t *testing.T) {
+	soundPolicies["test_low"] = SoundPolicy{Bus: BusSFX, Priority: 0, MaxVoices: MaxVoices}
+	soundPolicies["test_high"] = SoundPolicy{Bus: BusSFX, Priority: 9, MaxVoices: MaxVoices}
+	defer delete(soundPolicies, "test_low")
+	defer delete(soundPolicies, "test_high")
+
+	m := NewMixer()
+	pcm := constantPCM(0.1, 1)
+	for i := 0; i < MaxVoices; i++ {
+		if !m.Play("test_high", pcm, 1, 0) {
+			t.Fatalf("voice %d refused with free voices", i)
+		}
+	}
+	if m.Play("test_low", pcm, 1, 0) {
+		t.Error("expected a low-priority sound to be dropped when voices are full")
+	}
+	if m.Dropped() != 1 {
+		t.Errorf("expected 1 dropped sound, got %d", m.Dropped())
+	}
+
+	m = NewMixer()
+	for i := 0; i < MaxVoices; i++ {
+		m.Play("test_low", pcm, 1, 0)
+	}
+	if !m.Play("test_high", pcm, 1, 0) {
+		t.Error("expected a high-priority sound to steal a voice")
+	}
+	if m.ActiveVoices() != MaxVoices {
+		t.Errorf("expected %d voices, got %d", MaxVoices, m.ActiveVoices())
+	}
+}
+
+func TestMixer_VoicesFinish(t *testing.T) {
+	m := NewMixer()
+	m.Play("laser", constantPCM(0.1, 0.05), 1, 0)
+	readSeconds(t, m, 0.1)
+	if m.ActiveVoices() != 0 {
+		t.Errorf("expected finished voice to be freed, got %d active", m.ActiveVoices())
+	}
+}
+
+func TestMixer_BusVolume(t *testing.T) {
+	m := NewMixer()
+	m.SetBusVolume(BusSFX, 0.5)
+	m.Play("laser", constantPCM(0.2, 0.1), 1, 0)
+	if p := peak(readSeconds(t, m, 0.05)); math.Abs(p-0.1) > 0.01 {
+		t.Errorf("expected bus volume to halve the level, got peak %f", p)
+	}
+
+	m = NewMixer()
+	m.SetBusVolume(BusSFX, 0)
+	m.Play("laser", constantPCM(0.2, 0.1), 1, 0)
+	m.Play("wave_start", constantPCM(0.2, 0.1), 1, 0)
+	if p := peak(readSeconds(t, m, 0.05)); math.Abs(p-0.2) > 0.01 {
+		t.Errorf("expected only the UI bus to sound, got peak %f", p)
+	}
+	if m.BusVolume(BusUI) != 1 {
+		t.Error("expected UI bus to keep its volume")
+	}
+}
+
+func TestMixer_Pan(t *testing.T) {
+	m := NewMixer()
+	m.Play("laser", constantPCM(0.2, 0.1), 1, 1)
+	pcm := readSeconds(t, m, 0.01)
+	left := int16(binary.LittleEndian.Uint16(pcm[0:]))
+	right := int16(binary.LittleEndian.Uint16(pcm[2:]))
+	if left != 0 || right == 0 {
+		t.Errorf("expected hard right pan, got left %d right %d", left, right)
+	}
+}
+
+func TestMixer_LimiterPreventsClipping(t *testing.T) {
+	m := NewMixer()
+	for i := 0; i < MaxVoices; i++ {
+		m.Play("explosion", constantPCM(0.9, 1), 1, 0)
+		m.Play("laser", constantPCM(0.9, 1), 1, 0)
+	}
+	pcm := readSeconds(t, m, 0.5)
+	for i := 0; i < len(pcm); i += 2 {
+		if v := int16(binary.LittleEndian.Uint16(pcm[i:])); v <= 0 {
+			t.Fatalf("sample %d wrapped or dropped to %d", i/2, v)
+		}
+	}
+	if p := peak(pcm); p > 1 || p < LimiterThreshold*0.9 {
+		t.Errorf("expected limited peak near threshold, got %f", p)
+	}
+}
+
+func TestSoftClip(t *testing.T) {
+	if softClip(0.5) != 0.5 {
+		t.Error("expected quiet signals to pass unchanged")
+	}
+	prev := 0.0
+	for x := 0.0; x < 10; x += 0.1 {
+		y := softClip(x)
+		if y < prev || y > 1 {
+			t.Fatalf("softClip(%f) = %f not monotonic within full scale", x, y)
+		}
+		if softClip(-x) != -y {
+			t.Fatalf("softClip not symmetric at %f", x)
+		}
+		prev = y
+	}
+}
+
+func TestMixer_Duck(t *testing.T) {
+	m := NewMixer()
+	m.SetMusic(NewSequencer("scifi", 1))
+	m.Duck(0.6, 0.2)
+	readSeconds(t, m, 0.1)
+	if got := m.DuckLevel(); math.Abs(got-0.6) > 1e-9 {
+		t.Errorf("expected music ducked to 0.6, got %f", got)
+	}
+
+	// A shallower duck does not lift an active one
+	m.Duck(0.2, 0.05)
+	readSeconds(t, m, 0.05)
+	if got := m.DuckLevel(); math.Abs(got-0.6) > 1e-9 {
+		t.Errorf("expected deepest duck to win, got %f", got)
+	}
+
+	readSeconds(t, m, 0.05+DuckRelease+0.1)
+	if got := m.DuckLevel(); got != 0 {
+		t.Errorf("expected music to recover, got duck level %f", got)
+	}
+}
+
+func TestMixer_DuckLowersMusic(t *testing.T) {
+	plain := NewMixer()
+	plain.SetMusic(NewSequencer("scifi", 3))
+	ducked := NewMixer()
+	ducked.SetMusic(NewSequencer("scifi", 3))
+	ducked.Duck(1, 1)
+
+	// Skip the attack, then compare
+	readSeconds(t, plain, DuckAttack*2)
+	readSeconds(t, ducked, DuckAttack*2)
+	full := rms(readSeconds(t, plain, 0.5))
+	if full == 0 {
+		t.Fatal("expected music from the mixer")
+	}
+	if r := rms(readSeconds(t, ducked, 0.5)); r > full*0.01 {
+		t.Errorf("expected fully ducked music to be near silent, got rms %f of %f", r, full)
+	}
+}
+
+func TestManager_SFXPlaysThroughMixer(t *testing.T) {
+	backend := &recordingBackend{}
+	m := NewManager()
+	m.audioBackend = backend
+	m.PlaySFX("laser")
+	m.PlaySFXAt("explosion", DefaultMaxDistance*2, 0, true)
+	m.Update()
+	m.Update()
+
+	if backend.streams != 1 {
+		t.Errorf("expected the mixer streamed once, got %d", backend.streams)
+	}
+	if backend.bytes != 0 {
+		t.Errorf("expected no raw buffers sent to the backend, got %d bytes", backend.bytes)
+	}
+	if got := m.Mixer().ActiveVoices(); got != 1 {
+		t.Errorf("expected only the audible sound to get a voice, got %d", got)
+	}
+}
+
+func TestManager_SetVolumesReachesBuses(t *testing.T) {
+	m := NewManager()
+	m.SetVolumes(0.5, 0.25, 0.75)
+	mx := m.Mixer()
+	if mx.BusVolume(BusMusic) != 0.25 || mx.BusVolume(BusSFX) != 0.75 || mx.BusVolume(BusUI) != 0.75 {
+		t.Errorf("unexpected bus volumes: music %f sfx %f ui %f",
+			mx.BusVolume(BusMusic), mx.BusVolume(BusSFX), mx.BusVolume(BusUI))
+	}
+	m.SetBusVolume(BusVoice, 0.1)
+	if mx.BusVolume(BusVoice) != 0.1 {
+		t.Error("expected SetBusVolume to reach the mixer")
+	}
+}
@@ -0,0 +1,435 @@
+package audio
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// Music sequencer constants.
+const (
+	// StepsPerBar is the number of sixteenth-note steps in a bar.
+	StepsPerBar = 16
+	// PhraseBars is the length of a generated phrase before it repeats.
+	PhraseBars = 4
+	// LayerFadeTime is how long a layer takes to fade fully in or out, in seconds.
+	LayerFadeTime = 1.5
+	// GameOverFadeTime is how long the music takes to die away after game over.
+	GameOverFadeTime = 3.0
+	// BossTempoScale speeds up the tempo during boss encounters.
+	BossTempoScale = 1.15
+	// MusicHeadroom scales the summed layers to leave room for SFX.
+	MusicHeadroom = 0.35
+)
+
+// MusicLayer identifies one of the sequencer's instrument layers.
+type MusicLayer int
+
+// Music layers, from always-on foundation to high-intensity lead.
+const (
+	LayerBass MusicLayer = iota
+	LayerDrums
+	LayerMelody
+	MusicLayerCount
+)
+
+// MusicCue is a game event the music reacts to. Cues take effect at the
+// start of the next bar so transitions stay on the beat.
+type MusicCue int
+
+const (
+	// CueWaveStart plays a drum fill and moves to a new melody phrase.
+	CueWaveStart MusicCue = iota
+	// CueBoss raises the tempo, lifts the melody an octave and forces full intensity.
+	CueBoss
+	// CueGameOver drops the drums and melody and fades the music out.
+	CueGameOver
+)
+
+// voiceKind selects how a voice is synthesized.
+type voiceKind int
+
+const (
+	voiceKick voiceKind = iota
+	voiceSnare
+	voiceHat
+	voiceTone
+)
+
+// voice is a single sounding note or drum hit.
+type voice struct {
+	kind     voiceKind
+	layer    MusicLayer
+	freq     float64
+	phase    float64
+	age      int // Samples since the voice started
+	length   int // Samples until the voice ends
+	gain     float64
+	waveform float64 // 0 = sine, 1 = square
+}
+
+// musicPattern is one phrase of generated parts. Notes are frequencies in
+// Hz; zero is a rest.
+type musicPattern struct {
+	kick, snare, hat [StepsPerBar]bool
+	bass             [PhraseBars * StepsPerBar]float64
+	melody           [PhraseBars * StepsPerBar]float64
+}
+
+// Sequencer generates adaptive music from a genre's audio parameters. It
+// streams 16-bit stereo PCM through Read, so it can be handed straight to an
+// audio player. Intensity fades the drum and melody layers in and out.
+type Sequencer struct {
+	mu      sync.Mutex
+	params  GenreAudioParams
+	seed    int64
+	rng     *rand.Rand
+	noise   uint32
+	pattern musicPattern
+	phrase  int // Number of phrases generated, for reseeding
+
+	intensity float64
+	volume    float64
+	gains     [MusicLayerCount]float64
+	boss      bool
+	gameOver  bool
+	fade      float64 // Master fade, 1 while playing, falls to 0 after game over
+	stopped   bool
+
+	pending []MusicCue
+	fill    bool // Play a drum fill over the current bar
+
+	step       int // Step within the phrase
+	stepSample int // Samples elapsed in the current step
+	voices     []voice
+}
+
+// NewSequencer creates a sequencer for the genre, seeded for repeatable music.
+func NewSequencer(genreID string, seed int64) *Sequencer {
+	s := &Sequencer{
+		params: GetGenreParams(genreID),
+		seed:   seed,
+		rng:    rand.New(rand.NewSource(seed)),
+		noise:  uint32(seed) | 1,
+		volume: 1,
+		fade:   1,
+		voices: make([]voice, 0, 16),
+	}
+	s.generatePattern()
+	s.gains = s.targetGains()
+	return s
+}
+
+// SetIntensity sets how intense the music is (0-1). Drums come in at low
+// intensity and the melody at high intensity.
+func (s *Sequencer) SetIntensity(intensity float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.intensity = clampVolume(intensity)
+}
+
+// SetVolume sets the output volume (0-1).
+func (s *Sequencer) SetVolume(volume float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.volume = clampVolume(volume)
+}
+
+// Cue queues a transition for the start of the next bar.
+func (s *Sequencer) Cue(cue MusicCue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = append(s.pending, cue)
+}
+
+// Stop ends the stream; the next Read returns io.EOF.
+func (s *Sequencer) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+}
+
+// LayerGain returns the current gain of a layer (0-1).
+func (s *Sequencer) LayerGain(layer MusicLayer) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gains[layer]
+}
+
+// Tempo returns the current tempo in beats per minute.
+func (s *Sequencer) Tempo() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tempo()
+}
+
+// tempo returns the current tempo; s.mu must be held.
+func (s *Sequencer) tempo() float64 {
+	if s.boss {
+		return s.params.Tempo * BossTempoScale
+	}
+	return s.params.Tempo
+}
+
+// Read fills p with interleaved 16-bit little-endian stereo samples. It
+// implements io.Reader and never runs dry until Stop is called.
+func (s *Sequencer) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return 0, io.EOF
+	}
+
+	n := len(p) / BytesPerSample * BytesPerSample
+	for i := 0; i < n; i += BytesPerSample {
+		sample := s.nextSample()
+		v := int16(clamp(sample, -1, 1) * 32767)
+		binary.LittleEndian.PutUint16(p[i:], uint16(v))
+		binary.LittleEndian.PutUint16(p[i+2:], uint16(v))
+	}
+	return n, nil
+}
+
+// render fills dst with mono samples (-1 to 1), for mixing. A stopped
+// sequencer renders silence.
+func (s *Sequencer) render(dst []float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range dst {
+		if s.stopped {
+			dst[i] = 0
+			continue
+		}
+		dst[i] = s.nextSample()
+	}
+}
+
+// targetGains returns the layer gains the current state fades toward.
+func (s *Sequencer) targetGains() [MusicLayerCount]float64 {
+	var g [MusicLayerCount]float64
+	if s.gameOver {
+		g[LayerBass] = 1
+		return g
+	}
+	intensity := s.intensity
+	if s.boss {
+		intensity = 1
+	}
+	g[LayerBass] = 1
+	g[LayerDrums] = smoothstep(0.15, 0.45, intensity)
+	g[LayerMelody] = smoothstep(0.5, 0.8, intensity)
+	return g
+}
+
+// nextSample advances the sequencer by one sample and returns it (-1 to 1).
+func (s *Sequencer) nextSample() float64 {
+	stepLen := int(SampleRate * 60 / s.tempo() / 4)
+	if s.stepSample == 0 {
+		s.triggerStep()
+	}
+	s.stepSample++
+	if s.stepSample >= stepLen {
+		s.stepSample = 0
+		s.step = (s.step + 1) % (PhraseBars * StepsPerBar)
+	}
+
+	// Crossfade layers toward their targets
+	target := s.targetGains()
+	rate := 1.0 / (LayerFadeTime * SampleRate)
+	for i := range s.gains {
+		switch {
+		case s.gains[i] < target[i]:
+			s.gains[i] = math.Min(s.gains[i]+rate, target[i])
+		case s.gains[i] > target[i]:
+			s.gains[i] = math.Max(s.gains[i]-rate, target[i])
+		}
+	}
+	if s.gameOver {
+		s.fade = math.Max(0, s.fade-1.0/(GameOverFadeTime*SampleRate))
+	}
+
+	sum := 0.0
+	alive := s.voices[:0]
+	for i := range s.voices {
+		v := &s.voices[i]
+		sum += s.renderVoice(v) * v.gain * s.gains[v.layer]
+		v.age++
+		if v.age < v.length {
+			alive = append(alive, *v)
+		}
+	}
+	s.voices = alive
+	return sum * MusicHeadroom * s.volume * s.fade
+}
+
+// triggerStep applies pending cues on bar lines and starts the step's notes.
+func (s *Sequencer) triggerStep() {
+	barStep := s.step % StepsPerBar
+	if barStep == 0 {
+		s.fill = false
+		s.applyCues()
+	}
+	if s.step == 0 && barStep == 0 && !s.gameOver {
+		s.generatePattern()
+	}
+	if s.fade == 0 {
+		return
+	}
+
+	stepLen := SampleRate * 60 / s.tempo() / 4
+	hat := s.pattern.hat[barStep]
+	snare := s.pattern.snare[barStep]
+	if s.fill && barStep >= StepsPerBar-4 {
+		snare = true
+	}
+	if s.pattern.kick[barStep] {
+		s.addVoice(voice{kind: voiceKick, layer: LayerDrums, length: int(0.3 * SampleRate), gain: 0.9})
+	}
+	if snare {
+		s.addVoice(voice{kind: voiceSnare, layer: LayerDrums, length: int(0.18 * SampleRate), gain: 0.5})
+	}
+	if hat {
+		s.addVoice(voice{kind: voiceHat, layer: LayerDrums, length: int(0.05 * SampleRate), gain: 0.25})
+	}
+	if f := s.pattern.bass[s.step]; f > 0 {
+		s.addVoice(voice{kind: voiceTone, layer: LayerBass, freq: f, length: int(stepLen * 1.8),
+			gain: 0.5, waveform: s.params.WaveformMix})
+	}
+	if f := s.pattern.melody[s.step]; f > 0 {
+		if s.boss {
+			f *= 2
+		}
+		s.addVoice(voice{kind: voiceTone, layer: LayerMelody, freq: f, length: int(stepLen * 1.5),
+			gain: 0.35, waveform: s.params.WaveformMix * 0.5})
+	}
+}
+
+// applyCues handles queued transitions at a bar line.
+func (s *Sequencer) applyCues() {
+	for _, cue := range s.pending {
+		switch cue {
+		case CueWaveStart:
+			s.boss = false
+			s.gameOver = false
+			s.fade = 1
+			s.fill = true
+			s.generatePattern()
+		case CueBoss:
+			s.boss = true
+		case CueGameOver:
+			s.gameOver = true
+		}
+	}
+	s.pending = s.pending[:0]
+}
+
+// addVoice starts a voice, dropping the oldest when too many are sounding.
+func (s *Sequencer) addVoice(v voice) {
+	if len(s.voices) == cap(s.voices) {
+		s.voices = append(s.voices[:0], s.voices[1:]...)
+	}
+	s.voices = append(s.voices, v)
+}
+
+// renderVoice returns a voice's next sample before gain.
+func (s *Sequencer) renderVoice(v *voice) float64 {
+	t := float64(v.age) / SampleRate
+	progress := float64(v.age) / float64(v.length)
+	switch v.kind {
+	case voiceKick:
+		// Sine with a fast downward pitch sweep
+		freq := 45 + 75*math.Exp(-t*30)
+		v.phase += 2 * math.Pi * freq / SampleRate
+		return math.Sin(v.phase) * math.Exp(-t*12)
+	case voiceSnare:
+		v.phase += 2 * math.Pi * 185 / SampleRate
+		return (s.whiteNoise()*0.8 + math.Sin(v.phase)*0.3) * math.Exp(-t*25)
+	case voiceHat:
+		return s.whiteNoise() * math.Exp(-t*80)
+	default:
+		v.phase += 2 * math.Pi * v.freq / SampleRate
+		wave := math.Sin(v.phase)*(1-v.waveform) + squareWave(v.phase)*v.waveform
+		// Short attack, then a linear decay to silence
+		env := math.Min(1, t*200) * (1 - progress)
+		return wave * env
+	}
+}
+
+// whiteNoise returns the next value of a fast xorshift noise source (-1 to 1).
+func (s *Sequencer) whiteNoise() float64 {
+	s.noise ^= s.noise << 13
+	s.noise ^= s.noise >> 17
+	s.noise ^= s.noise << 5
+	return float64(s.noise)/float64(math.MaxUint32)*2 - 1
+}
+
+// generatePattern composes a new phrase from the genre's scale and tempo.
+// Each phrase is seeded from the sequencer seed and phrase number, so the
+// same seed always produces the same music.
+func (s *Sequencer) generatePattern() {
+	s.rng.Seed(s.seed + int64(s.phrase)*7919)
+	s.phrase++
+	p := &s.pattern
+	*p = musicPattern{}
+
+	// Drums: four-on-the-floor kicks thinned for slow genres, backbeat
+	// snares and eighth-note hats, with a few seeded extra kicks
+	for i := 0; i < StepsPerBar; i++ {
+		p.kick[i] = i%4 == 0 && (s.params.Tempo >= 100 || i%8 == 0)
+		p.snare[i] = i%8 == 4
+		p.hat[i] = i%2 == 0
+	}
+	for i := 0; i < 2; i++ {
+		p.kick[s.rng.Intn(StepsPerBar/2)*2+1] = true
+	}
+
+	scale := s.params.Scale
+	if len(scale) < 2 {
+		scale = PentatonicScale()
+	}
+	// Bass: root movement through the scale, an octave below the base, on
+	// every other eighth note
+	root := s.params.BaseFrequency / 2
+	ratios := make([]float64, len(scale))
+	for i, f := range scale {
+		ratios[i] = f / scale[0]
+	}
+	for bar := 0; bar < PhraseBars; bar++ {
+		degree := 0
+		if bar > 0 {
+			degree = s.rng.Intn(len(ratios))
+		}
+		for i := 0; i < StepsPerBar; i += 4 {
+			note := root * ratios[degree]
+			if i == 8 && s.rng.Float64() < 0.5 {
+				note = root * ratios[(degree+2)%len(ratios)]
+			}
+			p.bass[bar*StepsPerBar+i] = note
+		}
+	}
+
+	// Melody: a random walk over the scale with rests, ending on the tonic
+	degree := s.rng.Intn(len(scale))
+	for i := 0; i < len(p.melody); i += 2 {
+		if s.rng.Float64() < 0.3 {
+			continue
+		}
+		degree += s.rng.Intn(5) - 2
+		if degree < 0 {
+			degree = 1
+		}
+		if degree >= len(scale) {
+			degree = len(scale) - 2
+		}
+		p.melody[i] = scale[degree]
+	}
+	p.melody[len(p.melody)-4] = scale[0]
+}
+
+// smoothstep eases from 0 at edge0 to 1 at edge1.
+func smoothstep(edge0, edge1, x float64) float64 {
+	t := clamp((x-edge0)/(edge1-edge0), 0, 1)
+	return t * t * (3 - 2*t)
+}
@@ -0,0 +1,187 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+)
+
+// readSeconds reads the given duration of audio from a stream.
+func readSeconds(t *testing.T, s io.Reader, seconds float64) []byte {
+	t.Helper()
+	buf := make([]byte, int(seconds*SampleRate)*BytesPerSample)
+	if _, err := io.ReadFull(s, buf); err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	return buf
+}
+
+// rms returns the root mean square level of the left channel (0-1).
+func rms(pcm []byte) float64 {
+	sum := 0.0
+	n := 0
+	for i := 0; i+1 < len(pcm); i += BytesPerSample {
+		v := float64(int16(binary.LittleEndian.Uint16(pcm[i:]))) / 32768
+		sum += v * v
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return math.Sqrt(sum / float64(n))
+}
+
+func TestSequencer_Deterministic(t *testing.T) {
+	a := readSeconds(t, NewSequencer("scifi", 42), 1)
+	b := readSeconds(t, NewSequencer("scifi", 42), 1)
+	if !bytes.Equal(a, b) {
+		t.Error("expected identical audio for the same seed")
+	}
+	c := readSeconds(t, NewSequencer("scifi", 43), 4)
+	d := readSeconds(t, NewSequencer("scifi", 42), 4)
+	if bytes.Equal(c, d) {
+		t.Error("expected different seeds to produce different music")
+	}
+}
+
+func TestSequencer_ProducesSound(t *testing.T) {
+	for _, genre := range []string{"scifi", "fantasy", "horror", "cyberpunk", "postapoc"} {
+		s := NewSequencer(genre, 1)
+		s.SetIntensity(1)
+		level := rms(readSeconds(t, s, 2))
+		if level < 0.01 {
+			t.Errorf("%s: expected audible music, got RMS %f", genre, level)
+		}
+		if level > 0.9 {
+			t.Errorf("%s: expected headroom, got RMS %f", genre, level)
+		}
+	}
+}
+
+func TestSequencer_IntensityCrossfadesLayers(t *testing.T) {
+	s := NewSequencer("scifi", 1)
+	if s.LayerGain(LayerBass) != 1 || s.LayerGain(LayerDrums) != 0 || s.LayerGain(LayerMelody) != 0 {
+		t.Fatal("expected only bass at zero intensity")
+	}
+
+	s.SetIntensity(1)
+	readSeconds(t, s, LayerFadeTime/2)
+	if g := s.LayerGain(LayerMelody); g <= 0.2 || g >= 0.8 {
+		t.Errorf("expected melody partway faded in, got %f", g)
+	}
+	readSeconds(t, s, LayerFadeTime)
+	if s.LayerGain(LayerDrums) != 1 || s.LayerGain(LayerMelody) != 1 {
+		t.Error("expected all layers in at full intensity")
+	}
+
+	s.SetIntensity(0.3)
+	readSeconds(t, s, LayerFadeTime*1.1)
+	if s.LayerGain(LayerMelody) != 0 {
+		t.Errorf("expected melody faded out at low intensity, got %f", s.LayerGain(LayerMelody))
+	}
+	if g := s.LayerGain(LayerDrums); g <= 0 {
+		t.Errorf("expected drums to remain at low intensity, got %f", g)
+	}
+}
+
+func TestSequencer_BossCue(t *testing.T) {
+	s := NewSequencer("fantasy", 1)
+	base := s.Tempo()
+	s.Cue(CueBoss)
+	if s.Tempo() != base {
+		t.Error("expected cue to wait for the next bar")
+	}
+	readSeconds(t, s, 60/base*4+0.1)
+	if math.Abs(s.Tempo()-base*BossTempoScale) > 1e-9 {
+		t.Errorf("expected boss tempo %f, got %f", base*BossTempoScale, s.Tempo())
+	}
+	readSeconds(t, s, LayerFadeTime+0.1)
+	if s.LayerGain(LayerMelody) != 1 {
+		t.Error("expected boss music at full intensity")
+	}
+
+	s.Cue(CueWaveStart)
+	readSeconds(t, s, 60/s.Tempo()*4+0.1)
+	if s.Tempo() != base {
+		t.Error("expected wave start to end boss music")
+	}
+}
+
+func TestSequencer_GameOverFades(t *testing.T) {
+	s := NewSequencer("horror", 1)
+	s.SetIntensity(1)
+	readSeconds(t, s, 1)
+	s.Cue(CueGameOver)
+	tail := readSeconds(t, s, 60/s.Tempo()*4+GameOverFadeTime+0.5)
+	if level := rms(tail[len(tail)-SampleRate*BytesPerSample/4:]); level != 0 {
+		t.Errorf("expected silence after the game over fade, got RMS %f", level)
+	}
+}
+
+func TestSequencer_StopEndsStream(t *testing.T) {
+	s := NewSequencer("scifi", 1)
+	s.Stop()
+	if _, err := s.Read(make([]byte, 64)); err != io.EOF {
+		t.Errorf("expected io.EOF after Stop, got %v", err)
+	}
+}
+
+func TestSequencer_ReadWholeFrames(t *testing.T) {
+	s := NewSequencer("scifi", 1)
+	n, err := s.Read(make([]byte, 10))
+	if err != nil || n != 8 {
+		t.Errorf("expected 8 bytes of whole stereo frames, got %d, %v", n, err)
+	}
+}
+
+func TestSequencer_Volume(t *testing.T) {
+	loud := NewSequencer("scifi", 5)
+	loud.SetIntensity(1)
+	quiet := NewSequencer("scifi", 5)
+	quiet.SetIntensity(1)
+	quiet.SetVolume(0.25)
+	l, q := rms(readSeconds(t, loud, 1)), rms(readSeconds(t, quiet, 1))
+	if math.Abs(q-l*0.25) > l*0.02 {
+		t.Errorf("expected quarter volume, got %f vs %f", q, l)
+	}
+}
+
+func TestManager_MusicStreamsToBackend(t *testing.T) {
+	backend := &recordingBackend{}
+	m := NewManager()
+	m.audioBackend = backend
+	m.SetIntensity(0.6)
+	m.PlayMusic()
+	if m.Music() == nil {
+		t.Fatal("expected a sequencer after PlayMusic")
+	}
+	m.Update()
+	m.Update()
+	if backend.streams != 1 {
+		t.Errorf("expected music streamed once, got %d", backend.streams)
+	}
+
+	m.SetIntensity(1)
+	readSeconds(t, m.Music(), LayerFadeTime+0.1)
+	if m.Music().LayerGain(LayerMelody) != 1 {
+		t.Error("expected intensity to reach the sequencer")
+	}
+
+	seq := m.Music()
+	m.StopMusic()
+	if _, err := seq.Read(make([]byte, 4)); err != io.EOF {
+		t.Error("expected StopMusic to end the stream")
+	}
+}
+
+// recordingBackend counts what the manager sends to the backend.
+type recordingBackend struct {
+	streams int
+	bytes   int
+}
+
+func (b *recordingBackend) Initialize()            {}
+func (b *recordingBackend) PlayBytes(data []byte)  { b.bytes += len(data) }
+func (b *recordingBackend) PlayStream(r io.Reader) { b.streams++ }
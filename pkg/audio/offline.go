@@ -0,0 +1,223 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// TimelineEventKind identifies what a timeline event does.
+type TimelineEventKind int
+
+// Timeline event kinds.
+const (
+	EventSFX TimelineEventKind = iota
+	EventMusicStart
+	EventMusicStop
+	EventIntensity
+	EventCue
+	EventDuck
+)
+
+// TimelineEvent is one scheduled change in an offline render. Time is in
+// seconds from the start of the timeline.
+type TimelineEvent struct {
+	Time  float64
+	Kind  TimelineEventKind
+	Name  string      // Sound to play, for EventSFX
+	Voice VoiceParams // How to play it, for EventSFX
+	Cue   MusicCue    // For EventCue
+	Value float64     // Intensity, or duck depth
+	Hold  float64     // Duck hold time in seconds
+}
+
+// Timeline is a script of sound effects and music changes rendered offline
+// through the same mixer, synthesizer and sequencer the game plays.
+type Timeline struct {
+	Genre    string
+	Seed     int64
+	Duration float64 // Length of the render in seconds
+	Events   []TimelineEvent
+}
+
+// NewTimeline creates an empty timeline for a genre.
+func NewTimeline(genreID string, seed int64, duration float64) *Timeline {
+	return &Timeline{Genre: genreID, Seed: seed, Duration: duration}
+}
+
+// Add schedules an event.
+func (tl *Timeline) Add(e TimelineEvent) *Timeline {
+	tl.Events = append(tl.Events, e)
+	return tl
+}
+
+// SFX schedules a sound effect played with the given voice parameters.
+func (tl *Timeline) SFX(at float64, name string, voice VoiceParams) *Timeline {
+	return tl.Add(TimelineEvent{Time: at, Kind: EventSFX, Name: name, Voice: voice})
+}
+
+// PlayMusic schedules the genre's music to start at the given intensity.
+func (tl *Timeline) PlayMusic(at, intensity float64) *Timeline {
+	return tl.Add(TimelineEvent{Time: at, Kind: EventMusicStart, Value: intensity})
+}
+
+// StopMusic schedules the music to stop.
+func (tl *Timeline) StopMusic(at float64) *Timeline {
+	return tl.Add(TimelineEvent{Time: at, Kind: EventMusicStop})
+}
+
+// SetIntensity schedules a change of music intensity.
+func (tl *Timeline) SetIntensity(at, intensity float64) *Timeline {
+	return tl.Add(TimelineEvent{Time: at, Kind: EventIntensity, Value: intensity})
+}
+
+// Cue schedules a music cue.
+func (tl *Timeline) Cue(at float64, cue MusicCue) *Timeline {
+	return tl.Add(TimelineEvent{Time: at, Kind: EventCue, Cue: cue})
+}
+
+// Duck schedules the music to duck by depth for hold seconds.
+func (tl *Timeline) Duck(at, depth, hold float64) *Timeline {
+	return tl.Add(TimelineEvent{Time: at, Kind: EventDuck, Value: depth, Hold: hold})
+}
+
+// RenderTimeline mixes a timeline into 16-bit little-endian stereo PCM.
+// Events apply at their sample position in time order; events at the same
+// time apply in the order they were added. Renders are deterministic for a
+// given timeline.
+func RenderTimeline(tl *Timeline) []byte {
+	events := make([]TimelineEvent, len(tl.Events))
+	copy(events, tl.Events)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time < events[j].Time })
+
+	mixer := NewMixer()
+	sfx := NewSFXSynth(tl.Genre, tl.Seed)
+	var music *Sequencer
+
+	total := int(tl.Duration * SampleRate)
+	if total < 0 {
+		total = 0
+	}
+	pcm := make([]byte, total*BytesPerSample)
+	pos := 0
+	for _, e := range events {
+		frame := int(e.Time * SampleRate)
+		if frame > total {
+			break
+		}
+		if frame > pos {
+			mixer.Read(pcm[pos*BytesPerSample : frame*BytesPerSample])
+			pos = frame
+		}
+
+		switch e.Kind {
+		case EventSFX:
+			mixer.PlayVoice(e.Name, sfx.Render(e.Name), e.Voice)
+		case EventMusicStart:
+			music = NewSequencer(tl.Genre, tl.Seed)
+			music.SetIntensity(e.Value)
+			mixer.SetMusic(music)
+		case EventMusicStop:
+			music = nil
+			mixer.SetMusic(nil)
+		case EventIntensity:
+			if music != nil {
+				music.SetIntensity(e.Value)
+			}
+		case EventCue:
+			if music != nil {
+				music.Cue(e.Cue)
+			}
+		case EventDuck:
+			mixer.Duck(e.Value, e.Hold)
+		}
+	}
+	mixer.Read(pcm[pos*BytesPerSample:])
+	return pcm
+}
+
+// WAV format constants.
+const (
+	wavHeaderSize    = 44
+	wavFormatPCM     = 1
+	wavChannels      = 2
+	wavBitsPerSample = 16
+)
+
+// WriteWAV writes 16-bit stereo PCM at SampleRate as a WAV file.
+func WriteWAV(w io.Writer, pcm []byte) error {
+	header := make([]byte, wavHeaderSize)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(36+len(pcm)))
+	copy(header[8:], "WAVE")
+	copy(header[12:], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16)
+	binary.LittleEndian.PutUint16(header[20:], wavFormatPCM)
+	binary.LittleEndian.PutUint16(header[22:], wavChannels)
+	binary.LittleEndian.PutUint32(header[24:], SampleRate)
+	binary.LittleEndian.PutUint32(header[28:], SampleRate*BytesPerSample)
+	binary.LittleEndian.PutUint16(header[32:], BytesPerSample)
+	binary.LittleEndian.PutUint16(header[34:], wavBitsPerSample)
+	copy(header[36:], "data")
+	binary.LittleEndian.PutUint32(header[40:], uint32(len(pcm)))
+
+	if _, err := w.Write(header); err != nil {
+		return fmt.Errorf("audio: write wav header: %w", err)
+	}
+	if _, err := w.Write(pcm); err != nil {
+		return fmt.Errorf("audio: write wav data: %w", err)
+	}
+	return nil
+}
+
+// ReadWAV reads the PCM data of a WAV file in the format WriteWAV produces:
+// 16-bit stereo at SampleRate. Chunks other than fmt and data are skipped.
+func ReadWAV(r io.Reader) ([]byte, error) {
+	var riff [12]byte
+	if _, err := io.ReadFull(r, riff[:]); err != nil {
+		return nil, fmt.Errorf("audio: read wav header: %w", err)
+	}
+	if string(riff[0:4]) != "RIFF" || string(riff[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("audio: not a wav file")
+	}
+
+	haveFormat := false
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(r, chunk[:]); err != nil {
+			return nil, fmt.Errorf("audio: read wav chunk: %w", err)
+		}
+		size := binary.LittleEndian.Uint32(chunk[4:])
+		body := make([]byte, size+size%2) // Chunks are padded to even sizes
+		if _, err := io.ReadFull(r, body); err != nil {
+			return nil, fmt.Errorf("audio: read wav %q chunk: %w", chunk[0:4], err)
+		}
+
+		switch string(chunk[0:4]) {
+		case "fmt ":
+			if size < 16 {
+				return nil, fmt.Errorf("audio: short wav format chunk")
+			}
+			format := binary.LittleEndian.Uint16(body[0:])
+			channels := binary.LittleEndian.Uint16(body[2:])
+			rate := binary.LittleEndian.Uint32(body[4:])
+			bits := binary.LittleEndian.Uint16(body[14:])
+			if format != wavFormatPCM || channels != wavChannels || rate != SampleRate || bits != wavBitsPerSample {
+				return nil, fmt.Errorf("audio: unsupported wav format (format %d, %d channels, %d Hz, %d bits)",
+					format, channels, rate, bits)
+			}
+			haveFormat = true
+		case "data":
+			if !haveFormat {
+				return nil, fmt.Errorf("audio: wav data before format")
+			}
+			return body[:size], nil
+		}
+	}
+}
+
+// RenderTimelineWAV renders a timeline and writes it as a WAV file.
+func RenderTimelineWAV(w io.Writer, tl *Timeline) error {
+	return WriteWAV(w, RenderTimeline(tl))
+}
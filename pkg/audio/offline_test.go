@@ -0,0 +1,263 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"flag"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden audio fingerprints in testdata/golden")
+
+// Golden fingerprint tolerances in dB: small enough to catch a changed
+// preset, mix or pattern, loose enough for cross-platform float differences.
+const (
+	goldenMeanTolerance  = 0.5
+	goldenWorstTolerance = 6.0
+)
+
+// goldenTimelines returns the scenarios checked against golden fingerprints.
+func goldenTimelines() map[string]*Timeline {
+	timelines := make(map[string]*Timeline)
+
+	// Every preset of every genre, one after another
+	for _, genreID := range sfxGenres {
+		var names []string
+		for name := range NewSFXBank(genreID) {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		tl := NewTimeline(genreID, 1, float64(len(names))*0.6)
+		for i, name := range names {
+			tl.SFX(float64(i)*0.6, name, VoiceParams{Volume: 1, Pitch: 1})
+		}
+		timelines["sfx_"+genreID] = tl
+	}
+
+	// Music rising from drums to melody into a boss fight
+	timelines["music_scifi"] = NewTimeline("scifi", 7, 8).
+		PlayMusic(0, 0.2).
+		SetIntensity(2, 1).
+		Cue(4, CueBoss)
+
+	// A firefight over music: positional shots, a ducked explosion, game over
+	spatial := DefaultSpatialConfig()
+	tl := NewTimeline("cyberpunk", 3, 6).PlayMusic(0, 0.8)
+	for i := 0; i < 12; i++ {
+		src := SoundSource{X: float64(i*80 - 400), Y: 100, VX: -300}
+		tl.SFX(0.5+float64(i)*0.15, "laser", spatial.Compute(Listener{}, src, i%3 == 0))
+	}
+	tl.SFX(2.5, "explosion", VoiceParams{Volume: 1, Pitch: 1}).
+		Duck(2.5, 0.7, 0.8).
+		Cue(3, CueGameOver)
+	timelines["mix_cyberpunk"] = tl
+
+	return timelines
+}
+
+func TestRenderTimeline_Golden(t *testing.T) {
+	for name, tl := range goldenTimelines() {
+		t.Run(name, func(t *testing.T) {
+			got := SpectralFingerprint(RenderTimeline(tl))
+			path := filepath.Join("testdata", "golden", name+".json")
+
+			if *updateGolden {
+				writeGolden(t, path, got)
+				return
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read golden (run go test -tags noebiten ./pkg/audio -run Golden -update to create): %v", err)
+			}
+			var want Fingerprint
+			if err := json.Unmarshal(data, &want); err != nil {
+				t.Fatalf("parse golden: %v", err)
+			}
+			mean, worst := got.Compare(want)
+			if mean > goldenMeanTolerance || worst > goldenWorstTolerance {
+				t.Errorf("audio differs from golden by %.2f dB mean, %.2f dB worst; "+
+					"if the change is intended, rerun with -update", mean, worst)
+			}
+		})
+	}
+}
+
+// writeGolden stores a fingerprint rounded to 0.1 dB.
+func writeGolden(t *testing.T, path string, fp Fingerprint) {
+	t.Helper()
+	for _, row := range fp.Rows {
+		for i, v := range row {
+			row[i] = math.Round(v*10) / 10
+		}
+	}
+	data, err := json.Marshal(fp)
+	if err != nil {
+		t.Fatalf("encode golden: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("create golden dir: %v", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		t.Fatalf("write golden: %v", err)
+	}
+}
+
+func TestRenderTimeline_GoldenDetectsChanges(t *testing.T) {
+	tl := goldenTimelines()["sfx_scifi"]
+	base := SpectralFingerprint(RenderTimeline(tl))
+
+	changed := NewTimeline(tl.Genre, tl.Seed, tl.Duration)
+	for _, e := range tl.Events {
+		e.Voice.Pitch = 1.06 // A semitone sharp
+		changed.Add(e)
+	}
+	mean, worst := base.Compare(SpectralFingerprint(RenderTimeline(changed)))
+	if mean <= goldenMeanTolerance && worst <= goldenWorstTolerance {
+		t.Errorf("expected a semitone shift to fail the golden check, got %.2f mean %.2f worst", mean, worst)
+	}
+}
+
+func TestRenderTimeline(t *testing.T) {
+	tl := NewTimeline("scifi", 1, 1).
+		SFX(0.5, "explosion", VoiceParams{Volume: 1, Pitch: 1}).
+		SFX(0.1, "laser", VoiceParams{Volume: 1, Pitch: 1}).
+		SFX(5, "laser", VoiceParams{Volume: 1, Pitch: 1}) // Past the end
+	pcm := RenderTimeline(tl)
+	if len(pcm) != SampleRate*BytesPerSample {
+		t.Fatalf("expected one second of audio, got %d bytes", len(pcm))
+	}
+	at := func(sec float64) []byte {
+		off := int(sec*SampleRate) * BytesPerSample
+		return pcm[off : off+BytesPerSample*441]
+	}
+	if peak(at(0)) != 0 {
+		t.Error("expected silence before the first event")
+	}
+	if peak(at(0.12)) == 0 || peak(at(0.52)) == 0 {
+		t.Error("expected events to sound at their times regardless of order")
+	}
+	if !bytes.Equal(pcm, RenderTimeline(tl)) {
+		t.Error("expected renders to be deterministic")
+	}
+}
+
+func TestRenderTimeline_Music(t *testing.T) {
+	pcm := RenderTimeline(NewTimeline("horror", 2, 2).PlayMusic(0, 1).StopMusic(1))
+	half := len(pcm) / 2
+	if rms(pcm[:half]) == 0 {
+		t.Error("expected music before the stop")
+	}
+	if peak(pcm[half:]) != 0 {
+		t.Error("expected silence after the stop")
+	}
+}
+
+func TestWAV_RoundTrip(t *testing.T) {
+	pcm := RenderTimeline(NewTimeline("fantasy", 1, 0.25).SFX(0, "powerup", VoiceParams{Volume: 1, Pitch: 1}))
+	var buf bytes.Buffer
+	if err := WriteWAV(&buf, pcm); err != nil {
+		t.Fatalf("WriteWAV: %v", err)
+	}
+	wav := buf.Bytes()
+	if len(wav) != wavHeaderSize+len(pcm) {
+		t.Fatalf("expected %d bytes, got %d", wavHeaderSize+len(pcm), len(wav))
+	}
+	if string(wav[0:4]) != "RIFF" || string(wav[8:16]) != "WAVEfmt " || string(wav[36:40]) != "data" {
+		t.Error("unexpected wav header layout")
+	}
+	if rate := binary.LittleEndian.Uint32(wav[24:]); rate != SampleRate {
+		t.Errorf("expected sample rate %d, got %d", SampleRate, rate)
+	}
+
+	got, err := ReadWAV(bytes.NewReader(wav))
+	if err != nil {
+		t.Fatalf("ReadWAV: %v", err)
+	}
+	if !bytes.Equal(got, pcm) {
+		t.Error("expected PCM to survive a round trip")
+	}
+}
+
+func TestReadWAV_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteWAV(&buf, make([]byte, 16)); err != nil {
+		t.Fatal(err)
+	}
+	valid := buf.Bytes()
+
+	mono := append([]byte(nil), valid...)
+	binary.LittleEndian.PutUint16(mono[22:], 1)
+
+	tests := map[string][]byte{
+		"empty":     nil,
+		"not riff":  append([]byte("RIFX"), valid[4:]...),
+		"truncated": valid[:40],
+		"mono":      mono,
+	}
+	for name, data := range tests {
+		if _, err := ReadWAV(bytes.NewReader(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSpectralFingerprint(t *testing.T) {
+	edges := fingerprintBandEdges()
+	tone := Synthesize(SFXParams{Wave: WaveSine, Sustain: 1, SustainTime: 0.5, Frequency: 1000, Volume: 1})
+	fp := SpectralFingerprint(tone)
+	if len(fp.Rows) != len(tone)/BytesPerSample/FingerprintWindow {
+		t.Fatalf("unexpected row count %d", len(fp.Rows))
+	}
+
+	binHz := float64(SampleRate) / FingerprintWindow
+	row := fp.Rows[1]
+	loudest := 0
+	for b := range row {
+		if row[b] > row[loudest] {
+			loudest = b
+		}
+	}
+	if lo, hi := float64(edges[loudest])*binHz, float64(edges[loudest+1])*binHz; 1000 < lo || 1000 >= hi {
+		t.Errorf("expected 1 kHz in the loudest band, got band %.0f-%.0f Hz", lo, hi)
+	}
+	if math.Abs(row[loudest]) > 1 {
+		t.Errorf("expected a full-scale sine near 0 dB, got %.2f", row[loudest])
+	}
+
+	silent := SpectralFingerprint(make([]byte, FingerprintWindow*BytesPerSample))
+	for _, v := range silent.Rows[0] {
+		if v != FingerprintFloor {
+			t.Fatalf("expected silence at the floor, got %f", v)
+		}
+	}
+
+	if mean, worst := fp.Compare(fp); mean != 0 || worst != 0 {
+		t.Error("expected a fingerprint to match itself")
+	}
+	if mean, _ := fp.Compare(silent); !math.IsInf(mean, 1) {
+		t.Error("expected fingerprints of different lengths not to match")
+	}
+}
+
+func TestFFT(t *testing.T) {
+	x := make([]complex128, 8)
+	for i := range x {
+		x[i] = complex(math.Cos(2*math.Pi*2*float64(i)/8), 0)
+	}
+	fft(x)
+	for k, v := range x {
+		want := 0.0
+		if k == 2 || k == 6 {
+			want = 4
+		}
+		if math.Abs(real(v)-want) > 1e-9 || math.Abs(imag(v)) > 1e-9 {
+			t.Errorf("bin %d = %v, want %v", k, v, want)
+		}
+	}
+}
@@ -0,0 +1,400 @@
+package audio
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// SFX synthesis constants.
+const (
+	// SFXVariants is the number of varied renders cached per sound. Plays
+	// pick one at random so repeated sounds don't feel mechanical.
+	SFXVariants = 4
+	// MaxFilterCutoff caps filter cutoffs to keep the state-variable filter stable.
+	MaxFilterCutoff = SampleRate / 6
+	// MaxSFXDuration caps the length of a synthesized sound, in seconds.
+	MaxSFXDuration = 3.0
+)
+
+// Waveform is the oscillator shape of a synthesized sound.
+type Waveform int
+
+// Oscillator waveforms.
+const (
+	WaveSquare Waveform = iota
+	WaveSawtooth
+	WaveSine
+	WaveTriangle
+	WaveNoise // Sample-and-hold noise pitched by the oscillator frequency
+)
+
+// SFXParams describes a synthesized sound effect in the style of sfxr: an
+// oscillator with pitch slide, vibrato and arpeggio, shaped by an ADSR
+// envelope and low- and high-pass filters. Times are in seconds and
+// frequencies in Hz.
+type SFXParams struct {
+	Wave Waveform
+	Duty float64 // Square wave duty cycle (0-1); 0 means 0.5
+	// DutySweep changes the duty cycle per second.
+	DutySweep float64
+
+	// Envelope: rise to full level over Attack, fall to Sustain over Decay,
+	// hold for SustainTime, then fade out over Release.
+	Attack      float64
+	Decay       float64
+	Sustain     float64
+	SustainTime float64
+	Release     float64
+
+	Frequency    float64
+	MinFrequency float64 // Slides stop at this frequency; 0 lets them run
+	Slide        float64 // Pitch change in octaves per second
+	DeltaSlide   float64 // Change of Slide per second
+	VibratoDepth float64 // Fraction of the frequency
+	VibratoRate  float64
+	ArpeggioTime float64 // Time at which the pitch jumps; 0 disables
+	ArpeggioMul  float64 // Frequency multiplier applied at ArpeggioTime
+
+	NoiseMix     float64 // White noise blended into the oscillator (0-1)
+	LowPass      float64 // Cutoff; 0 disables
+	LowPassSweep float64 // Cutoff change in octaves per second
+	Resonance    float64 // Low-pass resonance (0-1)
+	HighPass     float64 // Cutoff; 0 disables
+
+	Volume    float64
+	Variation float64 // Per-play random spread of pitch and volume (0-1)
+}
+
+// Duration returns the length of the sound in seconds.
+func (p SFXParams) Duration() float64 {
+	return math.Min(p.Attack+p.Decay+p.SustainTime+p.Release, MaxSFXDuration)
+}
+
+// Vary returns a copy of the parameters with pitch, slide and volume
+// randomly spread by Variation.
+func (p SFXParams) Vary(rng *rand.Rand) SFXParams {
+	if p.Variation <= 0 {
+		return p
+	}
+	pitch := 1 + (rng.Float64()*2-1)*p.Variation
+	p.Frequency *= pitch
+	p.MinFrequency *= pitch
+	p.Slide *= 1 + (rng.Float64()*2-1)*p.Variation
+	p.SustainTime *= 1 + (rng.Float64()*2-1)*p.Variation*0.5
+	p.Volume *= 1 - rng.Float64()*p.Variation*0.5
+	return p
+}
+
+// envelope returns the ADSR level at time t.
+func (p SFXParams) envelope(t float64) float64 {
+	if t < p.Attack {
+		return t / p.Attack
+	}
+	t -= p.Attack
+	if t < p.Decay {
+		return 1 - (1-p.Sustain)*t/p.Decay
+	}
+	t -= p.Decay
+	if t < p.SustainTime {
+		return p.Sustain
+	}
+	t -= p.SustainTime
+	if p.Release > 0 && t < p.Release {
+		return p.Sustain * (1 - t/p.Release)
+	}
+	return 0
+}
+
+// Synthesize renders the sound as 16-bit little-endian stereo PCM. The same
+// parameters always produce the same samples.
+func Synthesize(p SFXParams) []byte {
+	n := int(p.Duration() * SampleRate)
+	buf := make([]byte, n*BytesPerSample)
+
+	duty := p.Duty
+	if duty <= 0 {
+		duty = 0.5
+	}
+	freq := p.Frequency
+	slide := p.Slide
+	cutoff := p.LowPass
+	noise := uint32(0x9E3779B9)
+	white := func() float64 {
+		noise ^= noise << 13
+		noise ^= noise >> 17
+		noise ^= noise << 5
+		return float64(noise)/float64(math.MaxUint32)*2 - 1
+	}
+	held := white()
+
+	var phase, low, band, hp, prev float64
+	dt := 1.0 / SampleRate
+	arpeggiated := false
+	for i := 0; i < n; i++ {
+		t := float64(i) * dt
+
+		// Pitch: slide, arpeggio jump and vibrato
+		slide += p.DeltaSlide * dt
+		freq *= math.Exp2(slide * dt)
+		if p.MinFrequency > 0 && freq < p.MinFrequency {
+			freq = p.MinFrequency
+		}
+		if p.ArpeggioTime > 0 && !arpeggiated && t >= p.ArpeggioTime {
+			freq *= p.ArpeggioMul
+			arpeggiated = true
+		}
+		f := freq * (1 + p.VibratoDepth*math.Sin(2*math.Pi*p.VibratoRate*t))
+		f = math.Min(math.Max(f, 1), SampleRate/2)
+
+		phase += f * dt
+		if phase >= 1 {
+			phase -= math.Floor(phase)
+			held = white()
+		}
+		d := clamp(duty+p.DutySweep*t, 0.05, 0.95)
+
+		var s float64
+		switch p.Wave {
+		case WaveSquare:
+			s = 1
+			if phase >= d {
+				s = -1
+			}
+		case WaveSawtooth:
+			s = 2*phase - 1
+		case WaveSine:
+			s = math.Sin(2 * math.Pi * phase)
+		case WaveTriangle:
+			s = 1 - 4*math.Abs(phase-0.5)
+		case WaveNoise:
+			s = held
+		}
+		if p.NoiseMix > 0 {
+			s = s*(1-p.NoiseMix) + white()*p.NoiseMix
+		}
+
+		// Resonant low-pass (state-variable) and one-pole high-pass
+		if p.LowPass > 0 {
+			cutoff *= math.Exp2(p.LowPassSweep * dt)
+			fc := 2 * math.Sin(math.Pi*clamp(cutoff, 20, MaxFilterCutoff)/SampleRate)
+			damp := 1 - clamp(p.Resonance, 0, 1)*0.9
+			low += fc * band
+			band += fc * (s - low - damp*band)
+			s = low
+		}
+		if p.HighPass > 0 {
+			rc := 1 / (2 * math.Pi * p.HighPass)
+			hp = rc / (rc + dt) * (hp + s - prev)
+			prev = s
+			s = hp
+		}
+
+		v := int16(clamp(s*p.envelope(t)*p.Volume, -1, 1) * 32767)
+		binary.LittleEndian.PutUint16(buf[i*4:], uint16(v))
+		binary.LittleEndian.PutUint16(buf[i*4+2:], uint16(v))
+	}
+	return buf
+}
+
+// sfxAliases maps alternative sound names used by the game to presets.
+var sfxAliases = map[string]string{
+	"fire":        "laser",
+	"shoot":       "laser",
+	"death":       "explosion",
+	"enemy_death": "explosion",
+	"pickup":      "powerup",
+	"collect":     "powerup",
+	"select":      "menu_select",
+	"ui_click":    "menu_select",
+}
+
+// DefaultSFXPreset is the preset played for names without one.
+const DefaultSFXPreset = "blip"
+
+// basePresets returns the sound presets before genre styling.
+func basePresets() map[string]SFXParams {
+	return map[string]SFXParams{
+		"laser": {
+			Wave: WaveSquare, Duty: 0.3, DutySweep: 1.5,
+			Attack: 0.005, Sustain: 1, SustainTime: 0.05, Release: 0.08,
+			Frequency: 1100, MinFrequency: 220, Slide: -9,
+			HighPass: 120, Volume: 0.3, Variation: 0.08,
+		},
+		"explosion": {
+			Wave: WaveNoise, Attack: 0.002, Decay: 0.08, Sustain: 0.6, SustainTime: 0.1, Release: 0.35,
+			Frequency: 900, MinFrequency: 60, Slide: -4,
+			LowPass: 5000, LowPassSweep: -3, Volume: 0.5, Variation: 0.15,
+		},
+		"hit": {
+			Wave: WaveNoise, Attack: 0.001, Sustain: 0.8, SustainTime: 0.02, Release: 0.08,
+			Frequency: 2400, Slide: -6, Volume: 0.35, Variation: 0.12,
+		},
+		"powerup": {
+			Wave: WaveSquare, Attack: 0.005, Sustain: 0.8, SustainTime: 0.12, Release: 0.1,
+			Frequency: 440, Slide: 2.5, ArpeggioTime: 0.08, ArpeggioMul: 1.5,
+			VibratoDepth: 0.02, VibratoRate: 12, Volume: 0.3, Variation: 0.04,
+		},
+		"menu_select": {
+			Wave: WaveSine, Attack: 0.002, Sustain: 0.7, SustainTime: 0.03, Release: 0.03,
+			Frequency: 660, Volume: 0.3,
+		},
+		"wave_start": {
+			Wave: WaveSquare, Duty: 0.25, Attack: 0.01, Decay: 0.05, Sustain: 0.6, SustainTime: 0.25, Release: 0.2,
+			Frequency: 330, ArpeggioTime: 0.15, ArpeggioMul: 4.0 / 3.0,
+			VibratoDepth: 0.01, VibratoRate: 6, LowPass: 4000, Volume: 0.3,
+		},
+		"wave_complete": {
+			Wave: WaveSquare, Attack: 0.01, Sustain: 0.8, SustainTime: 0.3, Release: 0.25,
+			Frequency: 523.25, ArpeggioTime: 0.12, ArpeggioMul: 1.5,
+			VibratoDepth: 0.015, VibratoRate: 8, Volume: 0.3,
+		},
+		DefaultSFXPreset: {
+			Wave: WaveSine, Attack: 0.01, Sustain: 1, SustainTime: 0.08, Release: 0.02,
+			Frequency: 440, Volume: 0.3,
+		},
+	}
+}
+
+// sfxStyle reshapes the base presets for a genre.
+type sfxStyle struct {
+	Pitch     float64   // Frequency multiplier
+	Tonal     *Waveform // Replaces the waveform of non-noise sounds
+	NoiseMix  float64   // Extra noise blended into every sound
+	LowPass   float64   // Low-pass applied to sounds without one
+	Resonance float64
+	Vibrato   float64 // Extra vibrato depth on tonal sounds
+}
+
+// waveform returns a pointer to w, for sfxStyle.Tonal.
+func waveform(w Waveform) *Waveform { return &w }
+
+// sfxStyles maps genre IDs to their SFX styling. Unknown genres use the
+// base presets unchanged.
+var sfxStyles = map[string]sfxStyle{
+	"scifi":     {Pitch: 1},
+	"fantasy":   {Pitch: 1.1, Tonal: waveform(WaveTriangle), Vibrato: 0.01},
+	"horror":    {Pitch: 0.7, Tonal: waveform(WaveSawtooth), LowPass: 1800, Vibrato: 0.03},
+	"cyberpunk": {Pitch: 1.2, Tonal: waveform(WaveSawtooth), LowPass: 3500, Resonance: 0.6},
+	"postapoc":  {Pitch: 0.85, NoiseMix: 0.2, LowPass: 2500},
+}
+
+// SFXBank is a genre's set of named sound presets.
+type SFXBank map[string]SFXParams
+
+// NewSFXBank creates the preset bank for a genre.
+func NewSFXBank(genreID string) SFXBank {
+	bank := SFXBank(basePresets())
+	style, ok := sfxStyles[genreID]
+	if !ok {
+		return bank
+	}
+	for name, p := range bank {
+		p.Frequency *= style.Pitch
+		p.MinFrequency *= style.Pitch
+		if p.Wave != WaveNoise {
+			if style.Tonal != nil {
+				p.Wave = *style.Tonal
+			}
+			p.VibratoDepth += style.Vibrato
+			if p.VibratoRate == 0 {
+				p.VibratoRate = 6
+			}
+		}
+		p.NoiseMix = math.Min(1, p.NoiseMix+style.NoiseMix)
+		if p.LowPass == 0 && style.LowPass > 0 {
+			p.LowPass = style.LowPass
+			p.Resonance = style.Resonance
+		}
+		bank[name] = p
+	}
+	return bank
+}
+
+// Preset returns the parameters for a sound name, resolving aliases and
+// falling back to DefaultSFXPreset.
+func (b SFXBank) Preset(name string) SFXParams {
+	if p, ok := b[name]; ok {
+		return p
+	}
+	if alias, ok := sfxAliases[name]; ok {
+		if p, ok := b[alias]; ok {
+			return p
+		}
+	}
+	return b[DefaultSFXPreset]
+}
+
+// sfxKey identifies a cached render.
+type sfxKey struct {
+	name    string
+	variant int
+}
+
+// SFXSynth renders sounds from a genre's preset bank, caching the PCM of
+// each sound's variants. Variant 0 is the unvaried preset; the others are
+// seeded from the synth seed and sound name, so a seed always produces the
+// same set of variants.
+type SFXSynth struct {
+	mu    sync.Mutex
+	bank  SFXBank
+	seed  int64
+	rng   *rand.Rand
+	cache map[sfxKey][]byte
+}
+
+// NewSFXSynth creates a synthesizer for a genre.
+func NewSFXSynth(genreID string, seed int64) *SFXSynth {
+	return &SFXSynth{
+		bank:  NewSFXBank(genreID),
+		seed:  seed,
+		rng:   rand.New(rand.NewSource(seed)),
+		cache: make(map[sfxKey][]byte),
+	}
+}
+
+// Render returns the PCM for one play of a sound, picking one of its
+// variants at random. The returned slice is shared and must not be modified.
+func (s *SFXSynth) Render(name string) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	variant := 0
+	if s.bank.Preset(name).Variation > 0 {
+		variant = s.rng.Intn(SFXVariants)
+	}
+	return s.variant(name, variant)
+}
+
+// Variant returns the PCM of one variant (0 to SFXVariants-1) of a sound.
+// The returned slice is shared and must not be modified.
+func (s *SFXSynth) Variant(name string, variant int) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.variant(name, variant)
+}
+
+// variant renders or fetches a cached variant; s.mu must be held.
+func (s *SFXSynth) variant(name string, variant int) []byte {
+	key := sfxKey{name, variant}
+	if pcm, ok := s.cache[key]; ok {
+		return pcm
+	}
+	p := s.bank.Preset(name)
+	if variant > 0 {
+		h := fnv.New64a()
+		h.Write([]byte(name))
+		p = p.Vary(rand.New(rand.NewSource(s.seed ^ int64(h.Sum64()) + int64(variant))))
+	}
+	pcm := Synthesize(p)
+	s.cache[key] = pcm
+	return pcm
+}
+
+// CacheSize returns the number of cached renders.
+func (s *SFXSynth) CacheSize() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.cache)
+}
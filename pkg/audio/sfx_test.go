@@ -0,0 +1,235 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+var sfxGenres = []string{"scifi", "fantasy", "horror", "cyberpunk", "postapoc"}
+
+// zeroCrossings counts sign changes of the left channel in pcm.
+func zeroCrossings(pcm []byte) int {
+	n := 0
+	prev := int16(0)
+	for i := 0; i+1 < len(pcm); i += BytesPerSample {
+		v := int16(binary.LittleEndian.Uint16(pcm[i:]))
+		if (v < 0) != (prev < 0) {
+			n++
+		}
+		prev = v
+	}
+	return n
+}
+
+func TestNewSFXBank_AllPresetsAllGenres(t *testing.T) {
+	for _, genreID := range sfxGenres {
+		bank := NewSFXBank(genreID)
+		for name, p := range bank {
+			pcm := Synthesize(p)
+			if want := int(p.Duration()*SampleRate) * BytesPerSample; len(pcm) != want {
+				t.Errorf("%s/%s: expected %d bytes, got %d", genreID, name, want, len(pcm))
+			}
+			level := rms(pcm)
+			if level < 0.01 || math.IsNaN(level) {
+				t.Errorf("%s/%s: expected audible output, got rms %f", genreID, name, level)
+			}
+			if pk := peak(pcm); pk > 0.99 {
+				t.Errorf("%s/%s: expected headroom, got peak %f", genreID, name, pk)
+			}
+		}
+	}
+}
+
+func TestNewSFXBank_GenresDiffer(t *testing.T) {
+	scifi := Synthesize(NewSFXBank("scifi").Preset("laser"))
+	for _, genreID := range sfxGenres[1:] {
+		if bytes.Equal(scifi, Synthesize(NewSFXBank(genreID).Preset("laser"))) {
+			t.Errorf("expected %s laser to differ from scifi", genreID)
+		}
+	}
+	if !bytes.Equal(scifi, Synthesize(NewSFXBank("unknown").Preset("laser"))) {
+		t.Error("expected unknown genres to use the base presets")
+	}
+}
+
+func TestSFXBank_Preset(t *testing.T) {
+	bank := NewSFXBank("scifi")
+	if bank.Preset("shoot") != bank["laser"] {
+		t.Error("expected alias to resolve to its preset")
+	}
+	if bank.Preset("no_such_sound") != bank[DefaultSFXPreset] {
+		t.Error("expected unknown names to use the default preset")
+	}
+	for _, name := range []string{"wave_start", "wave_complete", "hit"} {
+		if bank.Preset(name) == bank[DefaultSFXPreset] {
+			t.Errorf("expected a dedicated preset for %s", name)
+		}
+	}
+}
+
+func TestSynthesize_Deterministic(t *testing.T) {
+	p := NewSFXBank("postapoc").Preset("explosion")
+	if !bytes.Equal(Synthesize(p), Synthesize(p)) {
+		t.Error("expected identical parameters to render identical PCM")
+	}
+}
+
+func TestSynthesize_EnvelopeEndsSilent(t *testing.T) {
+	pcm := Synthesize(NewSFXBank("scifi").Preset("powerup"))
+	tail := pcm[len(pcm)-BytesPerSample*16:]
+	if pk := peak(tail); pk > 0.01 {
+		t.Errorf("expected release to fade out, got tail peak %f", pk)
+	}
+	head := pcm[:BytesPerSample]
+	if pk := peak(head); pk > 0.01 {
+		t.Errorf("expected attack to start silent, got %f", pk)
+	}
+}
+
+func TestSynthesize_PitchSlide(t *testing.T) {
+	p := SFXParams{Wave: WaveSine, Sustain: 1, SustainTime: 0.4, Frequency: 2000, Slide: -4, Volume: 0.5}
+	pcm := Synthesize(p)
+	half := len(pcm) / 2 / BytesPerSample * BytesPerSample
+	if first, last := zeroCrossings(pcm[:half]), zeroCrossings(pcm[half:]); last >= first {
+		t.Errorf("expected falling pitch, got %d then %d crossings", first, last)
+	}
+
+	p.MinFrequency = 1500
+	pcm = Synthesize(p)
+	want := 2 * 1500 * p.Duration() / 2
+	if got := float64(zeroCrossings(pcm[half:])); math.Abs(got-want) > want*0.05 {
+		t.Errorf("expected slide to stop at MinFrequency (%f crossings), got %f", want, got)
+	}
+}
+
+func TestSynthesize_Arpeggio(t *testing.T) {
+	p := SFXParams{Wave: WaveSquare, Sustain: 1, SustainTime: 0.2, Frequency: 500, ArpeggioTime: 0.1, ArpeggioMul: 2, Volume: 0.5}
+	pcm := Synthesize(p)
+	half := len(pcm) / 2 / BytesPerSample * BytesPerSample
+	first, last := zeroCrossings(pcm[:half]), zeroCrossings(pcm[half:])
+	if ratio := float64(last) / float64(first); math.Abs(ratio-2) > 0.1 {
+		t.Errorf("expected pitch to double, got ratio %f", ratio)
+	}
+}
+
+func TestSynthesize_Filters(t *testing.T) {
+	p := SFXParams{Wave: WaveNoise, Sustain: 1, SustainTime: 0.2, Frequency: SampleRate / 2, Volume: 0.5}
+	raw := zeroCrossings(Synthesize(p))
+
+	low := p
+	low.LowPass = 500
+	if got := zeroCrossings(Synthesize(low)); got > raw/4 {
+		t.Errorf("expected low-pass to remove high frequencies: %d vs %d crossings", got, raw)
+	}
+
+	tone := SFXParams{Wave: WaveSine, Sustain: 1, SustainTime: 0.2, Frequency: 50, Volume: 0.5}
+	high := tone
+	high.HighPass = 2000
+	if a, b := rms(Synthesize(tone)), rms(Synthesize(high)); b > a*0.2 {
+		t.Errorf("expected high-pass to cut a low tone: rms %f vs %f", b, a)
+	}
+}
+
+func TestSynthesize_Waveforms(t *testing.T) {
+	seen := make(map[string]Waveform)
+	for _, w := range []Waveform{WaveSquare, WaveSawtooth, WaveSine, WaveTriangle, WaveNoise} {
+		pcm := Synthesize(SFXParams{Wave: w, Sustain: 1, SustainTime: 0.05, Frequency: 440, Volume: 0.5})
+		if rms(pcm) == 0 {
+			t.Errorf("waveform %d is silent", w)
+		}
+		if other, ok := seen[string(pcm)]; ok {
+			t.Errorf("waveforms %d and %d render identically", w, other)
+		}
+		seen[string(pcm)] = w
+	}
+}
+
+func TestSFXParams_Vary(t *testing.T) {
+	p := NewSFXBank("scifi").Preset("explosion")
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		v := p.Vary(rng)
+		if ratio := v.Frequency / p.Frequency; math.Abs(ratio-1) > p.Variation {
+			t.Fatalf("pitch varied by %f, beyond %f", ratio, p.Variation)
+		}
+		if v.Volume > p.Volume {
+			t.Fatal("expected variation never to raise the volume")
+		}
+	}
+
+	steady := NewSFXBank("scifi").Preset("menu_select")
+	if steady.Vary(rng) != steady {
+		t.Error("expected presets without variation to stay unchanged")
+	}
+}
+
+func TestSFXSynth_CachesVariants(t *testing.T) {
+	s := NewSFXSynth("scifi", 42)
+	for i := 0; i < 50; i++ {
+		s.Render("laser")
+	}
+	if n := s.CacheSize(); n == 0 || n > SFXVariants {
+		t.Errorf("expected at most %d cached laser renders, got %d", SFXVariants, n)
+	}
+
+	a := s.Variant("explosion", 1)
+	if &a[0] != &s.Variant("explosion", 1)[0] {
+		t.Error("expected repeated renders to share cached PCM")
+	}
+	if bytes.Equal(a, s.Variant("explosion", 2)) {
+		t.Error("expected variants to differ")
+	}
+	if !bytes.Equal(s.Variant("explosion", 0), Synthesize(NewSFXBank("scifi").Preset("explosion"))) {
+		t.Error("expected variant 0 to be the unvaried preset")
+	}
+
+	for i := 0; i < 20; i++ {
+		s.Render("menu_select")
+	}
+	if !bytes.Equal(s.Render("menu_select"), s.Variant("menu_select", 0)) {
+		t.Error("expected steady sounds to always play variant 0")
+	}
+}
+
+func TestSFXSynth_Seeded(t *testing.T) {
+	a := NewSFXSynth("horror", 7)
+	b := NewSFXSynth("horror", 7)
+	c := NewSFXSynth("horror", 8)
+	if !bytes.Equal(a.Variant("laser", 3), b.Variant("laser", 3)) {
+		t.Error("expected the same seed to produce the same variants")
+	}
+	if bytes.Equal(a.Variant("laser", 3), c.Variant("laser", 3)) {
+		t.Error("expected different seeds to produce different variants")
+	}
+}
+
+func TestManager_SFXFollowsGenre(t *testing.T) {
+	m := NewManager()
+	m.SetGenre("cyberpunk")
+	if !bytes.Equal(m.synth().Variant("wave_start", 0), Synthesize(NewSFXBank("cyberpunk").Preset("wave_start"))) {
+		t.Error("expected the manager to play the genre's presets")
+	}
+}
+
+func TestManager_SFXSynthBuiltOnce(t *testing.T) {
+	m := NewManager()
+	m.SetGenre("horror")
+	m.SetSeed(7)
+	if m.sfx != nil {
+		t.Fatal("expected the synth to wait until a sound is played")
+	}
+	s := m.synth()
+	if m.synth() != s {
+		t.Error("expected the synth to be reused")
+	}
+	if !bytes.Equal(s.Variant("laser", 3), NewSFXSynth("horror", 7).Variant("laser", 3)) {
+		t.Error("expected the synth to use both the genre and the seed")
+	}
+	m.SetSeed(8)
+	if m.synth() == s {
+		t.Error("expected a new seed to rebuild the synth")
+	}
+}
@@ -0,0 +1,157 @@
+package audio
+
+import "math"
+
+// AttenuationCurve selects how a positional sound fades with distance.
+type AttenuationCurve string
+
+// Attenuation curves. All of them play at full volume inside MinDistance;
+// all but AttenuationNone reach silence at MaxDistance.
+const (
+	AttenuationLinear AttenuationCurve = "linear"
+	// AttenuationInverse falls quickly near the listener, then slowly.
+	AttenuationInverse AttenuationCurve = "inverse"
+	// AttenuationExponential falls steadily by a constant ratio.
+	AttenuationExponential AttenuationCurve = "exponential"
+	// AttenuationNone never fades.
+	AttenuationNone AttenuationCurve = "none"
+)
+
+// Default spatial audio settings, in pixels and pixels per second.
+const (
+	DefaultMinDistance     = 60.0
+	DefaultMaxDistance     = 900.0
+	DefaultRolloff         = 4.0
+	DefaultPanDistance     = 400.0
+	DefaultSpeedOfSound    = 2000.0
+	DefaultMinPitch        = 0.75
+	DefaultMaxPitch        = 1.33
+	DefaultNearCutoff      = 12000.0
+	DefaultFarCutoff       = 1200.0
+	DefaultOcclusionGain   = 0.5
+	DefaultOcclusionCutoff = 900.0
+)
+
+// SpatialConfig controls how positional sounds are heard.
+type SpatialConfig struct {
+	Curve       AttenuationCurve
+	MinDistance float64 // Sounds closer than this play at full volume
+	MaxDistance float64 // Sounds beyond this are silent
+	Rolloff     float64 // Steepness of the inverse and exponential curves
+	PanDistance float64 // Horizontal offset that pans fully to one side
+
+	// Doppler shifts pitch by the closing speed of source and listener;
+	// a SpeedOfSound of 0 disables it.
+	SpeedOfSound float64
+	DopplerScale float64
+	MinPitch     float64
+	MaxPitch     float64
+
+	// Distant sounds are muffled by a low-pass filter sweeping from
+	// NearCutoff at MinDistance to FarCutoff at MaxDistance (Hz); a
+	// NearCutoff of 0 disables it.
+	NearCutoff float64
+	FarCutoff  float64
+
+	// Sounds behind an obstacle are quieter and muffled.
+	OcclusionGain   float64
+	OcclusionCutoff float64
+}
+
+// DefaultSpatialConfig returns the spatial settings the game uses.
+func DefaultSpatialConfig() SpatialConfig {
+	return SpatialConfig{
+		Curve:           AttenuationInverse,
+		MinDistance:     DefaultMinDistance,
+		MaxDistance:     DefaultMaxDistance,
+		Rolloff:         DefaultRolloff,
+		PanDistance:     DefaultPanDistance,
+		SpeedOfSound:    DefaultSpeedOfSound,
+		DopplerScale:    1,
+		MinPitch:        DefaultMinPitch,
+		MaxPitch:        DefaultMaxPitch,
+		NearCutoff:      DefaultNearCutoff,
+		FarCutoff:       DefaultFarCutoff,
+		OcclusionGain:   DefaultOcclusionGain,
+		OcclusionCutoff: DefaultOcclusionCutoff,
+	}
+}
+
+// Listener is where positional sounds are heard from, usually the camera.
+type Listener struct {
+	X, Y   float64
+	VX, VY float64
+}
+
+// SoundSource is the position and velocity of a positional sound.
+type SoundSource struct {
+	X, Y   float64
+	VX, VY float64
+}
+
+// falloff returns how far d lies between MinDistance and MaxDistance (0-1).
+func (c SpatialConfig) falloff(d float64) float64 {
+	if c.MaxDistance <= c.MinDistance {
+		if d <= c.MinDistance {
+			return 0
+		}
+		return 1
+	}
+	return clamp((d-c.MinDistance)/(c.MaxDistance-c.MinDistance), 0, 1)
+}
+
+// Attenuation returns the volume (0-1) of a sound at distance d. Curves are
+// scaled so they meet 1 at MinDistance and 0 at MaxDistance without a jump.
+func (c SpatialConfig) Attenuation(d float64) float64 {
+	x := c.falloff(d)
+	switch c.Curve {
+	case AttenuationNone:
+		return 1
+	case AttenuationInverse, AttenuationExponential:
+		if c.Rolloff <= 0 {
+			return 1 - x
+		}
+	}
+	switch c.Curve {
+	case AttenuationInverse:
+		end := 1 / (1 + c.Rolloff)
+		return (1/(1+c.Rolloff*x) - end) / (1 - end)
+	case AttenuationExponential:
+		end := math.Exp(-c.Rolloff)
+		return (math.Exp(-c.Rolloff*x) - end) / (1 - end)
+	default:
+		return 1 - x
+	}
+}
+
+// Compute returns how a sound from src is played to the listener. Occluded
+// sounds are attenuated and muffled further.
+func (c SpatialConfig) Compute(l Listener, src SoundSource, occluded bool) VoiceParams {
+	dx, dy := src.X-l.X, src.Y-l.Y
+	d := math.Hypot(dx, dy)
+
+	p := VoiceParams{Volume: c.Attenuation(d), Pitch: 1}
+	if c.PanDistance > 0 {
+		p.Pan = clamp(dx/c.PanDistance, -1, 1)
+	}
+
+	// Doppler: closing speed raises pitch, separating speed lowers it
+	if c.SpeedOfSound > 0 && d > 0 {
+		ux, uy := dx/d, dy/d
+		toward := (l.VX*ux + l.VY*uy) * c.DopplerScale
+		away := (src.VX*ux + src.VY*uy) * c.DopplerScale
+		speed := c.SpeedOfSound
+		p.Pitch = clamp((speed+toward)/math.Max(speed+away, 1), c.MinPitch, c.MaxPitch)
+	}
+
+	if c.NearCutoff > 0 && c.FarCutoff > 0 {
+		p.LowPass = c.NearCutoff * math.Pow(c.FarCutoff/c.NearCutoff, c.falloff(d))
+	}
+	if occluded {
+		p.Volume *= c.OcclusionGain
+		if p.LowPass == 0 || p.LowPass > c.OcclusionCutoff {
+			p.LowPass = c.OcclusionCutoff
+		}
+	}
+	return p
+}
@@ -0,0 +1,193 @@
+package audio
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSpatialConfig_Attenuation(t *testing.T) {
+	for _, curve := range []AttenuationCurve{AttenuationLinear, AttenuationInverse, AttenuationExponential} {
+		t.Run(string(curve), func(t *testing.T) {
+			c := DefaultSpatialConfig()
+			c.Curve = curve
+			if got := c.Attenuation(0); got != 1 {
+				t.Errorf("expected full volume at the listener, got %f", got)
+			}
+			if got := c.Attenuation(c.MinDistance); math.Abs(got-1) > 1e-9 {
+				t.Errorf("expected full volume at MinDistance, got %f", got)
+			}
+			if got := c.Attenuation(c.MaxDistance); math.Abs(got) > 1e-9 {
+				t.Errorf("expected silence at MaxDistance, got %f", got)
+			}
+			prev := 1.0
+			for d := c.MinDistance; d <= c.MaxDistance; d += 10 {
+				got := c.Attenuation(d)
+				if got > prev+1e-9 {
+					t.Fatalf("attenuation rose from %f to %f at %f", prev, got, d)
+				}
+				prev = got
+			}
+		})
+	}
+
+	c := DefaultSpatialConfig()
+	mid := (c.MinDistance + c.MaxDistance) / 2
+	c.Curve = AttenuationLinear
+	linear := c.Attenuation(mid)
+	c.Curve = AttenuationInverse
+	if inverse := c.Attenuation(mid); inverse >= linear {
+		t.Errorf("expected inverse curve to fall faster than linear: %f vs %f", inverse, linear)
+	}
+	c.Curve = AttenuationNone
+	if got := c.Attenuation(c.MaxDistance * 2); got != 1 {
+		t.Errorf("expected no attenuation, got %f", got)
+	}
+	c.Curve = AttenuationExponential
+	c.Rolloff = 0
+	if got := c.Attenuation(mid); math.Abs(got-linear) > 1e-9 {
+		t.Errorf("expected zero rolloff to fall back to linear, got %f", got)
+	}
+}
+
+func TestSpatialConfig_ComputePan(t *testing.T) {
+	c := DefaultSpatialConfig()
+	l := Listener{X: 400, Y: 300}
+	if p := c.Compute(l, SoundSource{X: 400 + c.PanDistance*2, Y: 300}, false); p.Pan != 1 {
+		t.Errorf("expected hard right pan, got %f", p.Pan)
+	}
+	if p := c.Compute(l, SoundSource{X: 400 - c.PanDistance/2, Y: 300}, false); p.Pan != -0.5 {
+		t.Errorf("expected half left pan, got %f", p.Pan)
+	}
+	if p := c.Compute(l, SoundSource{X: 400, Y: 300}, false); p.Pan != 0 || p.Pitch != 1 || p.Volume != 1 {
+		t.Errorf("expected a centered, unshifted sound at the listener, got %+v", p)
+	}
+}
+
+func TestSpatialConfig_ComputeDoppler(t *testing.T) {
+	c := DefaultSpatialConfig()
+	l := Listener{X: 0, Y: 0}
+
+	approaching := c.Compute(l, SoundSource{X: 300, VX: -400}, false)
+	receding := c.Compute(l, SoundSource{X: 300, VX: 400}, false)
+	if approaching.Pitch <= 1 || receding.Pitch >= 1 {
+		t.Errorf("expected approaching pitch > 1 > receding, got %f and %f", approaching.Pitch, receding.Pitch)
+	}
+	want := c.SpeedOfSound / (c.SpeedOfSound - 400)
+	if math.Abs(approaching.Pitch-want) > 1e-9 {
+		t.Errorf("expected pitch %f, got %f", want, approaching.Pitch)
+	}
+
+	moving := c.Compute(Listener{VX: 400}, SoundSource{X: 300}, false)
+	if moving.Pitch <= 1 {
+		t.Errorf("expected listener moving toward the sound to raise pitch, got %f", moving.Pitch)
+	}
+
+	sideways := c.Compute(l, SoundSource{X: 300, VY: 400}, false)
+	if sideways.Pitch != 1 {
+		t.Errorf("expected no shift for sideways motion, got %f", sideways.Pitch)
+	}
+
+	fast := c.Compute(l, SoundSource{X: 300, VX: -1e6}, false)
+	if fast.Pitch != c.MaxPitch {
+		t.Errorf("expected pitch clamped to %f, got %f", c.MaxPitch, fast.Pitch)
+	}
+
+	c.DopplerScale = 0
+	if p := c.Compute(l, SoundSource{X: 300, VX: -400}, false); p.Pitch != 1 {
+		t.Errorf("expected Doppler disabled, got pitch %f", p.Pitch)
+	}
+}
+
+func TestSpatialConfig_ComputeDistanceFilter(t *testing.T) {
+	c := DefaultSpatialConfig()
+	near := c.Compute(Listener{}, SoundSource{X: c.MinDistance}, false)
+	far := c.Compute(Listener{}, SoundSource{X: c.MaxDistance * 0.9}, false)
+	if near.LowPass != c.NearCutoff {
+		t.Errorf("expected near cutoff %f, got %f", c.NearCutoff, near.LowPass)
+	}
+	if far.LowPass >= near.LowPass || far.LowPass < c.FarCutoff {
+		t.Errorf("expected distant sounds muffled toward %f, got %f", c.FarCutoff, far.LowPass)
+	}
+
+	c.NearCutoff = 0
+	if p := c.Compute(Listener{}, SoundSource{X: 500}, false); p.LowPass != 0 {
+		t.Errorf("expected distance filtering disabled, got %f", p.LowPass)
+	}
+}
+
+func TestSpatialConfig_ComputeOcclusion(t *testing.T) {
+	c := DefaultSpatialConfig()
+	src := SoundSource{X: 100}
+	unblocked := c.Compute(Listener{}, src, false)
+	blocked := c.Compute(Listener{}, src, true)
+	if math.Abs(blocked.Volume-unblocked.Volume*c.OcclusionGain) > 1e-9 {
+		t.Errorf("expected occlusion to scale volume by %f: %f vs %f", c.OcclusionGain, blocked.Volume, unblocked.Volume)
+	}
+	if blocked.LowPass != c.OcclusionCutoff {
+		t.Errorf("expected occluded cutoff %f, got %f", c.OcclusionCutoff, blocked.LowPass)
+	}
+}
+
+func TestMixer_PitchAndLowPass(t *testing.T) {
+	tone := Synthesize(SFXParams{Wave: WaveSquare, Sustain: 1, SustainTime: 0.5, Frequency: 2000, Volume: 0.5})
+
+	m := NewMixer()
+	m.PlayVoice("laser", tone, VoiceParams{Volume: 1, Pitch: 1})
+	normal := readSeconds(t, m, 0.2)
+
+	m = NewMixer()
+	m.PlayVoice("laser", tone, VoiceParams{Volume: 1, Pitch: 1.5})
+	raised := readSeconds(t, m, 0.2)
+	if ratio := float64(zeroCrossings(raised)) / float64(zeroCrossings(normal)); math.Abs(ratio-1.5) > 0.05 {
+		t.Errorf("expected pitch 1.5 to raise frequency by 1.5, got %f", ratio)
+	}
+
+	m = NewMixer()
+	m.PlayVoice("laser", tone, VoiceParams{Volume: 1, Pitch: 1, LowPass: 300})
+	if muffled := readSeconds(t, m, 0.2); rms(muffled) > rms(normal)*0.5 {
+		t.Errorf("expected low-pass to cut a 2 kHz tone: rms %f vs %f", rms(muffled), rms(normal))
+	}
+
+	m = NewMixer()
+	m.PlayVoice("laser", tone, VoiceParams{Volume: 1, Pitch: 2})
+	readSeconds(t, m, 0.3)
+	if m.ActiveVoices() != 0 {
+		t.Error("expected a sped-up sound to finish early")
+	}
+}
+
+func TestManager_PositionalSFX(t *testing.T) {
+	m := NewManager()
+	m.audioBackend = &recordingBackend{}
+	m.SetListener(1000, 1000, 0, 0)
+
+	m.PlaySFXAt("explosion", 0, 0, true)
+	m.Update()
+	if n := m.Mixer().ActiveVoices(); n != 0 {
+		t.Errorf("expected a sound far from the listener to be culled, got %d voices", n)
+	}
+
+	m.PlaySFXMoving("explosion", 1100, 1000, -300, 0)
+	m.Update()
+	if n := m.Mixer().ActiveVoices(); n != 1 {
+		t.Errorf("expected a nearby sound to play, got %d voices", n)
+	}
+
+	var blockedFrom [4]float64
+	m.SetOccluder(func(x1, y1, x2, y2 float64) bool {
+		blockedFrom = [4]float64{x1, y1, x2, y2}
+		return true
+	})
+	m.PlaySFXAt("hit", 1050, 1000, true)
+	m.Update()
+	if blockedFrom != [4]float64{1000, 1000, 1050, 1000} {
+		t.Errorf("expected occlusion tested from listener to source, got %v", blockedFrom)
+	}
+
+	c := DefaultSpatialConfig()
+	c.Curve = AttenuationNone
+	m.SetSpatialConfig(c)
+	if m.SpatialConfig().Curve != AttenuationNone {
+		t.Error("expected spatial config to be stored")
+	}
+}
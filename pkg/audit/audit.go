@@ -1,4 +1,4 @@
-// Package audit provides frame-time telemetry and entity-count logging.
+// Package audit provides frame-time telemetry, entity-count and draw-call logging.
 package audit
 
 import "time"
@@ -8,6 +8,7 @@ type FrameSample struct {
 	Timestamp   time.Time
 	DurationMs  float64
 	EntityCount int
+	DrawCalls   int
 }
 
 // Logger collects audit samples.
@@ -29,6 +30,16 @@ func (l *Logger) Record(duration float64, entityCount int) {
 	})
 }
 
+// RecordFrame adds a frame sample including the number of draw calls issued.
+func (l *Logger) RecordFrame(duration float64, entityCount, drawCalls int) {
+	l.samples = append(l.samples, FrameSample{
+		Timestamp:   time.Now(),
+		DurationMs:  duration,
+		EntityCount: entityCount,
+		DrawCalls:   drawCalls,
+	})
+}
+
 // Samples returns all recorded samples.
 func (l *Logger) Samples() []FrameSample {
 	return l.samples
@@ -38,3 +49,41 @@ func (l *Logger) Samples() []FrameSample {
 func (l *Logger) Reset() {
 	l.samples = l.samples[:0]
 }
+
+// MaxDrawCalls returns the highest draw-call count among recorded samples.
+func (l *Logger) MaxDrawCalls() int {
+	maxCalls := 0
+	for _, s := range l.samples {
+		if s.DrawCalls > maxCalls {
+			maxCalls = s.DrawCalls
+		}
+	}
+	return maxCalls
+}
+
+// DrawCallCounter tallies the draw calls issued during a frame.
+type DrawCallCounter struct {
+	current, last int
+}
+
+// Add records n draw calls in the current frame.
+func (c *DrawCallCounter) Add(n int) {
+	c.current += n
+}
+
+// Count returns the draw calls recorded so far this frame.
+func (c *DrawCallCounter) Count() int {
+	return c.current
+}
+
+// EndFrame closes the current frame, returning its total and starting a new count.
+func (c *DrawCallCounter) EndFrame() int {
+	c.last = c.current
+	c.current = 0
+	return c.last
+}
+
+// Last returns the total of the most recently completed frame.
+func (c *DrawCallCounter) Last() int {
+	return c.last
+}
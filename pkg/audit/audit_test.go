@@ -117,3 +117,40 @@ func TestFrameSampleFields(t *testing.T) {
 		t.Errorf("EntityCount = %d, want 200", sample.EntityCount)
 	}
 }
+
+func TestLoggerRecordFrame(t *testing.T) {
+	logger := NewLogger()
+	logger.RecordFrame(16.0, 50, 12)
+	logger.RecordFrame(17.0, 60, 30)
+	logger.Record(15.0, 40)
+
+	samples := logger.Samples()
+	if samples[0].DrawCalls != 12 || samples[1].DrawCalls != 30 || samples[2].DrawCalls != 0 {
+		t.Errorf("unexpected draw calls: %+v", samples)
+	}
+	if samples[1].EntityCount != 60 {
+		t.Errorf("EntityCount = %d, want 60", samples[1].EntityCount)
+	}
+	if got := logger.MaxDrawCalls(); got != 30 {
+		t.Errorf("MaxDrawCalls() = %d, want 30", got)
+	}
+}
+
+func TestDrawCallCounter(t *testing.T) {
+	var c DrawCallCounter
+	c.Add(3)
+	c.Add(2)
+	if c.Count() != 5 {
+		t.Errorf("Count() = %d, want 5", c.Count())
+	}
+	if got := c.EndFrame(); got != 5 {
+		t.Errorf("EndFrame() = %d, want 5", got)
+	}
+	if c.Count() != 0 {
+		t.Errorf("expected count to restart after EndFrame, got %d", c.Count())
+	}
+	c.Add(1)
+	if c.Last() != 5 {
+		t.Errorf("Last() = %d, want 5", c.Last())
+	}
+}
@@ -12,6 +12,22 @@ const (
 	WeaponBomb
 )
 
+// weaponTypeNames are the display names of the weapon classes.
+var weaponTypeNames = map[WeaponType]string{
+	WeaponPrimary:   "Blaster",
+	WeaponSecondary: "Cannon",
+	WeaponMissile:   "Missiles",
+	WeaponBomb:      "Bombs",
+}
+
+// String returns the weapon class's display name.
+func (wt WeaponType) String() string {
+	if name, ok := weaponTypeNames[wt]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
 // Weapon represents a ship weapon.
 type Weapon struct {
 	Type     WeaponType
@@ -37,6 +53,15 @@ func (w *Weapon) Fire() {
 	}
 }
 
+// CooldownFraction returns the part of the cooldown still to run, from 1
+// just after firing to 0 when the weapon is ready.
+func (w *Weapon) CooldownFraction() float64 {
+	if w.timer <= 0 || w.Cooldown <= 0 {
+		return 0
+	}
+	return min(1, w.timer/w.Cooldown)
+}
+
 // Update advances the weapon cooldown by dt seconds.
 func (w *Weapon) Update(dt float64) {
 	if w.timer > 0 {
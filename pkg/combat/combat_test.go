@@ -1,6 +1,7 @@
 package combat
 
 import (
+	"math"
 	"testing"
 )
 
@@ -81,6 +82,37 @@ func TestWeaponTypes(t *testing.T) {
 	}
 }
 
+func TestWeaponCooldownFraction(t *testing.T) {
+	w := NewWeapon(WeaponPrimary, 10, 0.2)
+	if f := w.CooldownFraction(); f != 0 {
+		t.Errorf("ready weapon fraction = %f, want 0", f)
+	}
+
+	w.Fire()
+	if f := w.CooldownFraction(); f != 1 {
+		t.Errorf("fraction just after firing = %f, want 1", f)
+	}
+	w.Update(0.05)
+	if f := w.CooldownFraction(); math.Abs(f-0.75) > 1e-9 {
+		t.Errorf("fraction after a quarter of the cooldown = %f, want 0.75", f)
+	}
+	w.Update(0.5)
+	if f := w.CooldownFraction(); f != 0 {
+		t.Errorf("fraction after cooldown = %f, want 0", f)
+	}
+}
+
+func TestWeaponTypeString(t *testing.T) {
+	for _, wt := range []WeaponType{WeaponPrimary, WeaponSecondary, WeaponMissile, WeaponBomb} {
+		if name := wt.String(); name == "" || name == "Unknown" {
+			t.Errorf("weapon type %d has no display name", int(wt))
+		}
+	}
+	if name := WeaponType(99).String(); name != "Unknown" {
+		t.Errorf("unknown weapon type name = %q", name)
+	}
+}
+
 func TestNewStatusEffect(t *testing.T) {
 	se := NewStatusEffect("slowed", 5.0)
 
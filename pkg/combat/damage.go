@@ -27,6 +27,7 @@ type DamageSystem struct {
 	pendingDamage []DamageEvent
 	pendingDeaths []DeathEvent
 	onDeath       func(DeathEvent)
+	onDamage      func(DamageEvent)
 }
 
 // NewDamageSystem creates a new damage system.
@@ -43,6 +44,12 @@ func (ds *DamageSystem) SetDeathCallback(fn func(DeathEvent)) {
 	ds.onDeath = fn
 }
 
+// SetDamageCallback sets the callback for damage that reaches an entity's
+// health. The event amount is what was actually applied after shields.
+func (ds *DamageSystem) SetDamageCallback(fn func(DamageEvent)) {
+	ds.onDamage = fn
+}
+
 // QueueDamage queues damage to be applied on next update.
 func (ds *DamageSystem) QueueDamage(target, source engine.Entity, amount float64, sourceType string) {
 	ds.pendingDamage = append(ds.pendingDamage, DamageEvent{
@@ -55,9 +62,24 @@ func (ds *DamageSystem) QueueDamage(target, source engine.Entity, amount float64
 
 // ApplyDamage immediately applies damage to an entity.
 func (ds *DamageSystem) ApplyDamage(target engine.Entity, amount float64) bool {
+	_, died := ds.applyDamage(target, amount)
+	return died
+}
+
+// applyDamage applies damage and returns the amount that reached the hull
+// and whether the entity died.
+func (ds *DamageSystem) applyDamage(target engine.Entity, amount float64) (float64, bool) {
 	healthComp, hasHealth := ds.world.GetComponent(target, "health")
 	if !hasHealth {
-		return false
+		return 0, false
+	}
+
+	// Shields and invulnerability soak damage before it reaches the hull
+	if powerUps := getPowerUps(ds.world, target); powerUps != nil {
+		amount = powerUps.AbsorbDamage(amount)
+		if amount <= 0 {
+			return 0, false
+		}
 	}
 
 	health := healthComp.(*Health)
@@ -65,10 +87,10 @@ func (ds *DamageSystem) ApplyDamage(target engine.Entity, amount float64) bool {
 
 	if health.Current <= 0 {
 		health.Current = 0
-		return true // Entity died
+		return amount, true // Entity died
 	}
 
-	return false
+	return amount, false
 }
 
 // Update processes all pending damage and removes dead entities.
@@ -77,8 +99,12 @@ func (ds *DamageSystem) Update(dt float64) {
 
 	// Process pending damage
 	for _, event := range ds.pendingDamage {
-		if ds.ApplyDamage(event.Target, event.Amount) {
-			// Entity died
+		applied, died := ds.applyDamage(event.Target, event.Amount)
+		if applied > 0 && ds.onDamage != nil {
+			event.Amount = applied
+			ds.onDamage(event)
+		}
+		if died {
 			ds.handleDeath(event.Target)
 		}
 	}
@@ -130,6 +130,35 @@ func TestDamageSystem_DeathCallback(t *testing.T) {
 	}
 }
 
+func TestDamageSystem_DamageCallback(t *testing.T) {
+	world := engine.NewWorld()
+	ds := NewDamageSystem(world)
+
+	entity := world.CreateEntity()
+	world.AddComponent(entity, "health", &Health{Current: 100, Max: 100})
+	shielded := world.CreateEntity()
+	world.AddComponent(shielded, "health", &Health{Current: 100, Max: 100})
+	powerUps := NewPowerUpComponent()
+	powerUps.Apply(PowerUpInvulnerability)
+	world.AddComponent(shielded, "powerups", powerUps)
+
+	var events []DamageEvent
+	ds.SetDamageCallback(func(event DamageEvent) {
+		events = append(events, event)
+	})
+
+	ds.QueueDamage(entity, 0, 25, "projectile")
+	ds.QueueDamage(shielded, 0, 25, "projectile")
+	ds.Update(0)
+
+	if len(events) != 1 {
+		t.Fatalf("expected only hull damage reported, got %d events", len(events))
+	}
+	if events[0].Target != entity || events[0].Amount != 25 || events[0].SourceType != "projectile" {
+		t.Errorf("unexpected damage event %+v", events[0])
+	}
+}
+
 func TestDamageSystem_EntityRemoved(t *testing.T) {
 	world := engine.NewWorld()
 	ds := NewDamageSystem(world)
@@ -0,0 +1,376 @@
+// Package combat provides weapons, damage calculation, hit detection,
+// and status effects.
+package combat
+
+import (
+	"image/color"
+
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+// PowerUpType identifies a timed player modifier.
+type PowerUpType int
+
+const (
+	PowerUpRapidFire PowerUpType = iota
+	PowerUpSpreadShot
+	PowerUpShield
+	PowerUpTimeSlow
+	PowerUpScoreMultiplier
+	PowerUpInvulnerability
+)
+
+// StackRule determines what happens when a power-up is collected while
+// the same power-up is already active.
+type StackRule int
+
+const (
+	// StackRefresh resets the remaining time to the full duration.
+	StackRefresh StackRule = iota
+	// StackExtend adds the full duration to the remaining time, capped at MaxDuration.
+	StackExtend
+	// StackIntensify adds a stack level (up to MaxStacks) and refreshes the timer.
+	StackIntensify
+)
+
+// Power-up tuning constants.
+const (
+	// PowerUpPickupLifetime is how long an uncollected pickup stays in the arena.
+	PowerUpPickupLifetime = 10.0
+	// PowerUpPickupSize is the pixel size of pickup sprites and hitboxes.
+	PowerUpPickupSize = 12
+	// ShieldCapacity is the damage a fresh shield bubble absorbs.
+	ShieldCapacity = 50.0
+	// SpreadShotAngle is the angle in radians between spread shot projectiles.
+	SpreadShotAngle = 0.2
+	// TimeSlowScale is the time scale applied to enemies while time slow is active.
+	TimeSlowScale = 0.5
+)
+
+// PowerUpDef describes the duration, stacking and visuals of a power-up type.
+type PowerUpDef struct {
+	Type        PowerUpType
+	Name        string
+	Duration    float64
+	MaxDuration float64
+	MaxStacks   int
+	Rule        StackRule
+	AuraColor   color.RGBA
+}
+
+// powerUpDefs holds the definition for each power-up type, indexed by type.
+var powerUpDefs = []PowerUpDef{
+	PowerUpRapidFire: {
+		Type: PowerUpRapidFire, Name: "Rapid Fire", Duration: 8, MaxDuration: 8, MaxStacks: 3,
+		Rule: StackIntensify, AuraColor: color.RGBA{R: 255, G: 160, B: 40, A: 255},
+	},
+	PowerUpSpreadShot: {
+		Type: PowerUpSpreadShot, Name: "Spread Shot", Duration: 10, MaxDuration: 10, MaxStacks: 2,
+		Rule: StackIntensify, AuraColor: color.RGBA{R: 120, G: 255, B: 120, A: 255},
+	},
+	PowerUpShield: {
+		Type: PowerUpShield, Name: "Shield", Duration: 12, MaxDuration: 12, MaxStacks: 1,
+		Rule: StackRefresh, AuraColor: color.RGBA{R: 80, G: 160, B: 255, A: 255},
+	},
+	PowerUpTimeSlow: {
+		Type: PowerUpTimeSlow, Name: "Time Slow", Duration: 5, MaxDuration: 10, MaxStacks: 1,
+		Rule: StackExtend, AuraColor: color.RGBA{R: 200, G: 120, B: 255, A: 255},
+	},
+	PowerUpScoreMultiplier: {
+		Type: PowerUpScoreMultiplier, Name: "Score x", Duration: 15, MaxDuration: 15, MaxStacks: 3,
+		Rule: StackIntensify, AuraColor: color.RGBA{R: 255, G: 230, B: 80, A: 255},
+	},
+	PowerUpInvulnerability: {
+		Type: PowerUpInvulnerability, Name: "Invulnerable", Duration: 4, MaxDuration: 8, MaxStacks: 1,
+		Rule: StackExtend, AuraColor: color.RGBA{R: 255, G: 255, B: 255, A: 255},
+	},
+}
+
+// AllPowerUpTypes returns every power-up type in definition order.
+func AllPowerUpTypes() []PowerUpType {
+	types := make([]PowerUpType, len(powerUpDefs))
+	for i := range powerUpDefs {
+		types[i] = powerUpDefs[i].Type
+	}
+	return types
+}
+
+// GetPowerUpDef returns the definition for a power-up type.
+// Unknown types fall back to the rapid fire definition.
+func GetPowerUpDef(t PowerUpType) PowerUpDef {
+	if t < 0 || int(t) >= len(powerUpDefs) {
+		return powerUpDefs[PowerUpRapidFire]
+	}
+	return powerUpDefs[t]
+}
+
+// ActivePowerUp is a power-up currently affecting an entity.
+type ActivePowerUp struct {
+	Type      PowerUpType
+	Remaining float64
+	Stacks    int
+	ShieldHP  float64
+}
+
+// PowerUpComponent tracks all power-ups active on an entity.
+type PowerUpComponent struct {
+	Active []ActivePowerUp
+	// ShieldsDisabled suppresses shield absorption (e.g. during a solar flare).
+	ShieldsDisabled bool
+}
+
+// NewPowerUpComponent creates an empty power-up component.
+func NewPowerUpComponent() *PowerUpComponent {
+	return &PowerUpComponent{Active: make([]ActivePowerUp, 0, len(powerUpDefs))}
+}
+
+// Apply activates a power-up, following its stacking rule if already active.
+func (pc *PowerUpComponent) Apply(t PowerUpType) {
+	def := GetPowerUpDef(t)
+	if active, ok := pc.Get(def.Type); ok {
+		applyStackRule(active, def)
+		return
+	}
+	pc.Active = append(pc.Active, ActivePowerUp{
+		Type:      def.Type,
+		Remaining: def.Duration,
+		Stacks:    1,
+		ShieldHP:  shieldHPFor(def.Type),
+	})
+}
+
+// applyStackRule updates an already active power-up when collected again.
+func applyStackRule(active *ActivePowerUp, def PowerUpDef) {
+	switch def.Rule {
+	case StackRefresh:
+		active.Remaining = def.Duration
+	case StackExtend:
+		active.Remaining += def.Duration
+		if active.Remaining > def.MaxDuration {
+			active.Remaining = def.MaxDuration
+		}
+	case StackIntensify:
+		if active.Stacks < def.MaxStacks {
+			active.Stacks++
+		}
+		active.Remaining = def.Duration
+	}
+	active.ShieldHP = shieldHPFor(def.Type)
+}
+
+// shieldHPFor returns the starting shield capacity for a power-up type.
+func shieldHPFor(t PowerUpType) float64 {
+	if t == PowerUpShield {
+		return ShieldCapacity
+	}
+	return 0
+}
+
+// Update advances all power-up timers by dt seconds and drops expired ones.
+func (pc *PowerUpComponent) Update(dt float64) {
+	alive := pc.Active[:0]
+	for _, p := range pc.Active {
+		p.Remaining -= dt
+		if p.Remaining > 0 {
+			alive = append(alive, p)
+		}
+	}
+	pc.Active = alive
+}
+
+// Get returns the active power-up of the given type, if any.
+func (pc *PowerUpComponent) Get(t PowerUpType) (*ActivePowerUp, bool) {
+	for i := range pc.Active {
+		if pc.Active[i].Type == t {
+			return &pc.Active[i], true
+		}
+	}
+	return nil, false
+}
+
+// Has returns true if the given power-up type is active.
+func (pc *PowerUpComponent) Has(t PowerUpType) bool {
+	_, ok := pc.Get(t)
+	return ok
+}
+
+// stacks returns the stack count of a power-up, or 0 if inactive.
+func (pc *PowerUpComponent) stacks(t PowerUpType) int {
+	if p, ok := pc.Get(t); ok {
+		return p.Stacks
+	}
+	return 0
+}
+
+// CooldownScale returns the multiplier applied to weapon cooldowns.
+func (pc *PowerUpComponent) CooldownScale() float64 {
+	return 1.0 / float64(1+pc.stacks(PowerUpRapidFire))
+}
+
+// SpreadCount returns the number of projectiles fired per shot.
+func (pc *PowerUpComponent) SpreadCount() int {
+	return 1 + 2*pc.stacks(PowerUpSpreadShot)
+}
+
+// ScoreMultiplier returns the multiplier applied to kill scores.
+func (pc *PowerUpComponent) ScoreMultiplier() int {
+	return 1 + pc.stacks(PowerUpScoreMultiplier)
+}
+
+// TimeScale returns the time scale applied to enemies (1 = normal speed).
+func (pc *PowerUpComponent) TimeScale() float64 {
+	if pc.Has(PowerUpTimeSlow) {
+		return TimeSlowScale
+	}
+	return 1.0
+}
+
+// AbsorbDamage filters incoming damage through invulnerability and shields,
+// returning the damage that still reaches the hull.
+func (pc *PowerUpComponent) AbsorbDamage(amount float64) float64 {
+	if pc.Has(PowerUpInvulnerability) {
+		return 0
+	}
+	shield, ok := pc.Get(PowerUpShield)
+	if !ok || pc.ShieldsDisabled {
+		return amount
+	}
+	if amount < shield.ShieldHP {
+		shield.ShieldHP -= amount
+		return 0
+	}
+	amount -= shield.ShieldHP
+	shield.ShieldHP = 0
+	shield.Remaining = 0
+	pc.Update(0)
+	return amount
+}
+
+// Pickup component marks a collectible power-up in the arena.
+type Pickup struct {
+	Type     PowerUpType
+	Lifetime float64
+}
+
+// PowerUpSystem ticks power-up timers and handles pickup collection.
+type PowerUpSystem struct {
+	world        *engine.World
+	playerEntity engine.Entity
+	toRemove     []engine.Entity
+	onCollect    func(entity engine.Entity, t PowerUpType)
+}
+
+// NewPowerUpSystem creates a new power-up system.
+func NewPowerUpSystem(world *engine.World) *PowerUpSystem {
+	return &PowerUpSystem{
+		world:    world,
+		toRemove: make([]engine.Entity, 0, 8),
+	}
+}
+
+// SetPlayerEntity sets which entity can collect pickups.
+func (ps *PowerUpSystem) SetPlayerEntity(entity engine.Entity) {
+	ps.playerEntity = entity
+}
+
+// SetCollectCallback sets the callback for when a pickup is collected.
+func (ps *PowerUpSystem) SetCollectCallback(fn func(entity engine.Entity, t PowerUpType)) {
+	ps.onCollect = fn
+}
+
+// SpawnPickup creates a collectible power-up entity at the given position.
+func (ps *PowerUpSystem) SpawnPickup(x, y float64, t PowerUpType) engine.Entity {
+	e := ps.world.CreateEntity()
+	half := float64(PowerUpPickupSize) / 2
+
+	ps.world.AddComponent(e, "position", &engine.Position{X: x, Y: y})
+	ps.world.AddComponent(e, "pickup", &Pickup{Type: t, Lifetime: PowerUpPickupLifetime})
+	ps.world.AddComponent(e, "collisiontag", &CollisionTag{Tag: "pickup"})
+	ps.world.AddComponent(e, "boundingbox", &BoundingBox{
+		X: -half, Y: -half, Width: PowerUpPickupSize, Height: PowerUpPickupSize,
+	})
+
+	return e
+}
+
+// Update ticks active power-ups, expires pickups and checks collection.
+func (ps *PowerUpSystem) Update(dt float64) {
+	ps.toRemove = ps.toRemove[:0]
+
+	ps.world.ForEachEntity(func(e engine.Entity) {
+		if comp, ok := ps.world.GetComponent(e, "powerups"); ok {
+			comp.(*PowerUpComponent).Update(dt)
+		}
+		if comp, ok := ps.world.GetComponent(e, "pickup"); ok {
+			ps.updatePickup(e, comp.(*Pickup), dt)
+		}
+	})
+
+	for _, e := range ps.toRemove {
+		ps.world.RemoveEntity(e)
+	}
+}
+
+// updatePickup expires a pickup or hands it to the player on contact.
+func (ps *PowerUpSystem) updatePickup(e engine.Entity, pickup *Pickup, dt float64) {
+	pickup.Lifetime -= dt
+	if pickup.Lifetime <= 0 {
+		ps.toRemove = append(ps.toRemove, e)
+		return
+	}
+
+	if ps.playerEntity == 0 || !ps.overlapsPlayer(e) {
+		return
+	}
+
+	ps.Collect(ps.playerEntity, pickup.Type)
+	ps.toRemove = append(ps.toRemove, e)
+}
+
+// overlapsPlayer returns true if the pickup's box overlaps the player's box.
+func (ps *PowerUpSystem) overlapsPlayer(e engine.Entity) bool {
+	pickupPos, pickupBox := entityBounds(ps.world, e)
+	playerPos, playerBox := entityBounds(ps.world, ps.playerEntity)
+	if pickupPos == nil || playerPos == nil {
+		return false
+	}
+	return CheckAABBCollision(
+		pickupPos.X+pickupBox.X, pickupPos.Y+pickupBox.Y, pickupBox.Width, pickupBox.Height,
+		playerPos.X+playerBox.X, playerPos.Y+playerBox.Y, playerBox.Width, playerBox.Height,
+	)
+}
+
+// Collect applies a power-up to an entity, creating its component if needed.
+func (ps *PowerUpSystem) Collect(entity engine.Entity, t PowerUpType) {
+	comp, ok := ps.world.GetComponent(entity, "powerups")
+	if !ok {
+		comp = NewPowerUpComponent()
+		ps.world.AddComponent(entity, "powerups", comp)
+	}
+	comp.(*PowerUpComponent).Apply(t)
+
+	if ps.onCollect != nil {
+		ps.onCollect(entity, t)
+	}
+}
+
+// entityBounds returns an entity's position and bounding box, defaulting to a 16px box.
+func entityBounds(world *engine.World, e engine.Entity) (*engine.Position, *BoundingBox) {
+	posComp, hasPos := world.GetComponent(e, "position")
+	if !hasPos {
+		return nil, nil
+	}
+	if boxComp, hasBox := world.GetComponent(e, "boundingbox"); hasBox {
+		return posComp.(*engine.Position), boxComp.(*BoundingBox)
+	}
+	return posComp.(*engine.Position), &BoundingBox{X: -8, Y: -8, Width: 16, Height: 16}
+}
+
+// getPowerUps returns the power-up component for an entity, or nil.
+func getPowerUps(world *engine.World, e engine.Entity) *PowerUpComponent {
+	comp, ok := world.GetComponent(e, "powerups")
+	if !ok {
+		return nil
+	}
+	return comp.(*PowerUpComponent)
+}
@@ -0,0 +1,231 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+func TestAllPowerUpTypes(t *testing.T) {
+	types := AllPowerUpTypes()
+	if len(types) != 6 {
+		t.Fatalf("expected 6 power-up types, got %d", len(types))
+	}
+	for i, pt := range types {
+		def := GetPowerUpDef(pt)
+		if def.Type != pt {
+			t.Errorf("def %d has type %d, want %d", i, def.Type, pt)
+		}
+		if def.Duration <= 0 || def.Name == "" {
+			t.Errorf("def %d is incomplete: %+v", i, def)
+		}
+	}
+}
+
+func TestPowerUpComponent_ApplyAndExpire(t *testing.T) {
+	pc := NewPowerUpComponent()
+	pc.Apply(PowerUpRapidFire)
+
+	if !pc.Has(PowerUpRapidFire) {
+		t.Fatal("expected rapid fire to be active")
+	}
+
+	pc.Update(GetPowerUpDef(PowerUpRapidFire).Duration + 0.1)
+	if pc.Has(PowerUpRapidFire) {
+		t.Error("expected rapid fire to expire")
+	}
+}
+
+func TestPowerUpComponent_StackRefresh(t *testing.T) {
+	pc := NewPowerUpComponent()
+	pc.Apply(PowerUpShield)
+	pc.Update(5)
+	pc.Apply(PowerUpShield)
+
+	p, _ := pc.Get(PowerUpShield)
+	if p.Remaining != GetPowerUpDef(PowerUpShield).Duration {
+		t.Errorf("expected shield refreshed to full duration, got %f", p.Remaining)
+	}
+	if p.Stacks != 1 {
+		t.Errorf("expected shield to stay at 1 stack, got %d", p.Stacks)
+	}
+}
+
+func TestPowerUpComponent_StackExtend(t *testing.T) {
+	pc := NewPowerUpComponent()
+	def := GetPowerUpDef(PowerUpTimeSlow)
+
+	pc.Apply(PowerUpTimeSlow)
+	pc.Update(1)
+	pc.Apply(PowerUpTimeSlow)
+
+	p, _ := pc.Get(PowerUpTimeSlow)
+	want := def.Duration*2 - 1
+	if want > def.MaxDuration {
+		want = def.MaxDuration
+	}
+	if p.Remaining != want {
+		t.Errorf("expected remaining %f, got %f", want, p.Remaining)
+	}
+
+	for i := 0; i < 5; i++ {
+		pc.Apply(PowerUpTimeSlow)
+	}
+	p, _ = pc.Get(PowerUpTimeSlow)
+	if p.Remaining != def.MaxDuration {
+		t.Errorf("expected remaining capped at %f, got %f", def.MaxDuration, p.Remaining)
+	}
+}
+
+func TestPowerUpComponent_StackIntensify(t *testing.T) {
+	pc := NewPowerUpComponent()
+	def := GetPowerUpDef(PowerUpScoreMultiplier)
+
+	for i := 0; i < def.MaxStacks+2; i++ {
+		pc.Apply(PowerUpScoreMultiplier)
+	}
+
+	p, _ := pc.Get(PowerUpScoreMultiplier)
+	if p.Stacks != def.MaxStacks {
+		t.Errorf("expected stacks capped at %d, got %d", def.MaxStacks, p.Stacks)
+	}
+	if pc.ScoreMultiplier() != 1+def.MaxStacks {
+		t.Errorf("expected multiplier %d, got %d", 1+def.MaxStacks, pc.ScoreMultiplier())
+	}
+}
+
+func TestPowerUpComponent_Modifiers(t *testing.T) {
+	pc := NewPowerUpComponent()
+
+	if pc.CooldownScale() != 1 || pc.SpreadCount() != 1 || pc.ScoreMultiplier() != 1 || pc.TimeScale() != 1 {
+		t.Fatal("expected neutral modifiers with no power-ups")
+	}
+
+	pc.Apply(PowerUpRapidFire)
+	pc.Apply(PowerUpSpreadShot)
+	pc.Apply(PowerUpTimeSlow)
+
+	if pc.CooldownScale() != 0.5 {
+		t.Errorf("expected cooldown scale 0.5, got %f", pc.CooldownScale())
+	}
+	if pc.SpreadCount() != 3 {
+		t.Errorf("expected spread count 3, got %d", pc.SpreadCount())
+	}
+	if pc.TimeScale() != TimeSlowScale {
+		t.Errorf("expected time scale %f, got %f", TimeSlowScale, pc.TimeScale())
+	}
+}
+
+func TestPowerUpComponent_ShieldAbsorbs(t *testing.T) {
+	pc := NewPowerUpComponent()
+	pc.Apply(PowerUpShield)
+
+	if got := pc.AbsorbDamage(20); got != 0 {
+		t.Errorf("expected shield to absorb 20 damage, %f leaked", got)
+	}
+	if got := pc.AbsorbDamage(ShieldCapacity); got != 20 {
+		t.Errorf("expected 20 damage to break through, got %f", got)
+	}
+	if pc.Has(PowerUpShield) {
+		t.Error("expected depleted shield to be removed")
+	}
+}
+
+func TestPowerUpComponent_Invulnerability(t *testing.T) {
+	pc := NewPowerUpComponent()
+	pc.Apply(PowerUpInvulnerability)
+
+	if got := pc.AbsorbDamage(1000); got != 0 {
+		t.Errorf("expected invulnerability to block all damage, got %f", got)
+	}
+}
+
+func TestDamageSystem_PowerUpShield(t *testing.T) {
+	world := engine.NewWorld()
+	ds := NewDamageSystem(world)
+
+	e := world.CreateEntity()
+	world.AddComponent(e, "health", &Health{Current: 100, Max: 100})
+	pc := NewPowerUpComponent()
+	pc.Apply(PowerUpShield)
+	world.AddComponent(e, "powerups", pc)
+
+	ds.ApplyDamage(e, 30)
+
+	comp, _ := world.GetComponent(e, "health")
+	if comp.(*Health).Current != 100 {
+		t.Errorf("expected shield to protect health, got %f", comp.(*Health).Current)
+	}
+}
+
+func TestPowerUpSystem_CollectPickup(t *testing.T) {
+	world := engine.NewWorld()
+	ps := NewPowerUpSystem(world)
+
+	player := world.CreateEntity()
+	world.AddComponent(player, "position", &engine.Position{X: 100, Y: 100})
+	world.AddComponent(player, "boundingbox", &BoundingBox{X: -8, Y: -8, Width: 16, Height: 16})
+	ps.SetPlayerEntity(player)
+
+	var collected PowerUpType = -1
+	ps.SetCollectCallback(func(_ engine.Entity, pt PowerUpType) {
+		collected = pt
+	})
+
+	pickup := ps.SpawnPickup(102, 100, PowerUpSpreadShot)
+	ps.Update(frameStep)
+
+	if collected != PowerUpSpreadShot {
+		t.Errorf("expected spread shot collected, got %d", collected)
+	}
+	if _, ok := world.GetComponent(pickup, "pickup"); ok {
+		t.Error("expected pickup entity to be removed")
+	}
+	comp, ok := world.GetComponent(player, "powerups")
+	if !ok || !comp.(*PowerUpComponent).Has(PowerUpSpreadShot) {
+		t.Error("expected player to have spread shot")
+	}
+}
+
+func TestPowerUpSystem_PickupExpires(t *testing.T) {
+	world := engine.NewWorld()
+	ps := NewPowerUpSystem(world)
+
+	pickup := ps.SpawnPickup(0, 0, PowerUpShield)
+	ps.Update(PowerUpPickupLifetime + 1)
+
+	if _, ok := world.GetComponent(pickup, "pickup"); ok {
+		t.Error("expected expired pickup to be removed")
+	}
+}
+
+func TestWeaponSystem_SpreadShotAndRapidFire(t *testing.T) {
+	world := engine.NewWorld()
+	projSys := NewProjectileSystem(world)
+	ws := NewWeaponSystem(world, projSys)
+	ws.SetFireProvider(&mockFireProvider{firePressed: true})
+
+	e := world.CreateEntity()
+	world.AddComponent(e, "position", &engine.Position{X: 100, Y: 100})
+	world.AddComponent(e, "rotation", &engine.Rotation{Angle: 0})
+	world.AddComponent(e, "collisiontag", &CollisionTag{Tag: "player"})
+	weapon := NewWeapon(WeaponPrimary, 10.0, 1.0)
+	world.AddComponent(e, "weapon", NewWeaponComponent(weapon))
+
+	pc := NewPowerUpComponent()
+	pc.Apply(PowerUpSpreadShot)
+	pc.Apply(PowerUpRapidFire)
+	world.AddComponent(e, "powerups", pc)
+
+	ws.Update(frameStep)
+
+	if got := projSys.ProjectileCount(); got != 3 {
+		t.Errorf("expected 3 spread projectiles, got %d", got)
+	}
+	if weapon.timer != 0.5 {
+		t.Errorf("expected rapid fire cooldown 0.5, got %f", weapon.timer)
+	}
+}
+
+// frameStep is a single 60 Hz frame used by power-up tests.
+const frameStep = 1.0 / 60.0
@@ -29,9 +29,14 @@ type BoundingBox struct {
 	Width, Height float64
 }
 
+// Bounds returns the box's offset from the entity position and its size.
+func (b *BoundingBox) Bounds() (x, y, width, height float64) {
+	return b.X, b.Y, b.Width, b.Height
+}
+
 // CollisionTag marks an entity as collidable with a tag.
 type CollisionTag struct {
-	Tag string // "player", "enemy", "projectile"
+	Tag string // "player", "enemy", "projectile", "obstacle"
 }
 
 // ProjectileSystem manages projectile movement, lifetime, and collision.
@@ -100,6 +105,7 @@ func (ps *ProjectileSystem) moveProjectile(e engine.Entity, proj *Projectile, dt
 
 	pos.X += vel.VX * dt
 	pos.Y += vel.VY * dt
+	ps.world.MarkChanged(e)
 }
 
 // checkCollisions tests the projectile against all potential targets.
@@ -157,6 +163,11 @@ func (ps *ProjectileSystem) isValidTarget(proj *Projectile, target engine.Entity
 	}
 	tag := tagComp.(*CollisionTag)
 
+	// Obstacles stop every projectile regardless of owner
+	if tag.Tag == "obstacle" {
+		return true
+	}
+
 	// Player projectiles hit enemies, enemy projectiles hit player
 	if proj.OwnerType == "player" && tag.Tag != "enemy" {
 		return false
@@ -147,6 +147,34 @@ func TestProjectileSystem_NoSelfCollision(t *testing.T) {
 	}
 }
 
+func TestProjectileSystem_ObstacleBlocksAllOwners(t *testing.T) {
+	for _, owner := range []string{"player", "enemy"} {
+		world := engine.NewWorld()
+		ps := NewProjectileSystem(world)
+
+		wall := world.CreateEntity()
+		world.AddComponent(wall, "position", &engine.Position{X: 50, Y: 0})
+		world.AddComponent(wall, "collisiontag", &CollisionTag{Tag: "obstacle"})
+		world.AddComponent(wall, "boundingbox", &BoundingBox{X: -8, Y: -8, Width: 16, Height: 16})
+
+		hitCount := 0
+		ps.SetHitCallback(func(proj, target engine.Entity, damage float64) {
+			if target == wall {
+				hitCount++
+			}
+		})
+
+		ps.SpawnProjectile(0, 0, 0, 100, 10, owner, 2.0)
+		for i := 0; i < 10; i++ {
+			ps.Update(0.1)
+		}
+
+		if hitCount != 1 {
+			t.Errorf("%s projectile: expected obstacle hit once, got %d", owner, hitCount)
+		}
+	}
+}
+
 func TestCheckAABBCollision(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -207,6 +235,14 @@ func TestBoundingBox_Fields(t *testing.T) {
 	}
 }
 
+func TestBoundingBox_Bounds(t *testing.T) {
+	bb := &BoundingBox{X: -4, Y: -6, Width: 8, Height: 12}
+	x, y, w, h := bb.Bounds()
+	if x != -4 || y != -6 || w != 8 || h != 12 {
+		t.Errorf("Bounds() = %v, %v, %v, %v", x, y, w, h)
+	}
+}
+
 func TestCollisionTag_Fields(t *testing.T) {
 	ct := CollisionTag{Tag: "player"}
 
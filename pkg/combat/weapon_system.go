@@ -113,20 +113,34 @@ func (ws *WeaponSystem) tryFire(e engine.Entity, weapon *Weapon, ownerType strin
 	spawnX := pos.X + math.Cos(rot.Angle)*offset
 	spawnY := pos.Y + math.Sin(rot.Angle)*offset
 
-	// Spawn projectile
+	// Spawn projectiles, fanning out when spread shot is active
 	projectileSpeed := 400.0
 	projectileLifetime := 2.0
 
-	ws.projectiles.SpawnProjectile(
-		spawnX, spawnY,
-		rot.Angle,
-		projectileSpeed,
-		weapon.Damage,
-		ownerType,
-		projectileLifetime,
-	)
+	powerUps := getPowerUps(ws.world, e)
+	count := 1
+	if powerUps != nil {
+		count = powerUps.SpreadCount()
+	}
+
+	for i := 0; i < count; i++ {
+		angle := rot.Angle + (float64(i)-float64(count-1)/2)*SpreadShotAngle
+		ws.projectiles.SpawnProjectile(
+			spawnX, spawnY,
+			angle,
+			projectileSpeed,
+			weapon.Damage,
+			ownerType,
+			projectileLifetime,
+		)
+	}
 
 	weapon.Fire()
+
+	// Rapid fire shortens the cooldown that was just started
+	if powerUps != nil {
+		weapon.timer *= powerUps.CooldownScale()
+	}
 }
 
 // FireAtTarget fires a weapon from an entity toward a target position.
@@ -15,6 +15,7 @@ type Config struct {
 	Audio    AudioConfig    `mapstructure:"audio"`
 	Gameplay GameplayConfig `mapstructure:"gameplay"`
 	Controls ControlsConfig `mapstructure:"controls"`
+	Effects  EffectsConfig  `mapstructure:"effects"`
 }
 
 // DisplayConfig holds display/window settings.
@@ -25,30 +26,123 @@ type DisplayConfig struct {
 	VSync      bool `mapstructure:"vsync"`
 }
 
-// AudioConfig holds audio volume settings.
+// AudioConfig holds audio volume and positional audio settings.
 type AudioConfig struct {
 	MasterVolume float64 `mapstructure:"master_volume"`
 	MusicVolume  float64 `mapstructure:"music_volume"`
 	SFXVolume    float64 `mapstructure:"sfx_volume"`
+	Attenuation  string  `mapstructure:"attenuation"`   // linear, inverse, exponential or none
+	HearingRange float64 `mapstructure:"hearing_range"` // Distance in pixels at which sounds fade out
+	Doppler      float64 `mapstructure:"doppler"`       // Doppler strength; 0 disables
 }
 
 // GameplayConfig holds gameplay settings.
 type GameplayConfig struct {
-	Genre     string `mapstructure:"genre"`
-	ArenaMode string `mapstructure:"arena_mode"`
-	Seed      int64  `mapstructure:"seed"`
+	Genre       string `mapstructure:"genre"`
+	ArenaMode   string `mapstructure:"arena_mode"`
+	Seed        int64  `mapstructure:"seed"`
+	WorldWidth  int    `mapstructure:"world_width"`  // 0 = same as display width
+	WorldHeight int    `mapstructure:"world_height"` // 0 = same as display height
 }
 
-// ControlsConfig holds key binding settings.
+// WorldSize returns the effective arena size, defaulting to the display size.
+func (c *Config) WorldSize() (int, int) {
+	width, height := c.Gameplay.WorldWidth, c.Gameplay.WorldHeight
+	if width == 0 {
+		width = c.Display.Width
+	}
+	if height == 0 {
+		height = c.Display.Height
+	}
+	return width, height
+}
+
+// EffectsConfig holds combat feedback settings. Each effect can be turned
+// down or off for players sensitive to motion or flashing.
+type EffectsConfig struct {
+	ScreenShake float64 `mapstructure:"screen_shake"` // Shake intensity, 0 (off) to 1
+	HitStop     bool    `mapstructure:"hit_stop"`
+	HitFlash    bool    `mapstructure:"hit_flash"`
+	Shockwaves  bool    `mapstructure:"shockwaves"`
+	Lighting    bool    `mapstructure:"lighting"`        // Ambient darkness and light sources
+	PostProcess bool    `mapstructure:"post_processing"` // Genre bloom, grain, scanlines and vignette
+}
+
+// ControlsConfig holds key binding settings. Each action lists the keys and
+// gamepad buttons bound to it. A single key, the format used before bindings
+// were lists, is read with the action's default gamepad button added.
 type ControlsConfig struct {
-	Thrust      string `mapstructure:"thrust"`
-	RotateLeft  string `mapstructure:"rotate_left"`
-	RotateRight string `mapstructure:"rotate_right"`
-	Fire        string `mapstructure:"fire"`
-	Secondary   string `mapstructure:"secondary"`
-	Pause       string `mapstructure:"pause"`
+	Thrust      []string `mapstructure:"thrust"`
+	RotateLeft  []string `mapstructure:"rotate_left"`
+	RotateRight []string `mapstructure:"rotate_right"`
+	Fire        []string `mapstructure:"fire"`
+	Secondary   []string `mapstructure:"secondary"`
+	Pause       []string `mapstructure:"pause"`
+}
+
+// Map returns the bindings keyed by their config names.
+func (c ControlsConfig) Map() map[string][]string {
+	return map[string][]string{
+		"thrust":       c.Thrust,
+		"rotate_left":  c.RotateLeft,
+		"rotate_right": c.RotateRight,
+		"fire":         c.Fire,
+		"secondary":    c.Secondary,
+		"pause":        c.Pause,
+	}
+}
+
+// fields returns pointers to the bindings keyed by their config names.
+func (c *ControlsConfig) fields() map[string]*[]string {
+	return map[string]*[]string{
+		"thrust":       &c.Thrust,
+		"rotate_left":  &c.RotateLeft,
+		"rotate_right": &c.RotateRight,
+		"fire":         &c.Fire,
+		"secondary":    &c.Secondary,
+		"pause":        &c.Pause,
+	}
+}
+
+// legacyPadButtons are the gamepad buttons each action had when they were
+// fixed and the config only named a key.
+var legacyPadButtons = map[string]string{
+	"thrust":       "PadUp",
+	"rotate_left":  "PadLeft",
+	"rotate_right": "PadRight",
+	"fire":         "PadA",
+	"secondary":    "PadB",
+	"pause":        "PadStart",
+}
+
+// keepPadButtons adds the legacy gamepad button to each action the config
+// file binds with a single key, so files written before bindings were lists
+// still work on a gamepad. Buttons already bound elsewhere are left out.
+func keepPadButtons(c *ControlsConfig) {
+	bound := make(map[string]bool)
+	for _, keys := range c.Map() {
+		for _, key := range keys {
+			bound[key] = true
+		}
+	}
+	for action, keys := range c.fields() {
+		key, single := viper.Get("controls." + action).(string)
+		pad := legacyPadButtons[action]
+		if !single || validation.IsGamepadKey(key) || bound[pad] {
+			continue
+		}
+		*keys = append(*keys, pad)
+		bound[pad] = true
+	}
 }
 
+// FileName is the config file Load looks for in the current directory and
+// Save writes when no file was loaded.
+const FileName = "config.yaml"
+
+// loadedFile is the path of the config file Load last read, if any.
+var loadedFile string
+
 // Load reads the configuration file and returns a Config struct.
 // It searches for config.yaml in the current directory.
 func Load() (*Config, error) {
@@ -58,28 +152,49 @@ func Load() (*Config, error) {
 
 	setDefaults()
 
+	loadedFile = ""
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
 			return nil, fmt.Errorf("failed to read config: %w", err)
 		}
+	} else {
+		loadedFile = viper.ConfigFileUsed()
 	}
 
 	var cfg Config
 	if err := viper.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
+	keepPadButtons(&cfg.Controls)
 
-	// Validate gameplay settings
-	if err := validation.ValidateGenre(cfg.Gameplay.Genre); err != nil {
-		return nil, fmt.Errorf("config validation failed: %w", err)
-	}
-	if err := validation.ValidateArenaMode(cfg.Gameplay.ArenaMode); err != nil {
+	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
 	}
 
 	return &cfg, nil
 }
 
+// Validate checks the settings that have a fixed set of valid values or
+// depend on each other.
+func (c *Config) Validate() error {
+	if err := validation.ValidateGenre(c.Gameplay.Genre); err != nil {
+		return err
+	}
+	if err := validation.ValidateArenaMode(c.Gameplay.ArenaMode); err != nil {
+		return err
+	}
+	if err := validation.ValidateAttenuation(c.Audio.Attenuation, c.Audio.HearingRange); err != nil {
+		return err
+	}
+	if err := validation.ValidateScreenShake(c.Effects.ScreenShake); err != nil {
+		return err
+	}
+	if err := validation.ValidateControls(c.Controls.Map()); err != nil {
+		return err
+	}
+	return validation.ValidateWorldSize(c.Gameplay.WorldWidth, c.Gameplay.WorldHeight, c.Display.Width, c.Display.Height)
+}
+
 // setDefaults configures default values for all configuration options.
 func setDefaults() {
 	viper.SetDefault("display.width", 800)
@@ -90,15 +205,27 @@ func setDefaults() {
 	viper.SetDefault("audio.master_volume", 0.8)
 	viper.SetDefault("audio.music_volume", 0.6)
 	viper.SetDefault("audio.sfx_volume", 0.8)
+	viper.SetDefault("audio.attenuation", "inverse")
+	viper.SetDefault("audio.hearing_range", 900.0)
+	viper.SetDefault("audio.doppler", 1.0)
 
 	viper.SetDefault("gameplay.genre", "scifi")
 	viper.SetDefault("gameplay.arena_mode", "wrap")
 	viper.SetDefault("gameplay.seed", 0)
-
-	viper.SetDefault("controls.thrust", "W")
-	viper.SetDefault("controls.rotate_left", "A")
-	viper.SetDefault("controls.rotate_right", "D")
-	viper.SetDefault("controls.fire", "Space")
-	viper.SetDefault("controls.secondary", "Shift")
-	viper.SetDefault("controls.pause", "Escape")
+	viper.SetDefault("gameplay.world_width", 0)
+	viper.SetDefault("gameplay.world_height", 0)
+
+	viper.SetDefault("controls.thrust", []string{"W", "PadUp"})
+	viper.SetDefault("controls.rotate_left", []string{"A", "PadLeft"})
+	viper.SetDefault("controls.rotate_right", []string{"D", "PadRight"})
+	viper.SetDefault("controls.fire", []string{"Space", "PadA"})
+	viper.SetDefault("controls.secondary", []string{"Shift", "PadB"})
+	viper.SetDefault("controls.pause", []string{"Escape", "PadStart"})
+
+	viper.SetDefault("effects.screen_shake", 1.0)
+	viper.SetDefault("effects.hit_stop", true)
+	viper.SetDefault("effects.hit_flash", true)
+	viper.SetDefault("effects.shockwaves", true)
+	viper.SetDefault("effects.lighting", true)
+	viper.SetDefault("effects.post_processing", true)
 }
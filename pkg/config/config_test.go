@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -48,6 +50,9 @@ func TestLoad_DefaultValues(t *testing.T) {
 	if cfg.Audio.SFXVolume != 0.8 {
 		t.Errorf("expected sfx_volume 0.8, got %f", cfg.Audio.SFXVolume)
 	}
+	if cfg.Audio.Attenuation != "inverse" || cfg.Audio.HearingRange != 900 || cfg.Audio.Doppler != 1 {
+		t.Errorf("unexpected positional audio defaults: %+v", cfg.Audio)
+	}
 
 	// Check gameplay defaults
 	if cfg.Gameplay.Genre != "scifi" {
@@ -61,14 +66,22 @@ func TestLoad_DefaultValues(t *testing.T) {
 	}
 
 	// Check controls defaults
-	if cfg.Controls.Thrust != "W" {
-		t.Errorf("expected thrust 'W', got %s", cfg.Controls.Thrust)
+	if !reflect.DeepEqual(cfg.Controls.Thrust, []string{"W", "PadUp"}) {
+		t.Errorf("expected thrust [W PadUp], got %v", cfg.Controls.Thrust)
+	}
+	if !reflect.DeepEqual(cfg.Controls.Fire, []string{"Space", "PadA"}) {
+		t.Errorf("expected fire [Space PadA], got %v", cfg.Controls.Fire)
 	}
-	if cfg.Controls.Fire != "Space" {
-		t.Errorf("expected fire 'Space', got %s", cfg.Controls.Fire)
+	if !reflect.DeepEqual(cfg.Controls.Pause, []string{"Escape", "PadStart"}) {
+		t.Errorf("expected pause [Escape PadStart], got %v", cfg.Controls.Pause)
+	}
+
+	// Check effects defaults
+	if cfg.Effects.ScreenShake != 1.0 {
+		t.Errorf("expected screen_shake 1.0, got %f", cfg.Effects.ScreenShake)
 	}
-	if cfg.Controls.Pause != "Escape" {
-		t.Errorf("expected pause 'Escape', got %s", cfg.Controls.Pause)
+	if !cfg.Effects.HitStop || !cfg.Effects.HitFlash || !cfg.Effects.Shockwaves || !cfg.Effects.Lighting || !cfg.Effects.PostProcess {
+		t.Errorf("expected all effects enabled by default, got %+v", cfg.Effects)
 	}
 }
 
@@ -104,7 +117,7 @@ gameplay:
 
 controls:
   thrust: Up
-  fire: Enter
+  fire: [Enter, PadX]
 `
 	err = os.WriteFile(filepath.Join(tmpDir, "config.yaml"), []byte(configContent), 0o644)
 	if err != nil {
@@ -143,12 +156,57 @@ controls:
 		t.Errorf("expected seed 12345, got %d", cfg.Gameplay.Seed)
 	}
 
-	// Check custom controls
-	if cfg.Controls.Thrust != "Up" {
-		t.Errorf("expected thrust 'Up', got %s", cfg.Controls.Thrust)
+	// Check custom controls; a single key keeps its default gamepad button
+	if !reflect.DeepEqual(cfg.Controls.Thrust, []string{"Up", "PadUp"}) {
+		t.Errorf("expected thrust [Up PadUp], got %v", cfg.Controls.Thrust)
+	}
+	if !reflect.DeepEqual(cfg.Controls.Fire, []string{"Enter", "PadX"}) {
+		t.Errorf("expected fire [Enter PadX], got %v", cfg.Controls.Fire)
+	}
+}
+
+func TestLoad_SingleKeyControls(t *testing.T) {
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpDir := t.TempDir()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(oldDir)
+
+	// The format used before bindings were lists
+	configContent := `
+controls:
+  thrust: "W"
+  rotate_left: "A"
+  rotate_right: "D"
+  fire: "Space"
+  secondary: "PadA"
+  pause: "Escape"
+`
+	err = os.WriteFile(filepath.Join(tmpDir, "config.yaml"), []byte(configContent), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	want := ControlsConfig{
+		Thrust:      []string{"W", "PadUp"},
+		RotateLeft:  []string{"A", "PadLeft"},
+		RotateRight: []string{"D", "PadRight"},
+		Fire:        []string{"Space"}, // PadA is already bound to secondary
+		Secondary:   []string{"PadA"},
+		Pause:       []string{"Escape", "PadStart"},
 	}
-	if cfg.Controls.Fire != "Enter" {
-		t.Errorf("expected fire 'Enter', got %s", cfg.Controls.Fire)
+	if !reflect.DeepEqual(cfg.Controls, want) {
+		t.Errorf("controls = %+v, want %+v", cfg.Controls, want)
 	}
 }
 
@@ -212,22 +270,22 @@ func TestGameplayConfig_Fields(t *testing.T) {
 
 func TestControlsConfig_Fields(t *testing.T) {
 	cc := ControlsConfig{
-		Thrust:      "W",
-		RotateLeft:  "A",
-		RotateRight: "D",
-		Fire:        "Space",
-		Secondary:   "Shift",
-		Pause:       "Escape",
+		Thrust:      []string{"W", "Up"},
+		RotateLeft:  []string{"A"},
+		RotateRight: []string{"D"},
+		Fire:        []string{"Space"},
+		Secondary:   []string{"Shift"},
+		Pause:       []string{"Escape"},
 	}
 
-	if cc.Thrust != "W" {
+	if cc.Thrust[1] != "Up" {
 		t.Error("thrust mismatch")
 	}
-	if cc.Fire != "Space" {
+	if cc.Fire[0] != "Space" {
 		t.Error("fire mismatch")
 	}
-	if cc.Pause != "Escape" {
-		t.Error("pause mismatch")
+	if m := cc.Map(); len(m) != 6 || m["rotate_left"][0] != "A" || m["pause"][0] != "Escape" {
+		t.Errorf("Map() = %v", m)
 	}
 }
 
@@ -250,12 +308,12 @@ func TestConfig_AllFieldsPopulated(t *testing.T) {
 			Seed:      0,
 		},
 		Controls: ControlsConfig{
-			Thrust:      "W",
-			RotateLeft:  "A",
-			RotateRight: "D",
-			Fire:        "Space",
-			Secondary:   "Shift",
-			Pause:       "Escape",
+			Thrust:      []string{"W"},
+			RotateLeft:  []string{"A"},
+			RotateRight: []string{"D"},
+			Fire:        []string{"Space"},
+			Secondary:   []string{"Shift"},
+			Pause:       []string{"Escape"},
 		},
 	}
 
@@ -268,7 +326,190 @@ func TestConfig_AllFieldsPopulated(t *testing.T) {
 	if cfg.Gameplay.Genre == "" {
 		t.Error("gameplay genre not set")
 	}
-	if cfg.Controls.Thrust == "" {
+	if len(cfg.Controls.Thrust) == 0 {
 		t.Error("controls thrust not set")
 	}
 }
+
+func TestConfig_WorldSize(t *testing.T) {
+	cfg := Config{Display: DisplayConfig{Width: 800, Height: 600}}
+	if w, h := cfg.WorldSize(); w != 800 || h != 600 {
+		t.Errorf("expected world to default to display size, got %dx%d", w, h)
+	}
+
+	cfg.Gameplay.WorldWidth = 3200
+	cfg.Gameplay.WorldHeight = 2400
+	if w, h := cfg.WorldSize(); w != 3200 || h != 2400 {
+		t.Errorf("expected configured world size 3200x2400, got %dx%d", w, h)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := validConfig()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+
+	cfg.Gameplay.Genre = "western"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected an unknown genre to fail validation")
+	}
+
+	cfg = validConfig()
+	cfg.Controls.Secondary = []string{"Enter"}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected a key bound to two actions to fail validation")
+	}
+	cfg.Controls.Secondary = []string{"Pedal"}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected an unknown key name to fail validation")
+	}
+}
+
+// validConfig returns a config with every setting filled in.
+func validConfig() *Config {
+	return &Config{
+		Display:  DisplayConfig{Width: 1280, Height: 720, Fullscreen: true, VSync: false},
+		Audio:    AudioConfig{MasterVolume: 0.5, MusicVolume: 1, SFXVolume: 0.25, Attenuation: "linear", HearingRange: 700, Doppler: 0.5},
+		Gameplay: GameplayConfig{Genre: "cyberpunk", ArenaMode: "ring", Seed: 42},
+		Controls: ControlsConfig{
+			Thrust: []string{"Up", "PadRT"}, RotateLeft: []string{"Left"}, RotateRight: []string{"Right"},
+			Fire: []string{"Enter"}, Secondary: []string{"S"}, Pause: []string{"Escape"},
+		},
+		Effects: EffectsConfig{ScreenShake: 0.5, HitStop: true, Lighting: true},
+	}
+}
+
+func TestSaveFile_RoundTrip(t *testing.T) {
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpDir := t.TempDir()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(oldDir)
+
+	want := validConfig()
+	if err := SaveFile(want, FileName); err != nil {
+		t.Fatalf("SaveFile() error: %v", err)
+	}
+	got, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", *got, *want)
+	}
+
+	// Save writes back to the file Load read
+	got.Audio.MusicVolume = 0.3
+	if err := Save(got); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	again, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if again.Audio.MusicVolume != 0.3 {
+		t.Errorf("expected saved music volume 0.3, got %f", again.Audio.MusicVolume)
+	}
+}
+
+func TestSaveFile_KeepsComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	original := `# Velocity configuration
+
+audio:
+  music_volume: 0.6  # background music
+  hearing_range: 900
+gameplay:
+  genre: scifi
+controls:
+  fire: "Space"  # shoot
+`
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := validConfig()
+	cfg.Audio.MusicVolume = 0.2
+	if err := SaveFile(cfg, path); err != nil {
+		t.Fatalf("SaveFile() error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := string(data)
+
+	for _, want := range []string{
+		"# Velocity configuration",
+		"music_volume: 0.2 # background music",
+		"hearing_range: 700\n",    // Written as the file wrote it, without a decimal point
+		"genre: cyberpunk\n",      // Unquoted as before
+		`fire: ["Enter"] # shoot`, // Single keys become lists
+		`thrust: ["Up", "PadRT"]`, // Missing keys are added
+	} {
+		if !strings.Contains(saved, want) {
+			t.Errorf("saved config missing %q:\n%s", want, saved)
+		}
+	}
+	if strings.Index(saved, "audio:") > strings.Index(saved, "controls:") {
+		t.Error("existing sections should keep their place ahead of added ones")
+	}
+}
+
+func TestSaveFile_EmptySection(t *testing.T) {
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpDir := t.TempDir()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(oldDir)
+
+	// Sections with nothing under them parse as null scalars
+	original := `display:
+  width: 1280
+controls:
+audio: ~
+`
+	if err := os.WriteFile(FileName, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := validConfig()
+	if err := SaveFile(want, FileName); err != nil {
+		t.Fatalf("SaveFile() error: %v", err)
+	}
+	got, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !reflect.DeepEqual(got.Controls, want.Controls) || !reflect.DeepEqual(got.Audio, want.Audio) {
+		t.Errorf("empty sections were not written:\n got %+v %+v\nwant %+v %+v", got.Controls, got.Audio, want.Controls, want.Audio)
+	}
+}
+
+func TestSaveFile_RejectsInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	cfg := validConfig()
+	cfg.Gameplay.ArenaMode = "maze"
+	if err := SaveFile(cfg, path); err == nil {
+		t.Error("expected an invalid config to be rejected")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("an invalid config should not be written")
+	}
+
+	if err := os.WriteFile(path, []byte("- not\n- a mapping\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveFile(validConfig(), path); err == nil {
+		t.Error("expected a non-mapping config file to be rejected")
+	}
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// saveIndent is the indentation of saved config files.
+const saveIndent = 2
+
+// Save writes cfg to the config file Load read, or to FileName in the
+// current directory if Load found none.
+func Save(cfg *Config) error {
+	path := loadedFile
+	if path == "" {
+		path = FileName
+	}
+	return SaveFile(cfg, path)
+}
+
+// SaveFile validates cfg and writes it to path. Settings already in the
+// file are updated in place, so its comments, ordering and quoting survive;
+// missing sections and keys are appended.
+func SaveFile(cfg *Config, path string) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("config validation failed: %w", err)
+	}
+
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("failed to parse config: %w", err)
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to update config: %s is not a mapping", path)
+	}
+
+	// Each config section is a struct whose mapstructure tags name its keys
+	sections := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < sections.NumField(); i++ {
+		section := mappingValue(root, sections.Type().Field(i).Tag.Get("mapstructure"), yaml.MappingNode)
+		fields := sections.Field(i)
+		for j := 0; j < fields.NumField(); j++ {
+			key := fields.Type().Field(j).Tag.Get("mapstructure")
+			setValue(mappingValue(section, key, yaml.ScalarNode), fields.Field(j))
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(saveIndent)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// mappingValue returns the value node for key in a mapping, appending an
+// empty node of the given kind if the key is missing. A section left empty
+// in the file, such as a bare "controls:", parses as a null scalar and is
+// turned into a mapping so the keys added to it are written.
+func mappingValue(mapping *yaml.Node, key string, kind yaml.Kind) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			value := mapping.Content[i+1]
+			if kind == yaml.MappingNode && value.Kind != kind {
+				value.Kind, value.Style, value.Tag, value.Value, value.Content = kind, 0, "", "", nil
+			}
+			return value
+		}
+	}
+	value := &yaml.Node{Kind: kind}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	return value
+}
+
+// setValue stores a setting in a node, keeping the node's quoting and
+// comments. New string values are double-quoted like the shipped file;
+// other values are left untagged so they are written plainly. Lists are
+// written inline unless the file already spelled them out as a block.
+func setValue(node *yaml.Node, v reflect.Value) {
+	if v.Kind() == reflect.Slice {
+		if node.Kind != yaml.SequenceNode {
+			node.Kind, node.Style = yaml.SequenceNode, yaml.FlowStyle
+		}
+		node.Tag, node.Value, node.Content = "", "", nil
+		for i := 0; i < v.Len(); i++ {
+			item := &yaml.Node{}
+			setValue(item, v.Index(i))
+			node.Content = append(node.Content, item)
+		}
+		return
+	}
+
+	added := node.Tag == ""
+	node.Kind = yaml.ScalarNode
+	switch v.Kind() {
+	case reflect.String:
+		node.Tag = "!!str"
+		node.Value = v.String()
+		if added {
+			node.Style = yaml.DoubleQuotedStyle
+		}
+	case reflect.Bool:
+		node.Tag = ""
+		node.Value = strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int64:
+		node.Tag = ""
+		node.Value = strconv.FormatInt(v.Int(), 10)
+	case reflect.Float64:
+		node.Tag = ""
+		// Whole numbers keep a decimal point unless the file wrote them without
+		wasDecimal := added || strings.Contains(node.Value, ".")
+		node.Value = strconv.FormatFloat(v.Float(), 'f', -1, 64)
+		if wasDecimal && !strings.Contains(node.Value, ".") {
+			node.Value += ".0"
+		}
+	}
+}
@@ -2,68 +2,351 @@
 // input handling, and camera system.
 package engine
 
+import "math"
+
 // ArenaMode determines how entities interact with screen boundaries.
 type ArenaMode string
 
 const (
 	ArenaModeWrap    ArenaMode = "wrap"
 	ArenaModeBounded ArenaMode = "bounded"
+	// ArenaModeScroll is a vertical scrolling stage: the view climbs from the
+	// bottom of the world to the top and the focus entity is kept inside it.
+	ArenaModeScroll ArenaMode = "scroll"
+	// ArenaModeRing bounds the arena and shrinks a circular safe zone toward
+	// the center; entities with health outside it take damage.
+	ArenaModeRing ArenaMode = "ring"
+	// ArenaModePortal bounces off the edges except through paired portals.
+	ArenaModePortal ArenaMode = "portal"
+	// ArenaModeElectric bounds the arena with walls that damage on bounce.
+	ArenaModeElectric ArenaMode = "electric"
+	// ArenaModeAsymmetric wraps horizontally and bounces vertically.
+	ArenaModeAsymmetric ArenaMode = "asymmetric"
+)
+
+// Arena tuning constants.
+const (
+	// DefaultScrollSpeed is the upward scroll speed of scrolling stages in pixels/sec.
+	DefaultScrollSpeed = 40.0
+	// RingShrinkRate is how fast the safe zone radius shrinks in pixels/sec.
+	RingShrinkRate = 8.0
+	// RingMinRadius is the smallest the safe zone gets.
+	RingMinRadius = 120.0
+	// RingDamagePerSecond is the damage dealt each second outside the safe zone.
+	RingDamagePerSecond = 10.0
+	// ElectricWallDamage is the damage dealt by each bounce off an electrified wall.
+	ElectricWallDamage = 5.0
+	// PortalSpan is the default length of a portal opening along its edge.
+	PortalSpan = 120.0
+)
+
+// ArenaEdge identifies one side of the arena.
+type ArenaEdge int
+
+const (
+	EdgeTop ArenaEdge = iota
+	EdgeRight
+	EdgeBottom
+	EdgeLeft
 )
 
+// Portal is an opening of length Span centered at Offset along an arena edge.
+// Offset is measured along X for the top and bottom edges and along Y for the sides.
+type Portal struct {
+	Edge   ArenaEdge
+	Offset float64
+	Span   float64
+}
+
+// contains reports whether a crossing at offset along the portal's edge passes through it.
+func (p Portal) contains(offset float64) bool {
+	return math.Abs(offset-p.Offset) <= p.Span/2
+}
+
+// PortalPair links two portals; entities leaving through one emerge from the other.
+type PortalPair struct {
+	A, B Portal
+}
+
+// DefaultPortals returns portal pairs that link each side edge to the
+// neighbouring top or bottom edge, so travel through them turns a corner.
+func DefaultPortals(width, height int) []PortalPair {
+	w, h := float64(width), float64(height)
+	return []PortalPair{
+		{A: Portal{Edge: EdgeLeft, Offset: h / 2, Span: PortalSpan}, B: Portal{Edge: EdgeTop, Offset: w / 2, Span: PortalSpan}},
+		{A: Portal{Edge: EdgeRight, Offset: h / 2, Span: PortalSpan}, B: Portal{Edge: EdgeBottom, Offset: w / 2, Span: PortalSpan}},
+	}
+}
+
 // ArenaSystem handles entity position clamping at screen boundaries.
 type ArenaSystem struct {
 	world  *World
 	mode   ArenaMode
 	width  float64
 	height float64
+
+	viewWidth   float64
+	viewHeight  float64
+	scrollY     float64
+	scrollSpeed float64
+	focus       Entity
+
+	ringRadius float64
+	portals    []PortalPair
+
+	onDamage func(entity Entity, amount float64)
 }
 
 // NewArenaSystem creates an arena system with the given dimensions and mode.
+// The view defaults to the whole arena; see SetView.
 func NewArenaSystem(world *World, width, height int, mode ArenaMode) *ArenaSystem {
-	return &ArenaSystem{
-		world:  world,
-		mode:   mode,
-		width:  float64(width),
-		height: float64(height),
+	as := &ArenaSystem{
+		world:       world,
+		mode:        mode,
+		width:       float64(width),
+		height:      float64(height),
+		viewWidth:   float64(width),
+		viewHeight:  float64(height),
+		scrollSpeed: DefaultScrollSpeed,
+		portals:     DefaultPortals(width, height),
 	}
+	as.ResetScroll()
+	as.ResetRing()
+	return as
+}
+
+// Mode returns the current arena boundary behavior.
+func (as *ArenaSystem) Mode() ArenaMode {
+	return as.mode
+}
+
+// SetDamageCallback sets the callback for arena damage (ring and electric
+// walls). It is only called for entities with a health component.
+func (as *ArenaSystem) SetDamageCallback(fn func(entity Entity, amount float64)) {
+	as.onDamage = fn
+}
+
+// SetPortals replaces the portal pairs used in portal mode.
+func (as *ArenaSystem) SetPortals(pairs []PortalPair) {
+	as.portals = pairs
+}
+
+// Portals returns the portal pairs used in portal mode.
+func (as *ArenaSystem) Portals() []PortalPair {
+	return as.portals
+}
+
+// ResetRing expands the safe zone to cover the whole arena again.
+func (as *ArenaSystem) ResetRing() {
+	as.ringRadius = math.Hypot(as.width, as.height) / 2
+}
+
+// Ring returns the safe zone center and radius, and whether ring mode is active.
+func (as *ArenaSystem) Ring() (float64, float64, float64, bool) {
+	return as.width / 2, as.height / 2, as.ringRadius, as.mode == ArenaModeRing
 }
 
 // SetMode changes the arena boundary behavior.
 func (as *ArenaSystem) SetMode(mode ArenaMode) {
 	as.mode = mode
+	as.ResetScroll()
+}
+
+// SetView sets the size of the visible window used by scrolling stages.
+func (as *ArenaSystem) SetView(width, height int) {
+	as.viewWidth = float64(width)
+	as.viewHeight = float64(height)
+	as.ResetScroll()
+}
+
+// SetScrollSpeed sets the upward scroll speed in pixels per second.
+func (as *ArenaSystem) SetScrollSpeed(speed float64) {
+	as.scrollSpeed = speed
+}
+
+// SetFocus sets the entity kept inside the scrolling window (usually the player).
+func (as *ArenaSystem) SetFocus(entity Entity) {
+	as.focus = entity
+}
+
+// ResetScroll returns the scrolling window to the bottom of the world.
+func (as *ArenaSystem) ResetScroll() {
+	as.scrollY = math.Max(as.height-as.viewHeight, 0)
+}
+
+// ScrollOffset returns the world Y coordinate of the top of the scrolling window.
+func (as *ArenaSystem) ScrollOffset() float64 {
+	return as.scrollY
 }
 
 // Update processes all entities and applies boundary logic.
 func (as *ArenaSystem) Update(dt float64) {
+	switch as.mode {
+	case ArenaModeScroll:
+		as.scrollY = math.Max(as.scrollY-as.scrollSpeed*dt, 0)
+	case ArenaModeRing:
+		as.ringRadius = math.Max(as.ringRadius-RingShrinkRate*dt, RingMinRadius)
+	}
 	for entity := range as.world.entities {
-		as.processEntity(entity)
+		as.processEntity(entity, dt)
 	}
 }
 
 // processEntity applies boundary logic to a single entity.
-func (as *ArenaSystem) processEntity(entity Entity) {
+func (as *ArenaSystem) processEntity(entity Entity, dt float64) {
 	posComp, hasPos := as.world.GetComponent(entity, "position")
 	if !hasPos {
 		return
 	}
 
 	pos := posComp.(*Position)
+	x, y := pos.X, pos.Y
 
 	switch as.mode {
 	case ArenaModeWrap:
 		as.applyWrap(pos)
 	case ArenaModeBounded:
 		as.applyBounded(entity, pos)
+	case ArenaModeScroll:
+		if entity == as.focus {
+			as.applyScrollWindow(entity, pos)
+		} else {
+			as.applyBounded(entity, pos)
+		}
+	case ArenaModeRing:
+		as.applyBounded(entity, pos)
+		as.applyRing(entity, pos, dt)
+	case ArenaModePortal:
+		if !as.applyPortals(entity, pos) {
+			as.applyBounded(entity, pos)
+		}
+	case ArenaModeElectric:
+		if as.applyBounded(entity, pos) {
+			as.damage(entity, ElectricWallDamage)
+		}
+	case ArenaModeAsymmetric:
+		as.applyWrapX(pos)
+		as.bounceOnYBoundary(pos, as.getVelocity(entity))
+	}
+	if pos.X != x || pos.Y != y {
+		as.world.MarkChanged(entity)
+	}
+}
+
+// damage reports arena damage for entities that have health.
+func (as *ArenaSystem) damage(entity Entity, amount float64) {
+	if as.onDamage == nil {
+		return
+	}
+	if _, hasHealth := as.world.GetComponent(entity, "health"); hasHealth {
+		as.onDamage(entity, amount)
+	}
+}
+
+// applyRing damages an entity standing outside the safe zone.
+func (as *ArenaSystem) applyRing(entity Entity, pos *Position, dt float64) {
+	if math.Hypot(pos.X-as.width/2, pos.Y-as.height/2) > as.ringRadius {
+		as.damage(entity, RingDamagePerSecond*dt)
+	}
+}
+
+// applyPortals moves an entity that left the arena through a portal to the
+// paired portal, turning its heading to point into the arena. It returns
+// false if the entity did not cross an edge through a portal.
+func (as *ArenaSystem) applyPortals(entity Entity, pos *Position) bool {
+	var edge ArenaEdge
+	var offset float64
+	switch {
+	case pos.Y < 0:
+		edge, offset = EdgeTop, pos.X
+	case pos.X >= as.width:
+		edge, offset = EdgeRight, pos.Y
+	case pos.Y >= as.height:
+		edge, offset = EdgeBottom, pos.X
+	case pos.X < 0:
+		edge, offset = EdgeLeft, pos.Y
+	default:
+		return false
+	}
+
+	for _, pair := range as.portals {
+		from, to := pair.A, pair.B
+		if from.Edge != edge || !from.contains(offset) {
+			from, to = pair.B, pair.A
+			if from.Edge != edge || !from.contains(offset) {
+				continue
+			}
+		}
+		pos.X, pos.Y = as.edgePoint(to.Edge, to.Offset+offset-from.Offset)
+		as.turn(entity, edgeAngle(to.Edge)-edgeAngle(from.Edge)+math.Pi)
+		return true
+	}
+	return false
+}
+
+// edgePoint returns the position just inside an edge at offset along it.
+func (as *ArenaSystem) edgePoint(edge ArenaEdge, offset float64) (float64, float64) {
+	switch edge {
+	case EdgeTop:
+		return math.Max(0, math.Min(offset, as.width-1)), 0
+	case EdgeRight:
+		return as.width - 1, math.Max(0, math.Min(offset, as.height-1))
+	case EdgeBottom:
+		return math.Max(0, math.Min(offset, as.width-1)), as.height - 1
+	default:
+		return 0, math.Max(0, math.Min(offset, as.height-1))
+	}
+}
+
+// edgeAngle returns the direction pointing into the arena from an edge.
+func edgeAngle(edge ArenaEdge) float64 {
+	switch edge {
+	case EdgeTop:
+		return math.Pi / 2
+	case EdgeRight:
+		return math.Pi
+	case EdgeBottom:
+		return -math.Pi / 2
+	default:
+		return 0
+	}
+}
+
+// turn rotates an entity's velocity and facing by delta radians.
+func (as *ArenaSystem) turn(entity Entity, delta float64) {
+	sin, cos := math.Sincos(delta)
+	if vel := as.getVelocity(entity); vel != nil {
+		vel.VX, vel.VY = vel.VX*cos-vel.VY*sin, vel.VX*sin+vel.VY*cos
+	}
+	if rotComp, ok := as.world.GetComponent(entity, "rotation"); ok {
+		rotComp.(*Rotation).Angle += delta
+	}
+}
+
+// applyScrollWindow keeps the focus entity inside the scrolling window,
+// pushing it up with the bottom edge as the stage advances.
+func (as *ArenaSystem) applyScrollWindow(entity Entity, pos *Position) {
+	vel := as.getVelocity(entity)
+	as.bounceOnXBoundary(pos, vel)
+
+	top := as.scrollY
+	bottom := as.scrollY + as.viewHeight
+	if pos.Y < top {
+		pos.Y = top
+		if vel != nil && vel.VY < 0 {
+			vel.VY = 0
+		}
+	} else if pos.Y >= bottom {
+		pos.Y = bottom - 1
+		if vel != nil && vel.VY > 0 {
+			vel.VY = 0
+		}
 	}
 }
 
 // applyWrap teleports entity to opposite edge when crossing boundary.
 func (as *ArenaSystem) applyWrap(pos *Position) {
-	if pos.X < 0 {
-		pos.X += as.width
-	} else if pos.X >= as.width {
-		pos.X -= as.width
-	}
+	as.applyWrapX(pos)
 
 	if pos.Y < 0 {
 		pos.Y += as.height
@@ -72,11 +355,22 @@ func (as *ArenaSystem) applyWrap(pos *Position) {
 	}
 }
 
+// applyWrapX teleports entity to the opposite side when crossing the left or right edge.
+func (as *ArenaSystem) applyWrapX(pos *Position) {
+	if pos.X < 0 {
+		pos.X += as.width
+	} else if pos.X >= as.width {
+		pos.X -= as.width
+	}
+}
+
 // applyBounded bounces entity off boundaries by reversing velocity.
-func (as *ArenaSystem) applyBounded(entity Entity, pos *Position) {
+// It returns true if the entity hit a wall.
+func (as *ArenaSystem) applyBounded(entity Entity, pos *Position) bool {
 	vel := as.getVelocity(entity)
-	as.bounceOnXBoundary(pos, vel)
-	as.bounceOnYBoundary(pos, vel)
+	hitX := as.bounceOnXBoundary(pos, vel)
+	hitY := as.bounceOnYBoundary(pos, vel)
+	return hitX || hitY
 }
 
 // getVelocity retrieves the velocity component for an entity, or nil.
@@ -89,25 +383,31 @@ func (as *ArenaSystem) getVelocity(entity Entity) *Velocity {
 }
 
 // bounceOnXBoundary handles left/right edge collision.
-func (as *ArenaSystem) bounceOnXBoundary(pos *Position, vel *Velocity) {
+func (as *ArenaSystem) bounceOnXBoundary(pos *Position, vel *Velocity) bool {
 	if pos.X < 0 {
 		pos.X = 0
 		reverseVX(vel)
+		return true
 	} else if pos.X >= as.width {
 		pos.X = as.width - 1
 		reverseVX(vel)
+		return true
 	}
+	return false
 }
 
 // bounceOnYBoundary handles top/bottom edge collision.
-func (as *ArenaSystem) bounceOnYBoundary(pos *Position, vel *Velocity) {
+func (as *ArenaSystem) bounceOnYBoundary(pos *Position, vel *Velocity) bool {
 	if pos.Y < 0 {
 		pos.Y = 0
 		reverseVY(vel)
+		return true
 	} else if pos.Y >= as.height {
 		pos.Y = as.height - 1
 		reverseVY(vel)
+		return true
 	}
+	return false
 }
 
 // reverseVX negates the X velocity component if vel is non-nil.
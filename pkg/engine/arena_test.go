@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"math"
 	"testing"
 )
 
@@ -193,3 +194,173 @@ func TestArenaSystem_UpdateNoPosition(t *testing.T) {
 	// Should not panic
 	as.Update(0)
 }
+
+func TestArenaSystem_ScrollMode_Advances(t *testing.T) {
+	world := NewWorld()
+	as := NewArenaSystem(world, 800, 2000, ArenaModeScroll)
+	as.SetView(800, 600)
+
+	if as.ScrollOffset() != 1400 {
+		t.Fatalf("expected scroll to start at the bottom (1400), got %f", as.ScrollOffset())
+	}
+
+	as.SetScrollSpeed(100)
+	as.Update(1)
+	if as.ScrollOffset() != 1300 {
+		t.Errorf("expected scroll offset 1300, got %f", as.ScrollOffset())
+	}
+
+	as.Update(100)
+	if as.ScrollOffset() != 0 {
+		t.Errorf("expected scroll to stop at the top, got %f", as.ScrollOffset())
+	}
+}
+
+func TestArenaSystem_ScrollMode_KeepsFocusInView(t *testing.T) {
+	world := NewWorld()
+	player := world.CreateEntity()
+	world.AddComponent(player, "position", &Position{X: 400, Y: 1990})
+	world.AddComponent(player, "velocity", &Velocity{VY: 50})
+
+	as := NewArenaSystem(world, 800, 2000, ArenaModeScroll)
+	as.SetView(800, 600)
+	as.SetFocus(player)
+	as.SetScrollSpeed(100)
+	as.Update(1)
+
+	posComp, _ := world.GetComponent(player, "position")
+	pos := posComp.(*Position)
+	bottom := as.ScrollOffset() + 600
+	if pos.Y >= bottom {
+		t.Errorf("expected player pushed inside the window (< %f), got %f", bottom, pos.Y)
+	}
+	velComp, _ := world.GetComponent(player, "velocity")
+	if vy := velComp.(*Velocity).VY; vy != 0 {
+		t.Errorf("expected downward velocity cancelled, got %f", vy)
+	}
+}
+
+func TestArenaSystem_RingMode_DamagesOutsideSafeZone(t *testing.T) {
+	world := NewWorld()
+	as := NewArenaSystem(world, 800, 600, ArenaModeRing)
+
+	damaged := map[Entity]float64{}
+	as.SetDamageCallback(func(entity Entity, amount float64) {
+		damaged[entity] += amount
+	})
+
+	center := world.CreateEntity()
+	world.AddComponent(center, "position", &Position{X: 400, Y: 300})
+	world.AddComponent(center, "health", struct{}{})
+	corner := world.CreateEntity()
+	world.AddComponent(corner, "position", &Position{X: 10, Y: 10})
+	world.AddComponent(corner, "health", struct{}{})
+	debris := world.CreateEntity()
+	world.AddComponent(debris, "position", &Position{X: 10, Y: 10})
+
+	// Shrink long enough for the corner to fall outside
+	as.Update(20)
+
+	_, _, radius, active := as.Ring()
+	if !active || radius != 500-20*RingShrinkRate {
+		t.Fatalf("expected ring radius %f, got %f (active=%v)", 500-20*RingShrinkRate, radius, active)
+	}
+	if damaged[corner] != RingDamagePerSecond*20 {
+		t.Errorf("expected corner damaged %f, got %f", RingDamagePerSecond*20, damaged[corner])
+	}
+	if _, ok := damaged[center]; ok {
+		t.Error("expected no damage inside the safe zone")
+	}
+	if _, ok := damaged[debris]; ok {
+		t.Error("expected entities without health to be ignored")
+	}
+
+	as.Update(1000)
+	if _, _, radius, _ := as.Ring(); radius != RingMinRadius {
+		t.Errorf("expected ring to stop at %f, got %f", RingMinRadius, radius)
+	}
+	as.ResetRing()
+	if _, _, radius, _ := as.Ring(); radius != 500 {
+		t.Errorf("expected ResetRing to restore radius 500, got %f", radius)
+	}
+}
+
+func TestArenaSystem_PortalMode_Teleports(t *testing.T) {
+	world := NewWorld()
+	as := NewArenaSystem(world, 800, 600, ArenaModePortal)
+
+	// Left portal at y=300 leads to the top portal at x=400
+	entity := world.CreateEntity()
+	world.AddComponent(entity, "position", &Position{X: -5, Y: 310})
+	world.AddComponent(entity, "velocity", &Velocity{VX: -100, VY: 0})
+	world.AddComponent(entity, "rotation", &Rotation{Angle: math.Pi})
+
+	as.Update(0)
+
+	posComp, _ := world.GetComponent(entity, "position")
+	pos := posComp.(*Position)
+	if pos.X != 410 || pos.Y != 0 {
+		t.Errorf("expected to emerge from top portal at (410, 0), got (%f, %f)", pos.X, pos.Y)
+	}
+	velComp, _ := world.GetComponent(entity, "velocity")
+	vel := velComp.(*Velocity)
+	if math.Abs(vel.VX) > 1e-9 || math.Abs(vel.VY-100) > 1e-9 {
+		t.Errorf("expected velocity turned to point down, got (%f, %f)", vel.VX, vel.VY)
+	}
+
+	// Outside a portal the edge is solid
+	wall := world.CreateEntity()
+	world.AddComponent(wall, "position", &Position{X: -5, Y: 50})
+	world.AddComponent(wall, "velocity", &Velocity{VX: -100})
+	as.Update(0)
+	wallPos, _ := world.GetComponent(wall, "position")
+	if x := wallPos.(*Position).X; x != 0 {
+		t.Errorf("expected bounce off solid edge, got x=%f", x)
+	}
+}
+
+func TestArenaSystem_ElectricMode_DamagesOnBounce(t *testing.T) {
+	world := NewWorld()
+	as := NewArenaSystem(world, 800, 600, ArenaModeElectric)
+
+	var total float64
+	as.SetDamageCallback(func(entity Entity, amount float64) {
+		total += amount
+	})
+
+	entity := world.CreateEntity()
+	world.AddComponent(entity, "position", &Position{X: 810, Y: 300})
+	world.AddComponent(entity, "velocity", &Velocity{VX: 50})
+	world.AddComponent(entity, "health", struct{}{})
+
+	as.Update(0)
+	as.Update(0) // Already back inside; no second hit
+
+	if total != ElectricWallDamage {
+		t.Errorf("expected one wall hit of %f, got %f", ElectricWallDamage, total)
+	}
+}
+
+func TestArenaSystem_AsymmetricMode(t *testing.T) {
+	world := NewWorld()
+	as := NewArenaSystem(world, 800, 600, ArenaModeAsymmetric)
+
+	entity := world.CreateEntity()
+	world.AddComponent(entity, "position", &Position{X: -10, Y: -10})
+	world.AddComponent(entity, "velocity", &Velocity{VX: -50, VY: -50})
+
+	as.Update(0)
+
+	posComp, _ := world.GetComponent(entity, "position")
+	pos := posComp.(*Position)
+	if pos.X != 790 {
+		t.Errorf("expected horizontal wrap to 790, got %f", pos.X)
+	}
+	if pos.Y != 0 {
+		t.Errorf("expected vertical clamp to 0, got %f", pos.Y)
+	}
+	velComp, _ := world.GetComponent(entity, "velocity")
+	if vy := velComp.(*Velocity).VY; vy != 50 {
+		t.Errorf("expected vertical bounce, got VY=%f", vy)
+	}
+}
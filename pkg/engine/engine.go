@@ -2,7 +2,10 @@
 // input handling, and camera system.
 package engine
 
-import "math/rand"
+import (
+	"math"
+	"math/rand"
+)
 
 // GenreSetter is the interface that all output-producing systems must implement.
 type GenreSetter interface {
@@ -20,11 +23,20 @@ type System interface {
 	Update(dt float64)
 }
 
+// EntityObserver is told when an entity may have moved or changed shape and
+// when it is removed, so indexes over the world can stay current without
+// rescanning it.
+type EntityObserver interface {
+	EntityChanged(e Entity)
+	EntityRemoved(e Entity)
+}
+
 // World holds all entities and their components.
 type World struct {
-	nextID   Entity
-	entities map[Entity]map[string]Component
-	systems  []System
+	nextID    Entity
+	entities  map[Entity]map[string]Component
+	systems   []System
+	observers []EntityObserver
 }
 
 // NewWorld creates a new empty ECS world.
@@ -45,6 +57,7 @@ func (w *World) CreateEntity() Entity {
 func (w *World) AddComponent(e Entity, name string, c Component) {
 	if comps, ok := w.entities[e]; ok {
 		comps[name] = c
+		w.MarkChanged(e)
 	}
 }
 
@@ -59,7 +72,26 @@ func (w *World) GetComponent(e Entity, name string) (Component, bool) {
 
 // RemoveEntity removes an entity and all its components.
 func (w *World) RemoveEntity(e Entity) {
+	if _, ok := w.entities[e]; !ok {
+		return
+	}
 	delete(w.entities, e)
+	for _, o := range w.observers {
+		o.EntityRemoved(e)
+	}
+}
+
+// AddObserver registers an observer of entity changes and removals.
+func (w *World) AddObserver(o EntityObserver) {
+	w.observers = append(w.observers, o)
+}
+
+// MarkChanged tells observers that an entity's position, rotation or size
+// changed. Code that writes a position or rotation in place must call it.
+func (w *World) MarkChanged(e Entity) {
+	for _, o := range w.observers {
+		o.EntityChanged(e)
+	}
 }
 
 // ForEachEntity iterates over all entities calling the given function.
@@ -101,16 +133,129 @@ type InputState struct {
 	Pause       bool
 }
 
+// Camera follow defaults.
+const (
+	// DefaultCameraSmoothing is how quickly the camera catches up to its target (1/sec).
+	DefaultCameraSmoothing = 6.0
+	// DefaultCameraLookAhead is how many seconds of target velocity the camera leads by.
+	DefaultCameraLookAhead = 0.35
+	// DefaultCameraMaxLookAhead caps the look-ahead offset in pixels.
+	DefaultCameraMaxLookAhead = 120.0
+	// DefaultTraumaDecay is how much trauma the camera sheds per second.
+	DefaultTraumaDecay = 1.5
+	// DefaultMaxShakeOffset is the shake offset in pixels at full trauma.
+	DefaultMaxShakeOffset = 12.0
+)
+
 // Camera tracks the viewport position and screen-shake state.
+// X and Y are the world coordinates of the top-left corner of the view.
 type Camera struct {
 	X, Y          float64
 	ShakeAmount   float64
 	ShakeDuration float64
+
+	ViewWidth, ViewHeight     float64
+	BoundsWidth, BoundsHeight float64 // World size to keep the view inside; 0 disables clamping
+	Smoothing                 float64 // Follow responsiveness; 0 snaps to the target
+	LookAhead                 float64 // Seconds of target velocity to lead by
+	MaxLookAhead              float64 // Maximum look-ahead offset in pixels
+
+	// Trauma drives screen shake: shake grows with its square and it decays
+	// over time, so small hits barely register and big ones stack up.
+	Trauma         float64
+	TraumaDecay    float64
+	MaxShakeOffset float64
+	ShakeScale     float64 // Accessibility multiplier; 0 disables shake entirely
+	shakeTime      float64
 }
 
 // NewCamera creates a new camera at the origin.
 func NewCamera() *Camera {
-	return &Camera{}
+	return &Camera{
+		Smoothing:    DefaultCameraSmoothing,
+		LookAhead:    DefaultCameraLookAhead,
+		MaxLookAhead: DefaultCameraMaxLookAhead,
+
+		TraumaDecay:    DefaultTraumaDecay,
+		MaxShakeOffset: DefaultMaxShakeOffset,
+		ShakeScale:     1,
+	}
+}
+
+// SetViewport sets the size of the visible area in pixels.
+func (c *Camera) SetViewport(width, height int) {
+	c.ViewWidth = float64(width)
+	c.ViewHeight = float64(height)
+}
+
+// SetBounds sets the world size the view is kept inside.
+func (c *Camera) SetBounds(width, height int) {
+	c.BoundsWidth = float64(width)
+	c.BoundsHeight = float64(height)
+	c.clamp()
+}
+
+// CenterOn moves the view so (x, y) is centered, without smoothing.
+func (c *Camera) CenterOn(x, y float64) {
+	c.X = x - c.ViewWidth/2
+	c.Y = y - c.ViewHeight/2
+	c.clamp()
+}
+
+// Follow eases the view toward a target moving with velocity (vx, vy),
+// leading it by the look-ahead so the player sees where they are heading.
+func (c *Camera) Follow(x, y, vx, vy, dt float64) {
+	leadX := vx * c.LookAhead
+	leadY := vy * c.LookAhead
+	if lead := math.Hypot(leadX, leadY); lead > c.MaxLookAhead && lead > 0 {
+		leadX *= c.MaxLookAhead / lead
+		leadY *= c.MaxLookAhead / lead
+	}
+
+	targetX := x + leadX - c.ViewWidth/2
+	targetY := y + leadY - c.ViewHeight/2
+	if c.Smoothing <= 0 {
+		c.X, c.Y = targetX, targetY
+	} else {
+		// Frame-rate independent exponential smoothing
+		t := 1 - math.Exp(-c.Smoothing*dt)
+		c.X += (targetX - c.X) * t
+		c.Y += (targetY - c.Y) * t
+	}
+	c.clamp()
+}
+
+// clamp keeps the view inside the world bounds, centering it on axes where
+// the world is smaller than the view.
+func (c *Camera) clamp() {
+	c.X = clampAxis(c.X, c.ViewWidth, c.BoundsWidth)
+	c.Y = clampAxis(c.Y, c.ViewHeight, c.BoundsHeight)
+}
+
+// clampAxis clamps a view offset along one axis.
+func clampAxis(pos, view, bounds float64) float64 {
+	if bounds <= 0 {
+		return pos
+	}
+	if bounds <= view {
+		return (bounds - view) / 2
+	}
+	return math.Max(0, math.Min(pos, bounds-view))
+}
+
+// WorldToScreen converts world coordinates to screen coordinates.
+func (c *Camera) WorldToScreen(x, y float64) (float64, float64) {
+	return x - c.X, y - c.Y
+}
+
+// ScreenToWorld converts screen coordinates to world coordinates.
+func (c *Camera) ScreenToWorld(x, y float64) (float64, float64) {
+	return x + c.X, y + c.Y
+}
+
+// Center returns the world coordinates of the middle of the view.
+func (c *Camera) Center() (float64, float64) {
+	return c.X + c.ViewWidth/2, c.Y + c.ViewHeight/2
 }
 
 // Shake applies a screen-shake effect.
@@ -119,8 +264,31 @@ func (c *Camera) Shake(amount, duration float64) {
 	c.ShakeDuration = duration
 }
 
+// AddTrauma adds screen-shake trauma, capped at 1.
+func (c *Camera) AddTrauma(amount float64) {
+	c.Trauma = math.Min(c.Trauma+amount, 1)
+}
+
+// ShakeOffset returns the screen-space offset to apply when drawing this frame.
+// Both trauma and fixed Shake calls contribute.
+func (c *Camera) ShakeOffset() (float64, float64) {
+	amplitude := (c.Trauma*c.Trauma*c.MaxShakeOffset + c.ShakeAmount) * c.ShakeScale
+	if amplitude == 0 {
+		return 0, 0
+	}
+	return amplitude * shakeNoise(c.shakeTime, 0), amplitude * shakeNoise(c.shakeTime, 1.7)
+}
+
+// shakeNoise is a smooth pseudo-random signal in [-1, 1]: a sum of
+// incommensurate sines, so the shake wobbles rather than jitters.
+func shakeNoise(t, phase float64) float64 {
+	return (math.Sin(t*37+phase) + math.Sin(t*59+phase*2.3)*0.6 + math.Sin(t*83+phase*4.1)*0.4) / 2
+}
+
 // Update advances the camera state by dt seconds.
 func (c *Camera) Update(dt float64) {
+	c.shakeTime += dt
+	c.Trauma = math.Max(c.Trauma-c.TraumaDecay*dt, 0)
 	if c.ShakeDuration > 0 {
 		c.ShakeDuration -= dt
 		if c.ShakeDuration <= 0 {
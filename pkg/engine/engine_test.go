@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"math"
 	"testing"
 )
 
@@ -96,6 +97,34 @@ func TestWorld_AddSystem(t *testing.T) {
 	}
 }
 
+func TestWorld_Observer(t *testing.T) {
+	w := NewWorld()
+	obs := &mockObserver{}
+	w.AddObserver(obs)
+
+	e := w.CreateEntity()
+	w.AddComponent(e, "position", &Position{X: 1, Y: 2})
+	w.MarkChanged(e)
+	w.AddComponent(99, "position", &Position{})
+	w.RemoveEntity(e)
+	w.RemoveEntity(e)
+
+	if obs.changed != 2 {
+		t.Errorf("expected 2 change notifications, got %d", obs.changed)
+	}
+	if obs.removed != 1 {
+		t.Errorf("expected 1 removal notification, got %d", obs.removed)
+	}
+}
+
+type mockObserver struct {
+	changed, removed int
+}
+
+func (m *mockObserver) EntityChanged(e Entity) { m.changed++ }
+
+func (m *mockObserver) EntityRemoved(e Entity) { m.removed++ }
+
 type mockSystem struct {
 	updateCount int
 }
@@ -187,6 +216,66 @@ func TestCamera_UpdatePartial(t *testing.T) {
 	}
 }
 
+func TestCamera_FollowLooksAhead(t *testing.T) {
+	c := NewCamera()
+	c.SetViewport(800, 600)
+	c.Smoothing = 0
+
+	c.Follow(1000, 1000, 100, 0, 1.0/60.0)
+	wantX := 1000 + 100*c.LookAhead - 400
+	if c.X != wantX || c.Y != 700 {
+		t.Errorf("expected view at (%f, 700), got (%f, %f)", wantX, c.X, c.Y)
+	}
+
+	// Look-ahead is capped
+	c.Follow(1000, 1000, 100000, 0, 1.0/60.0)
+	if c.X != 1000+c.MaxLookAhead-400 {
+		t.Errorf("expected look-ahead capped at %f, got view X %f", c.MaxLookAhead, c.X)
+	}
+}
+
+func TestCamera_FollowSmoothing(t *testing.T) {
+	c := NewCamera()
+	c.SetViewport(800, 600)
+	c.LookAhead = 0
+
+	c.Follow(1400, 300, 0, 0, 1.0/60.0)
+	if c.X <= 0 || c.X >= 1000 {
+		t.Errorf("expected camera partway to target, got X=%f", c.X)
+	}
+	for i := 0; i < 600; i++ {
+		c.Follow(1400, 300, 0, 0, 1.0/60.0)
+	}
+	if c.X < 999 || c.X > 1000 {
+		t.Errorf("expected camera to settle at X=1000, got %f", c.X)
+	}
+}
+
+func TestCamera_Bounds(t *testing.T) {
+	c := NewCamera()
+	c.SetViewport(800, 600)
+	c.SetBounds(1600, 400)
+
+	c.CenterOn(1590, 10)
+	if c.X != 800 {
+		t.Errorf("expected X clamped to 800, got %f", c.X)
+	}
+	if c.Y != -100 {
+		t.Errorf("expected world smaller than view to be centered (Y=-100), got %f", c.Y)
+	}
+
+	sx, sy := c.WorldToScreen(900, 0)
+	if sx != 100 || sy != 100 {
+		t.Errorf("WorldToScreen = (%f, %f), want (100, 100)", sx, sy)
+	}
+	if wx, wy := c.ScreenToWorld(sx, sy); wx != 900 || wy != 0 {
+		t.Errorf("ScreenToWorld = (%f, %f), want (900, 0)", wx, wy)
+	}
+	if cx, cy := c.Center(); cx != 1200 || cy != 200 {
+		t.Errorf("Center = (%f, %f), want (1200, 200)", cx, cy)
+	}
+}
+
 func TestWorld_GetComponentNotFound(t *testing.T) {
 	w := NewWorld()
 	e := w.CreateEntity()
@@ -205,3 +294,47 @@ func TestWorld_GetComponentInvalidEntity(t *testing.T) {
 		t.Error("expected component not found for invalid entity")
 	}
 }
+
+func TestCamera_TraumaShake(t *testing.T) {
+	c := NewCamera()
+	if x, y := c.ShakeOffset(); x != 0 || y != 0 {
+		t.Fatalf("expected no shake without trauma, got (%f, %f)", x, y)
+	}
+
+	c.AddTrauma(0.7)
+	c.AddTrauma(0.7)
+	if c.Trauma != 1 {
+		t.Errorf("expected trauma capped at 1, got %f", c.Trauma)
+	}
+
+	moved := false
+	for i := 0; i < 10; i++ {
+		c.Update(0.01)
+		x, y := c.ShakeOffset()
+		if math.Abs(x) > c.MaxShakeOffset || math.Abs(y) > c.MaxShakeOffset {
+			t.Fatalf("shake offset (%f, %f) exceeds max %f", x, y, c.MaxShakeOffset)
+		}
+		if x != 0 || y != 0 {
+			moved = true
+		}
+	}
+	if !moved {
+		t.Error("expected trauma to shake the camera")
+	}
+
+	c.Update(1)
+	if c.Trauma != 0 {
+		t.Errorf("expected trauma to decay to 0, got %f", c.Trauma)
+	}
+}
+
+func TestCamera_ShakeScaleDisables(t *testing.T) {
+	c := NewCamera()
+	c.ShakeScale = 0
+	c.AddTrauma(1)
+	c.Shake(10, 1)
+	c.Update(0.01)
+	if x, y := c.ShakeOffset(); x != 0 || y != 0 {
+		t.Errorf("expected shake disabled, got (%f, %f)", x, y)
+	}
+}
@@ -0,0 +1,40 @@
+package engine
+
+import "math"
+
+// MaxHitStop caps how long stacked hit-stops can freeze the game, in seconds.
+const MaxHitStop = 0.2
+
+// HitStop freezes simulation time for a few frames to sell heavy impacts.
+type HitStop struct {
+	remaining float64
+}
+
+// Trigger freezes time for duration seconds. Overlapping triggers extend the
+// freeze to the longest request rather than adding up.
+func (h *HitStop) Trigger(duration float64) {
+	h.remaining = math.Min(math.Max(h.remaining, duration), MaxHitStop)
+}
+
+// Active returns true while time is frozen.
+func (h *HitStop) Active() bool {
+	return h.remaining > 0
+}
+
+// Step consumes dt seconds of real time and returns how much simulation time
+// should pass: zero while frozen, dt otherwise.
+func (h *HitStop) Step(dt float64) float64 {
+	if h.remaining <= 0 {
+		return dt
+	}
+	h.remaining -= dt
+	if h.remaining < 0 {
+		h.remaining = 0
+	}
+	return 0
+}
+
+// Reset cancels any freeze in progress.
+func (h *HitStop) Reset() {
+	h.remaining = 0
+}
@@ -0,0 +1,37 @@
+package engine
+
+import "testing"
+
+func TestHitStop_FreezesThenResumes(t *testing.T) {
+	var h HitStop
+	if got := h.Step(0.1); got != 0.1 {
+		t.Fatalf("expected time to pass when idle, got %f", got)
+	}
+
+	h.Trigger(0.05)
+	h.Trigger(0.03) // Shorter trigger does not shorten or extend the freeze
+	if !h.Active() {
+		t.Fatal("expected hit-stop active after trigger")
+	}
+	if got := h.Step(0.04); got != 0 {
+		t.Errorf("expected frozen step, got %f", got)
+	}
+	if got := h.Step(0.04); got != 0 {
+		t.Errorf("expected last frozen step, got %f", got)
+	}
+	if h.Active() {
+		t.Error("expected freeze over after 0.05s")
+	}
+	if got := h.Step(0.04); got != 0.04 {
+		t.Errorf("expected time to resume, got %f", got)
+	}
+}
+
+func TestHitStop_Capped(t *testing.T) {
+	var h HitStop
+	h.Trigger(5)
+	h.Step(MaxHitStop)
+	if h.Active() {
+		t.Errorf("expected freeze capped at %f seconds", MaxHitStop)
+	}
+}
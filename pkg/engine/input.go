@@ -7,28 +7,121 @@ type InputReader interface {
 	ReadState(bindings KeyBindings) InputState
 }
 
-// KeyBindings holds the string key names for player controls.
+// Action names, matching the keys of the controls config section.
+const (
+	ActionThrust      = "thrust"
+	ActionRotateLeft  = "rotate_left"
+	ActionRotateRight = "rotate_right"
+	ActionFire        = "fire"
+	ActionSecondary   = "secondary"
+	ActionPause       = "pause"
+)
+
+// actions lists every action in display order.
+var actions = []string{ActionThrust, ActionRotateLeft, ActionRotateRight, ActionFire, ActionSecondary, ActionPause}
+
+// Actions returns the bindable action names in display order.
+func Actions() []string {
+	return append([]string(nil), actions...)
+}
+
+// KeyBindings holds the key and gamepad button names bound to each player
+// control. An action is active while any of its bindings is held.
 type KeyBindings struct {
-	Thrust      string
-	RotateLeft  string
-	RotateRight string
-	Fire        string
-	Secondary   string
-	Pause       string
+	Thrust      []string
+	RotateLeft  []string
+	RotateRight []string
+	Fire        []string
+	Secondary   []string
+	Pause       []string
 }
 
 // DefaultKeyBindings returns the default key bindings.
 func DefaultKeyBindings() KeyBindings {
 	return KeyBindings{
-		Thrust:      "W",
-		RotateLeft:  "A",
-		RotateRight: "D",
-		Fire:        "Space",
-		Secondary:   "Shift",
-		Pause:       "Escape",
+		Thrust:      []string{"W", "PadUp"},
+		RotateLeft:  []string{"A", "PadLeft"},
+		RotateRight: []string{"D", "PadRight"},
+		Fire:        []string{"Space", "PadA"},
+		Secondary:   []string{"Shift", "PadB"},
+		Pause:       []string{"Escape", "PadStart"},
 	}
 }
 
+// KeyBindingsFromMap builds bindings from lists keyed by action name, such
+// as the controls config section. Unknown actions are ignored.
+func KeyBindingsFromMap(m map[string][]string) KeyBindings {
+	var b KeyBindings
+	for action, keys := range m {
+		b.Set(action, append([]string(nil), keys...))
+	}
+	return b
+}
+
+// field returns the binding list for an action, or nil for unknown names.
+func (b *KeyBindings) field(action string) *[]string {
+	switch action {
+	case ActionThrust:
+		return &b.Thrust
+	case ActionRotateLeft:
+		return &b.RotateLeft
+	case ActionRotateRight:
+		return &b.RotateRight
+	case ActionFire:
+		return &b.Fire
+	case ActionSecondary:
+		return &b.Secondary
+	case ActionPause:
+		return &b.Pause
+	}
+	return nil
+}
+
+// Get returns the keys bound to an action.
+func (b KeyBindings) Get(action string) []string {
+	if f := b.field(action); f != nil {
+		return *f
+	}
+	return nil
+}
+
+// Set replaces the keys bound to an action. Unknown actions are ignored.
+func (b *KeyBindings) Set(action string, keys []string) {
+	if f := b.field(action); f != nil {
+		*f = keys
+	}
+}
+
+// Action returns the action a key is bound to, or "" if it is unbound.
+func (b KeyBindings) Action(key string) string {
+	for _, action := range actions {
+		for _, k := range b.Get(action) {
+			if k == key {
+				return action
+			}
+		}
+	}
+	return ""
+}
+
+// Clone returns a copy that shares no binding lists with b.
+func (b KeyBindings) Clone() KeyBindings {
+	var c KeyBindings
+	for _, action := range actions {
+		c.Set(action, append([]string(nil), b.Get(action)...))
+	}
+	return c
+}
+
+// Map returns the bindings keyed by action name.
+func (b KeyBindings) Map() map[string][]string {
+	m := make(map[string][]string, len(actions))
+	for _, action := range actions {
+		m[action] = b.Get(action)
+	}
+	return m
+}
+
 // InputSystem reads player input and applies it to entities.
 type InputSystem struct {
 	world        *World
@@ -54,6 +147,16 @@ func (is *InputSystem) SetPlayerEntity(entity Entity) {
 	is.playerEntity = entity
 }
 
+// Bindings returns the key bindings input is read with.
+func (is *InputSystem) Bindings() KeyBindings {
+	return is.bindings
+}
+
+// SetBindings replaces the key bindings; they apply from the next update.
+func (is *InputSystem) SetBindings(bindings KeyBindings) {
+	is.bindings = bindings
+}
+
 // GetState returns the current input state.
 func (is *InputSystem) GetState() InputState {
 	return is.state
@@ -4,7 +4,10 @@
 // input handling, and camera system.
 package engine
 
-import "github.com/hajimehoshi/ebiten/v2"
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
 
 // EbitenInputReader reads input from Ebiten's keyboard and gamepad APIs.
 type EbitenInputReader struct {
@@ -32,17 +35,18 @@ func (r *EbitenInputReader) SetScreenSize(width, height int) {
 
 // ReadState reads the current input state from keyboard, gamepads, and touch.
 func (r *EbitenInputReader) ReadState(bindings KeyBindings) InputState {
+	pads := ebiten.AppendGamepadIDs(nil)
 	state := InputState{
-		Thrust:      ebiten.IsKeyPressed(parseKey(bindings.Thrust)),
-		RotateLeft:  ebiten.IsKeyPressed(parseKey(bindings.RotateLeft)),
-		RotateRight: ebiten.IsKeyPressed(parseKey(bindings.RotateRight)),
-		Fire:        ebiten.IsKeyPressed(parseKey(bindings.Fire)),
-		Secondary:   ebiten.IsKeyPressed(parseKey(bindings.Secondary)),
-		Pause:       ebiten.IsKeyPressed(parseKey(bindings.Pause)),
+		Thrust:      bindingPressed(bindings.Thrust, pads),
+		RotateLeft:  bindingPressed(bindings.RotateLeft, pads),
+		RotateRight: bindingPressed(bindings.RotateRight, pads),
+		Fire:        bindingPressed(bindings.Fire, pads),
+		Secondary:   bindingPressed(bindings.Secondary, pads),
+		Pause:       bindingPressed(bindings.Pause, pads),
 	}
 
-	// Merge gamepad input (any connected gamepad)
-	r.mergeGamepadInput(&state)
+	// Merge analog gamepad input (any connected gamepad)
+	r.mergeGamepadInput(pads, &state)
 
 	// Merge touch input
 	r.mergeTouchInput(&state)
@@ -50,6 +54,65 @@ func (r *EbitenInputReader) ReadState(bindings KeyBindings) InputState {
 	return state
 }
 
+// bindingPressed reports whether any key or gamepad button in names is
+// held. Gamepad buttons are read from every connected pad, through the
+// standard layout where the pad has one and raw buttons otherwise.
+func bindingPressed(names []string, pads []ebiten.GamepadID) bool {
+	for _, name := range names {
+		if key, ok := keyNameMap[name]; ok && ebiten.IsKeyPressed(key) {
+			return true
+		}
+		for _, id := range pads {
+			if padButtonPressed(id, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// padButtonPressed reports whether the named gamepad button is held on a pad.
+func padButtonPressed(id ebiten.GamepadID, name string) bool {
+	if ebiten.IsStandardGamepadLayoutAvailable(id) {
+		button, ok := padButtonMap[name]
+		return ok && ebiten.IsStandardGamepadButtonPressed(id, button)
+	}
+	for _, button := range rawPadButtonMap[name] {
+		if ebiten.IsGamepadButtonPressed(id, button) {
+			return true
+		}
+	}
+	return false
+}
+
+// JustPressedBinding returns the name of a bindable key or gamepad button
+// pressed this tick, for rebinding screens to capture.
+func JustPressedBinding() (string, bool) {
+	for _, key := range inpututil.AppendJustPressedKeys(nil) {
+		if name, ok := keyBindingNames[key]; ok {
+			return name, true
+		}
+	}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			for name, buttons := range rawPadButtonMap {
+				for _, button := range buttons {
+					if inpututil.IsGamepadButtonJustPressed(id, button) {
+						return name, true
+					}
+				}
+			}
+			continue
+		}
+		for name, button := range padButtonMap {
+			if inpututil.IsStandardGamepadButtonJustPressed(id, button) {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
 // mergeTouchInput reads from touch screen and maps regions to virtual buttons.
 // Touch regions:
 //   - Left 1/3 of screen: rotate left
@@ -92,31 +155,30 @@ func (r *EbitenInputReader) mapTouchToAction(x, y int, state *InputState) {
 	}
 }
 
-// mergeGamepadInput reads from all connected gamepads and merges into state.
-func (r *EbitenInputReader) mergeGamepadInput(state *InputState) {
-	ids := ebiten.AppendGamepadIDs(nil)
-	for _, id := range ids {
+// mergeGamepadInput reads the analog sticks and triggers of all connected
+// gamepads and merges them into state. Buttons are read through bindings.
+func (r *EbitenInputReader) mergeGamepadInput(pads []ebiten.GamepadID, state *InputState) {
+	for _, id := range pads {
 		r.readGamepadRotation(id, state)
 		r.readGamepadThrust(id, state)
-		r.readGamepadButtons(id, state)
 	}
 }
 
-// readGamepadRotation reads rotation input from D-pad and left stick.
+// readGamepadRotation reads rotation input from the left stick.
 func (r *EbitenInputReader) readGamepadRotation(id ebiten.GamepadID, state *InputState) {
 	leftX := ebiten.GamepadAxisValue(id, 0) // Left stick X
-	if leftX < -r.deadzone || ebiten.IsGamepadButtonPressed(id, ebiten.GamepadButton13) {
+	if leftX < -r.deadzone {
 		state.RotateLeft = true
 	}
-	if leftX > r.deadzone || ebiten.IsGamepadButtonPressed(id, ebiten.GamepadButton14) {
+	if leftX > r.deadzone {
 		state.RotateRight = true
 	}
 }
 
-// readGamepadThrust reads thrust input from D-pad, left stick, and triggers.
+// readGamepadThrust reads thrust input from the left stick and right trigger.
 func (r *EbitenInputReader) readGamepadThrust(id ebiten.GamepadID, state *InputState) {
 	leftY := ebiten.GamepadAxisValue(id, 1) // Left stick Y
-	if leftY < -r.deadzone || ebiten.IsGamepadButtonPressed(id, ebiten.GamepadButton11) {
+	if leftY < -r.deadzone {
 		state.Thrust = true
 	}
 	rightTrigger := ebiten.GamepadAxisValue(id, 5)
@@ -125,40 +187,105 @@ func (r *EbitenInputReader) readGamepadThrust(id ebiten.GamepadID, state *InputS
 	}
 }
 
-// readGamepadButtons reads face button input for fire, secondary, and pause.
-func (r *EbitenInputReader) readGamepadButtons(id ebiten.GamepadID, state *InputState) {
-	if ebiten.IsGamepadButtonPressed(id, ebiten.GamepadButton0) {
-		state.Fire = true
-	}
-	if ebiten.IsGamepadButtonPressed(id, ebiten.GamepadButton1) {
-		state.Secondary = true
+// keyNameMap maps key names to ebiten.Key values. It covers every keyboard
+// name in validation.ValidKeys; modifiers match either side.
+var keyNameMap = map[string]ebiten.Key{
+	"A":         ebiten.KeyA,
+	"B":         ebiten.KeyB,
+	"C":         ebiten.KeyC,
+	"D":         ebiten.KeyD,
+	"E":         ebiten.KeyE,
+	"F":         ebiten.KeyF,
+	"G":         ebiten.KeyG,
+	"H":         ebiten.KeyH,
+	"I":         ebiten.KeyI,
+	"J":         ebiten.KeyJ,
+	"K":         ebiten.KeyK,
+	"L":         ebiten.KeyL,
+	"M":         ebiten.KeyM,
+	"N":         ebiten.KeyN,
+	"O":         ebiten.KeyO,
+	"P":         ebiten.KeyP,
+	"Q":         ebiten.KeyQ,
+	"R":         ebiten.KeyR,
+	"S":         ebiten.KeyS,
+	"T":         ebiten.KeyT,
+	"U":         ebiten.KeyU,
+	"V":         ebiten.KeyV,
+	"W":         ebiten.KeyW,
+	"X":         ebiten.KeyX,
+	"Y":         ebiten.KeyY,
+	"Z":         ebiten.KeyZ,
+	"0":         ebiten.KeyDigit0,
+	"1":         ebiten.KeyDigit1,
+	"2":         ebiten.KeyDigit2,
+	"3":         ebiten.KeyDigit3,
+	"4":         ebiten.KeyDigit4,
+	"5":         ebiten.KeyDigit5,
+	"6":         ebiten.KeyDigit6,
+	"7":         ebiten.KeyDigit7,
+	"8":         ebiten.KeyDigit8,
+	"9":         ebiten.KeyDigit9,
+	"Up":        ebiten.KeyUp,
+	"Down":      ebiten.KeyDown,
+	"Left":      ebiten.KeyLeft,
+	"Right":     ebiten.KeyRight,
+	"Space":     ebiten.KeySpace,
+	"Shift":     ebiten.KeyShift,
+	"Control":   ebiten.KeyControl,
+	"Alt":       ebiten.KeyAlt,
+	"Enter":     ebiten.KeyEnter,
+	"Escape":    ebiten.KeyEscape,
+	"Tab":       ebiten.KeyTab,
+	"Backspace": ebiten.KeyBackspace,
+}
+
+// keyBindingNames maps pressed keys back to their binding names for
+// capture, folding left and right modifiers together.
+var keyBindingNames = func() map[ebiten.Key]string {
+	names := map[ebiten.Key]string{
+		ebiten.KeyShiftLeft:    "Shift",
+		ebiten.KeyShiftRight:   "Shift",
+		ebiten.KeyControlLeft:  "Control",
+		ebiten.KeyControlRight: "Control",
+		ebiten.KeyAltLeft:      "Alt",
+		ebiten.KeyAltRight:     "Alt",
 	}
-	if ebiten.IsGamepadButtonPressed(id, ebiten.GamepadButton7) ||
-		ebiten.IsGamepadButtonPressed(id, ebiten.GamepadButton9) {
-		state.Pause = true
+	for name, key := range keyNameMap {
+		names[key] = name
 	}
+	return names
+}()
+
+// padButtonMap maps gamepad button names to standard layout buttons. It
+// covers every Pad name in validation.ValidKeys.
+var padButtonMap = map[string]ebiten.StandardGamepadButton{
+	"PadA":     ebiten.StandardGamepadButtonRightBottom,
+	"PadB":     ebiten.StandardGamepadButtonRightRight,
+	"PadX":     ebiten.StandardGamepadButtonRightLeft,
+	"PadY":     ebiten.StandardGamepadButtonRightTop,
+	"PadLB":    ebiten.StandardGamepadButtonFrontTopLeft,
+	"PadRB":    ebiten.StandardGamepadButtonFrontTopRight,
+	"PadLT":    ebiten.StandardGamepadButtonFrontBottomLeft,
+	"PadRT":    ebiten.StandardGamepadButtonFrontBottomRight,
+	"PadLS":    ebiten.StandardGamepadButtonLeftStick,
+	"PadRS":    ebiten.StandardGamepadButtonRightStick,
+	"PadBack":  ebiten.StandardGamepadButtonCenterLeft,
+	"PadStart": ebiten.StandardGamepadButtonCenterRight,
+	"PadUp":    ebiten.StandardGamepadButtonLeftTop,
+	"PadDown":  ebiten.StandardGamepadButtonLeftBottom,
+	"PadLeft":  ebiten.StandardGamepadButtonLeftLeft,
+	"PadRight": ebiten.StandardGamepadButtonLeftRight,
 }
 
-// keyNameMap maps string key names to ebiten.Key values.
-var keyNameMap = map[string]ebiten.Key{
-	"W":      ebiten.KeyW,
-	"A":      ebiten.KeyA,
-	"S":      ebiten.KeyS,
-	"D":      ebiten.KeyD,
-	"Space":  ebiten.KeySpace,
-	"Shift":  ebiten.KeyShiftLeft,
-	"Escape": ebiten.KeyEscape,
-	"Enter":  ebiten.KeyEnter,
-	"Up":     ebiten.KeyUp,
-	"Down":   ebiten.KeyDown,
-	"Left":   ebiten.KeyLeft,
-	"Right":  ebiten.KeyRight,
-}
-
-// parseKey converts a string key name to an ebiten.Key.
-func parseKey(name string) ebiten.Key {
-	if key, ok := keyNameMap[name]; ok {
-		return key
-	}
-	return ebiten.KeyW
+// rawPadButtonMap maps gamepad button names to raw buttons for pads without
+// a standard layout, following the common XInput numbering. Only the
+// buttons the default bindings use are covered.
+var rawPadButtonMap = map[string][]ebiten.GamepadButton{
+	"PadA":     {ebiten.GamepadButton0},
+	"PadB":     {ebiten.GamepadButton1},
+	"PadStart": {ebiten.GamepadButton7, ebiten.GamepadButton9},
+	"PadUp":    {ebiten.GamepadButton11},
+	"PadLeft":  {ebiten.GamepadButton13},
+	"PadRight": {ebiten.GamepadButton14},
 }
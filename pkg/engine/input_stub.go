@@ -19,3 +19,8 @@ func (r *StubInputReader) SetScreenSize(width, height int) {}
 func (r *StubInputReader) ReadState(bindings KeyBindings) InputState {
 	return InputState{}
 }
+
+// JustPressedBinding reports no presses when built without ebiten.
+func JustPressedBinding() (string, bool) {
+	return "", false
+}
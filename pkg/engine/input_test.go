@@ -2,6 +2,8 @@ package engine
 
 import (
 	"testing"
+
+	"github.com/opd-ai/velocity/pkg/validation"
 )
 
 // mockInputReader is a test double for InputReader.
@@ -16,16 +18,16 @@ func (m *mockInputReader) ReadState(_ KeyBindings) InputState {
 func TestDefaultKeyBindings(t *testing.T) {
 	kb := DefaultKeyBindings()
 
-	if kb.Thrust == "" {
+	if len(kb.Thrust) == 0 {
 		t.Error("expected Thrust binding to be set")
 	}
-	if kb.RotateLeft == "" {
+	if len(kb.RotateLeft) == 0 {
 		t.Error("expected RotateLeft binding to be set")
 	}
-	if kb.RotateRight == "" {
+	if len(kb.RotateRight) == 0 {
 		t.Error("expected RotateRight binding to be set")
 	}
-	if kb.Fire == "" {
+	if len(kb.Fire) == 0 {
 		t.Error("expected Fire binding to be set")
 	}
 }
@@ -176,3 +178,92 @@ func TestInputSystem_IsPausePressed(t *testing.T) {
 		t.Error("expected pause to be false initially")
 	}
 }
+
+func TestDefaultKeyBindings_Valid(t *testing.T) {
+	if err := validation.ValidateControls(DefaultKeyBindings().Map()); err != nil {
+		t.Errorf("default bindings should validate: %v", err)
+	}
+}
+
+func TestKeyBindings_GetSetAction(t *testing.T) {
+	kb := DefaultKeyBindings()
+
+	if got := kb.Get(ActionFire); len(got) == 0 || got[0] != "Space" {
+		t.Errorf("Get(fire) = %v, want Space first", got)
+	}
+	if kb.Get("jump") != nil {
+		t.Error("Get of an unknown action should return nil")
+	}
+	if got := kb.Action("PadStart"); got != ActionPause {
+		t.Errorf("Action(PadStart) = %q, want pause", got)
+	}
+	if got := kb.Action("Q"); got != "" {
+		t.Errorf("Action of an unbound key = %q, want empty", got)
+	}
+
+	kb.Set(ActionSecondary, []string{"Q", "E"})
+	if got := kb.Action("E"); got != ActionSecondary {
+		t.Errorf("Action(E) after Set = %q, want secondary", got)
+	}
+	kb.Set("jump", []string{"J"})
+	if kb.Action("J") != "" {
+		t.Error("Set of an unknown action should be ignored")
+	}
+
+	m := kb.Map()
+	if len(m) != len(Actions()) || len(m[ActionSecondary]) != 2 {
+		t.Errorf("Map() = %v, want every action", m)
+	}
+}
+
+func TestKeyBindingsFromMap(t *testing.T) {
+	m := DefaultKeyBindings().Map()
+	m["jump"] = []string{"J"}
+	kb := KeyBindingsFromMap(m)
+
+	if kb.Action("PadB") != ActionSecondary || kb.Action("J") != "" {
+		t.Errorf("KeyBindingsFromMap() = %+v", kb)
+	}
+	m[ActionFire][0] = "F"
+	if kb.Fire[0] != "Space" {
+		t.Error("bindings should not share lists with the map")
+	}
+}
+
+func TestKeyBindings_Clone(t *testing.T) {
+	kb := DefaultKeyBindings()
+	c := kb.Clone()
+	c.Fire[0] = "F"
+
+	if kb.Fire[0] != "Space" {
+		t.Error("changing a clone should not change the original")
+	}
+}
+
+func TestInputSystem_SetBindings(t *testing.T) {
+	world := NewWorld()
+	reader := &bindingRecorder{}
+	is := NewInputSystem(world, NewPhysicsSystem(world, DefaultPhysicsConfig()), DefaultKeyBindings(), reader)
+
+	custom := DefaultKeyBindings()
+	custom.Thrust = []string{"Up", "PadRT"}
+	is.SetBindings(custom)
+	is.Update(1.0 / 60)
+
+	if got := reader.last.Thrust; len(got) != 2 || got[0] != "Up" {
+		t.Errorf("reader saw thrust bound to %v, want [Up PadRT]", got)
+	}
+	if got := is.Bindings().Thrust; len(got) != 2 || got[1] != "PadRT" {
+		t.Errorf("Bindings() thrust = %v, want the replaced bindings", got)
+	}
+}
+
+// bindingRecorder records the bindings input was last read with.
+type bindingRecorder struct {
+	last KeyBindings
+}
+
+func (r *bindingRecorder) ReadState(bindings KeyBindings) InputState {
+	r.last = bindings
+	return InputState{}
+}
@@ -85,6 +85,7 @@ func (ps *PhysicsSystem) updateEntity(entity Entity, dt float64) {
 	// Update position from velocity
 	pos.X += vel.VX * dt
 	pos.Y += vel.VY * dt
+	ps.world.MarkChanged(entity)
 }
 
 // ApplyThrust applies thrust acceleration to an entity along its rotation.
@@ -124,4 +125,5 @@ func (ps *PhysicsSystem) ApplyRotation(entity Entity, direction, dt float64) {
 	for rot.Angle >= 2*math.Pi {
 		rot.Angle -= 2 * math.Pi
 	}
+	ps.world.MarkChanged(entity)
 }
@@ -9,21 +9,29 @@ import (
 	"image/color"
 	"log"
 	"math"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/colorm"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 
 	"github.com/opd-ai/velocity/pkg/audio"
+	"github.com/opd-ai/velocity/pkg/audit"
 	"github.com/opd-ai/velocity/pkg/combat"
 	"github.com/opd-ai/velocity/pkg/config"
 	"github.com/opd-ai/velocity/pkg/engine"
 	"github.com/opd-ai/velocity/pkg/procgen"
+	"github.com/opd-ai/velocity/pkg/procgen/genre"
 	"github.com/opd-ai/velocity/pkg/rendering"
 	"github.com/opd-ai/velocity/pkg/saveload"
 	"github.com/opd-ai/velocity/pkg/ux"
 	"github.com/opd-ai/velocity/pkg/version"
+	"github.com/opd-ai/velocity/pkg/world"
 )
 
 // Physics tuning constants - adjust these to change ship handling feel.
@@ -50,10 +58,127 @@ const (
 	PlayerSpriteSizePx = 16
 	// PlayerBoundingBoxOffset is the offset from sprite center for collision.
 	PlayerBoundingBoxOffset = -8
+	// EngineTrailOffset is how far behind the player's center exhaust is emitted.
+	EngineTrailOffset = PlayerSpriteSizePx / 2
 	// DefaultProjectileSize is the pixel size for projectile sprites.
 	DefaultProjectileSize = 8
 )
 
+// Weather constants.
+const (
+	// WeatherHazardSpriteVariant is the projectile sprite variant used for meteors.
+	WeatherHazardSpriteVariant = 1
+	// WeatherParticleSpread is the angular spread in radians of weather particles.
+	WeatherParticleSpread = 0.3
+)
+
+// Combat feedback constants.
+const (
+	// PlayerHitTraumaDamage is the damage to the player that produces full camera trauma.
+	PlayerHitTraumaDamage = 40.0
+	// EnemyKillTrauma is the camera trauma added by each enemy kill.
+	EnemyKillTrauma = 0.15
+	// ObstacleBreakTrauma is the camera trauma added when an obstacle breaks.
+	ObstacleBreakTrauma = 0.1
+	// PlayerDeathTrauma is the camera trauma added when the player dies.
+	PlayerDeathTrauma = 1.0
+	// BigKillHitStop is the freeze in seconds on combo milestones and marked targets.
+	BigKillHitStop = 0.06
+	// PlayerDeathHitStop is the freeze in seconds when the player dies.
+	PlayerDeathHitStop = 0.15
+	// HitFlashMinDamage is the smallest hit that flashes a sprite, so damage
+	// over time (like the arena ring) doesn't strobe.
+	HitFlashMinDamage = 1.0
+	// KillShockwaveRadius is the final radius of an enemy kill shockwave.
+	KillShockwaveRadius = 60.0
+	// BigShockwaveRadius is the final radius of big kill and death shockwaves.
+	BigShockwaveRadius = 140.0
+	// ShockwaveDuration is how long a shockwave takes to expand and fade.
+	ShockwaveDuration = 0.4
+	// ShockwaveChromaticOffset is the pixel split of the red and blue fringe rings.
+	ShockwaveChromaticOffset = 2.0
+)
+
+// Lighting constants.
+const (
+	// ExplosionLightScale sizes an explosion's light flash relative to its shockwave.
+	ExplosionLightScale = 1.5
+	// ExplosionLightDuration is how long an explosion's light flash takes to fade.
+	ExplosionLightDuration = 0.35
+	// PlayerLightRadius is the radius of the light around the player's ship.
+	PlayerLightRadius = 110.0
+	// PlayerLightIdle is the player's light intensity while coasting.
+	PlayerLightIdle = 0.7
+	// PlayerLightThrust is the player's light intensity while the engine fires.
+	PlayerLightThrust = 1.0
+)
+
+// playerLightColor is the cool white of the player's running lights and engine.
+var playerLightColor = color.RGBA{R: 190, G: 220, B: 255, A: 255}
+
+// Shockwave colors.
+var (
+	killShockwaveColor     = color.RGBA{R: 255, G: 210, B: 140, A: 255}
+	obstacleShockwaveColor = color.RGBA{R: 200, G: 200, B: 200, A: 255}
+	deathShockwaveColor    = color.RGBA{R: 255, G: 80, B: 80, A: 255}
+)
+
+// Arena constants.
+const (
+	// ProceduralArenaMode is the config value that lets the generator pick the arena mode per wave.
+	ProceduralArenaMode = "procedural"
+	// ArenaOverlayStroke is the line width of ring, portal and electric wall overlays.
+	ArenaOverlayStroke = 2
+)
+
+// Arena overlay colors.
+var (
+	ringColor     = color.RGBA{R: 255, G: 80, B: 80, A: 200}
+	portalColor   = color.RGBA{R: 180, G: 80, B: 255, A: 255}
+	electricColor = color.RGBA{R: 120, G: 220, B: 255, A: 255}
+)
+
+// arenaModeNames are the HUD labels for arena modes other than plain wrap.
+var arenaModeNames = map[engine.ArenaMode]string{
+	engine.ArenaModeBounded:    "Walled Arena",
+	engine.ArenaModeScroll:     "Scrolling Stage",
+	engine.ArenaModeRing:       "Closing Ring",
+	engine.ArenaModePortal:     "Portals",
+	engine.ArenaModeElectric:   "Electrified Walls",
+	engine.ArenaModeAsymmetric: "Side Wrap",
+}
+
+// Obstacle constants.
+const (
+	// ObstacleDebrisParticles is the particle count emitted when an obstacle breaks.
+	ObstacleDebrisParticles = 12
+	// ObstacleDestroyScore is the score for breaking an obstacle.
+	ObstacleDestroyScore = 10
+)
+
+// Objective constants.
+const (
+	// ObjectiveTargetScale enlarges the marked assassination target's sprite.
+	ObjectiveTargetScale = 1.5
+	// StatusTintStrength is how far status effect tints pull from the base tint.
+	StatusTintStrength = 0.6
+	// FogFadeBand is the distance over which entities fade out at the fog's edge.
+	FogFadeBand = 40.0
+	// CollectibleSpriteVariant is the pickup sprite variant used for
+	// collectibles, drawn in a shape no power-up uses.
+	CollectibleSpriteVariant = rendering.PickupShapeRings
+)
+
+// Power-up constants.
+const (
+	// PowerUpDropChance is the probability that a killed enemy drops a power-up.
+	PowerUpDropChance = 0.12
+	// PowerUpAuraInterval is the time in seconds between aura particle bursts.
+	PowerUpAuraInterval = 0.05
+	// PowerUpAuraParticles is the number of aura particles per active power-up per burst.
+	PowerUpAuraParticles = 1
+)
+
 // Scoring constants.
 const (
 	// BaseKillScore is the base points awarded for killing an enemy.
@@ -62,6 +187,8 @@ const (
 	WaveBonusMultiplier = 100
 	// ComboTimerDuration is how long in seconds before combo resets.
 	ComboTimerDuration = 2.0
+	// BossBarName labels the boss bar shown for a marked target.
+	BossBarName = "MARKED TARGET"
 	// ComboTierDivisor is the combo count divisor for multiplier calculation.
 	ComboTierDivisor = 5
 )
@@ -72,6 +199,14 @@ const (
 	AudioIntensityLow = 0.3
 	// AudioIntensityHigh is used during active wave combat.
 	AudioIntensityHigh = 0.8
+	// BigKillDuckDepth is how far music ducks under a big kill.
+	BigKillDuckDepth = 0.5
+	// BigKillDuckHold is how long music stays ducked after a big kill, in seconds.
+	BigKillDuckHold = 0.3
+	// PlayerDeathDuckDepth is how far music ducks when the player dies.
+	PlayerDeathDuckDepth = 0.7
+	// PlayerDeathDuckHold is how long music stays ducked after the player dies, in seconds.
+	PlayerDeathDuckHold = 0.8
 )
 
 // Frame timing.
@@ -94,14 +229,42 @@ const (
 	TutorialStepCount = 4
 	// MenuItemSpacing is the vertical spacing between menu items.
 	MenuItemSpacing = 20
-	// HUDBottomOffset is the Y offset from bottom for HUD text.
-	HUDBottomOffset = 20
 	// GameOverScoreOffset is the Y offset for score display on game over.
 	GameOverScoreOffset = 80
+	// AuditWindowFrames is the length of the audit window. The peak draw-call
+	// count covers the last completed window and the current one.
+	AuditWindowFrames = 600
+	// SettingsTitleY and SettingsTopY place the settings screen's title and
+	// first line; the list is too long to start at mid-screen.
+	SettingsTitleY = 40
+	SettingsTopY   = 80
+	// SettingsNoteOffset is the distance of the selected setting's note
+	// from the bottom of the screen.
+	SettingsNoteOffset = 40
 	// ViewportCullMargin is the margin in pixels for partial visibility culling.
 	ViewportCullMargin = 32
+	// MinimapSize is the width in pixels of the minimap shown in large worlds.
+	MinimapSize = 120
+	// MinimapMargin is the gap between the minimap and the screen edge.
+	MinimapMargin = 10
 )
 
+// minimapColors maps collision tags to minimap blip colors. Untagged entities
+// and projectiles are left off the map.
+var minimapColors = map[string]color.RGBA{
+	"player":   {R: 80, G: 255, B: 80, A: 255},
+	"enemy":    {R: 255, G: 60, B: 60, A: 255},
+	"convoy":   {R: 80, G: 200, B: 255, A: 255},
+	"hazard":   {R: 255, G: 160, B: 40, A: 255},
+	"obstacle": {R: 140, G: 140, B: 140, A: 255},
+}
+
+// minimapCollectibleColor is the blip color of objective collectibles.
+var minimapCollectibleColor = color.RGBA{R: 255, G: 230, B: 60, A: 255}
+
+// shieldTint colors the player's ship while a shield power-up is active.
+var shieldTint = color.RGBA{R: 120, G: 180, B: 255, A: 255}
+
 // savePath returns the path to the save file.
 func savePath() string {
 	home, err := os.UserHomeDir()
@@ -115,13 +278,20 @@ func savePath() string {
 
 // Game implements the ebiten.Game interface.
 type Game struct {
-	cfg      *config.Config
-	world    *engine.World
-	camera   *engine.Camera
-	renderer *rendering.Renderer
-	audio    *audio.Manager
-	hud      *ux.HUD
-	menu     *ux.Menu
+	cfg        *config.Config // What the running game uses
+	pendingCfg *config.Config // What the settings screen saves, including changes applying later
+	world      *engine.World
+	camera     *engine.Camera
+	renderer   *rendering.Renderer
+	audio      *audio.Manager
+	hud        *ux.HUD
+
+	// HUD drawing with the genre theme
+	hudRenderer *ux.HUDRenderer
+
+	// Last camera center, for the audio listener's velocity
+	listenerX, listenerY float64
+	menu                 *ux.Menu
 
 	// Game state management
 	stateManager   *ux.GameStateManager
@@ -134,10 +304,50 @@ type Game struct {
 	projectileSystem *combat.ProjectileSystem
 	damageSystem     *combat.DamageSystem
 	weaponSystem     *combat.WeaponSystem
+	powerUpSystem    *combat.PowerUpSystem
 	enemyAISystem    *procgen.EnemyAISystem
 
 	// Particle effects
 	particleSystem *rendering.ParticleSystem
+	explosionFX    *rendering.EmitterDef
+	sparkFX        *rendering.EmitterDef
+	smokeFX        *rendering.EmitterDef
+	engineTrail    *rendering.Emitter
+	particleBatch  particleBatch
+
+	// Combat feedback: hit flashes, shockwaves and hit-stop
+	effectsSystem   *rendering.EffectsSystem
+	animationSystem *rendering.AnimationSystem
+	lightingSystem  *rendering.LightingSystem
+	hitStop         engine.HitStop
+	shakeX, shakeY  float64 // Camera shake offset for the frame being drawn
+
+	// Parallax background layers converted from the renderer's cached layers
+	backgroundImages []*ebiten.Image
+	backgroundSource *image.RGBA
+
+	// Lightmap pass; lightTexture is stamped once per light each frame
+	lightTexture *ebiten.Image
+	lightmap     *ebiten.Image
+
+	// Genre post-processing; postShader is nil when disabled or unsupported
+	postFX     rendering.PostFX
+	postShader *ebiten.Shader
+	sceneImage *ebiten.Image
+	postFrame  int64
+
+	// Large-world overview; nil when the world fits on screen
+	minimap      *rendering.Minimap
+	minimapImage *ebiten.Image
+
+	// Arena obstacles
+	obstacleSystem *procgen.ObstacleSystem
+
+	// Space weather
+	weatherSystem *world.WeatherSystem
+
+	// Wave objectives
+	objectiveSystem *world.ObjectiveSystem
 
 	// Procedural generation
 	generator   *procgen.Generator
@@ -150,6 +360,10 @@ type Game struct {
 	combo        int
 	comboTimer   float64
 
+	// Power-up drops and aura timing
+	dropRNG   *rand.Rand
+	auraTimer float64
+
 	// Save state
 	hasSavedGame bool
 
@@ -157,32 +371,59 @@ type Game struct {
 	tutorial *ux.Tutorial
 
 	// Sprite rendering cache (converts *image.RGBA to *ebiten.Image)
-	ebitenImageCache map[string]*ebiten.Image
+	spriteAtlas   *rendering.AtlasRenderer
+	spatialGrid   *rendering.SpatialGrid
+	visible       []engine.Entity
+	spriteBatch   rendering.SpriteBatch
+	spriteFlashes []spriteFlash
+	drawCalls     audit.DrawCallCounter
+	auditLog      *audit.Logger
+	drawPeak      int // Peak draw calls of the last completed audit window
 }
 
 // NewGame initializes a new game instance from configuration.
 func NewGame(cfg *config.Config) *Game {
+	pending := *cfg
 	g := &Game{
-		cfg:              cfg,
-		world:            engine.NewWorld(),
-		camera:           engine.NewCamera(),
-		renderer:         rendering.NewRenderer(),
-		audio:            audio.NewManager(),
-		hud:              ux.NewHUD(),
-		menu:             ux.NewMenu(),
-		particleSystem:   rendering.NewParticleSystem(),
-		ebitenImageCache: make(map[string]*ebiten.Image),
+		cfg:            cfg,
+		pendingCfg:     &pending,
+		world:          engine.NewWorld(),
+		camera:         engine.NewCamera(),
+		renderer:       rendering.NewRenderer(),
+		audio:          audio.NewManager(),
+		hud:            ux.NewHUD(),
+		auditLog:       audit.NewLogger(),
+		hudRenderer:    ux.NewHUDRenderer(),
+		menu:           ux.NewMenu(),
+		particleSystem: rendering.NewParticleSystem(),
+		spriteAtlas:    rendering.NewAtlasRenderer(rendering.DefaultAtlasPageSize),
+		spatialGrid:    rendering.NewSpatialGrid(rendering.DefaultGridCellSize),
 	}
+	// The grid follows world changes instead of rescanning every frame
+	g.world.AddObserver(g.spatialGrid)
 
 	genre := cfg.Gameplay.Genre
 	g.renderer.SetGenre(genre)
 	g.renderer.SetSeed(cfg.Gameplay.Seed)
 	g.audio.SetGenre(genre)
+	g.audio.SetSeed(cfg.Gameplay.Seed)
 	g.hud.SetGenre(genre)
+	g.hud.SetResolution(cfg.Display.Width, cfg.Display.Height)
 	g.menu.SetGenre(genre)
 	g.particleSystem.SetGenre(genre)
 	g.particleSystem.SetSeed(cfg.Gameplay.Seed)
+	g.initParticleEffects()
 	g.audio.SetVolumes(cfg.Audio.MasterVolume, cfg.Audio.MusicVolume, cfg.Audio.SFXVolume)
+	spatial := audio.DefaultSpatialConfig()
+	if cfg.Audio.Attenuation != "" {
+		spatial.Curve = audio.AttenuationCurve(cfg.Audio.Attenuation)
+	}
+	if cfg.Audio.HearingRange > 0 {
+		spatial.MaxDistance = cfg.Audio.HearingRange
+	}
+	spatial.DopplerScale = cfg.Audio.Doppler
+	g.audio.SetSpatialConfig(spatial)
+	g.initPostFX()
 
 	// Check for saved game
 	g.hasSavedGame = g.checkSavedGame()
@@ -190,6 +431,13 @@ func NewGame(cfg *config.Config) *Game {
 	// Initialize game state management
 	g.stateManager = ux.NewGameStateManager()
 	g.menuController = ux.NewMenuController(g.stateManager)
+	g.menuController.SetSettings(ux.NewSettings(cfg))
+	g.menuController.SetSettingChangeCallback(g.onSettingChange)
+	g.menuController.SetControlsChangeCallback(func(b engine.KeyBindings) {
+		g.menuController.Settings().Apply(g.pendingCfg)
+		g.menuController.Settings().ApplyLive(g.cfg)
+		g.inputSystem.SetBindings(b)
+	})
 
 	// Add Continue option if save exists
 	if g.hasSavedGame {
@@ -219,6 +467,8 @@ func NewGame(cfg *config.Config) *Game {
 			g.loadAndResumeGame()
 		case "quit_menu":
 			g.saveGame() // Save before returning to menu
+		case ux.ActionBack:
+			g.saveSettings()
 		}
 	})
 
@@ -232,6 +482,17 @@ func NewGame(cfg *config.Config) *Game {
 func (g *Game) initializeSystems() {
 	width := g.cfg.Display.Width
 	height := g.cfg.Display.Height
+	worldWidth, worldHeight := g.cfg.WorldSize()
+
+	// Camera follows the player through worlds larger than the screen
+	g.camera.ShakeScale = g.cfg.Effects.ScreenShake
+	g.camera.SetViewport(width, height)
+	g.camera.SetBounds(worldWidth, worldHeight)
+	if worldWidth > width || worldHeight > height {
+		mapHeight := MinimapSize * worldHeight / worldWidth
+		g.minimap = rendering.NewMinimap(MinimapSize, mapHeight, worldWidth, worldHeight)
+		g.minimapImage = ebiten.NewImage(MinimapSize, mapHeight)
+	}
 
 	// Physics system
 	physicsConfig := engine.PhysicsConfig{
@@ -243,16 +504,13 @@ func (g *Game) initializeSystems() {
 	g.physicsSystem = engine.NewPhysicsSystem(g.world, physicsConfig)
 
 	// Input system
-	bindings := engine.DefaultKeyBindings()
+	bindings := engine.KeyBindingsFromMap(g.cfg.Controls.Map())
 	inputReader := engine.NewEbitenInputReader()
 	g.inputSystem = engine.NewInputSystem(g.world, g.physicsSystem, bindings, inputReader)
 
 	// Arena system
-	arenaMode := engine.ArenaModeWrap
-	if g.cfg.Gameplay.ArenaMode == "bounded" {
-		arenaMode = engine.ArenaModeBounded
-	}
-	g.arenaSystem = engine.NewArenaSystem(g.world, width, height, arenaMode)
+	g.arenaSystem = engine.NewArenaSystem(g.world, worldWidth, worldHeight, g.configArenaMode())
+	g.arenaSystem.SetView(width, height)
 
 	// Combat systems
 	g.projectileSystem = combat.NewProjectileSystem(g.world)
@@ -260,11 +518,31 @@ func (g *Game) initializeSystems() {
 	g.weaponSystem = combat.NewWeaponSystem(g.world, g.projectileSystem)
 	g.weaponSystem.SetFireProvider(g.inputSystem)
 
+	// Power-ups
+	g.powerUpSystem = combat.NewPowerUpSystem(g.world)
+	g.powerUpSystem.SetCollectCallback(func(entity engine.Entity, t combat.PowerUpType) {
+		g.playSFXFrom("powerup", entity)
+	})
+	g.dropRNG = engine.DeterministicRNG(g.cfg.Gameplay.Seed)
+
+	// Combat feedback
+	g.effectsSystem = rendering.NewEffectsSystem(g.world)
+	g.animationSystem = rendering.NewAnimationSystem(g.world)
+	g.lightingSystem = rendering.NewLightingSystem(g.world, g.cfg.Gameplay.Genre)
+	g.damageSystem.SetDamageCallback(g.onDamage)
+
 	// Connect projectile hits to damage system
 	g.projectileSystem.SetHitCallback(func(projectile, target engine.Entity, damage float64) {
 		g.damageSystem.QueueDamage(target, projectile, damage, "projectile")
 	})
 
+	// Shrinking rings and electrified walls hurt ships, not obstacles
+	g.arenaSystem.SetDamageCallback(func(entity engine.Entity, amount float64) {
+		if tag, ok := g.world.GetComponent(entity, "collisiontag"); ok && tag.(*combat.CollisionTag).Tag != "obstacle" {
+			g.damageSystem.QueueDamage(entity, 0, amount, "arena")
+		}
+	})
+
 	// Procedural generation
 	g.generator = procgen.NewGenerator(g.cfg.Gameplay.Seed)
 	g.generator.SetGenre(g.cfg.Gameplay.Genre)
@@ -272,10 +550,51 @@ func (g *Game) initializeSystems() {
 	g.enemyAISystem = procgen.NewEnemyAISystem(g.world)
 	g.waveSpawner = procgen.NewWaveSpawner(g.world, g.generator, width, height)
 	g.waveManager = procgen.NewWaveManager(g.world, g.waveSpawner, g.enemyAISystem)
+	g.obstacleSystem = procgen.NewObstacleSystem(g.world, g.generator, worldWidth, worldHeight)
+	g.audio.SetOccluder(g.obstacleSystem.Occludes)
+
+	// Space weather, rolled per wave by the generator
+	g.weatherSystem = world.NewWeatherSystem(g.world, worldWidth, worldHeight)
+	g.weatherSystem.SetDamageCallback(func(target, source engine.Entity, amount float64) {
+		g.damageSystem.QueueDamage(target, source, amount, "weather")
+	})
+	g.weatherSystem.SetHazardSpawnCallback(func(e engine.Entity) {
+		g.world.AddComponent(e, "sprite", &rendering.SpriteComponent{
+			Type:    rendering.SpriteTypeProjectile,
+			Variant: WeatherHazardSpriteVariant,
+			Size:    world.MeteorSize,
+		})
+	})
+
+	// Wave objectives, rolled per wave by the generator and resolved by the wave manager
+	g.objectiveSystem = world.NewObjectiveSystem(g.world, worldWidth, worldHeight)
+	g.objectiveSystem.SetDamageCallback(func(target, source engine.Entity, amount float64) {
+		g.damageSystem.QueueDamage(target, source, amount, "contact")
+	})
+	g.objectiveSystem.SetSpawnCallback(g.onObjectiveSpawn)
+	g.objectiveSystem.SetCompleteCallback(func(o *world.Objective) {
+		g.score += o.Reward
+		if o.Reward > 0 {
+			g.audio.PlaySFX("powerup")
+		}
+	})
+	g.waveManager.SetObjectiveSystem(g.objectiveSystem)
 
 	// Wave callbacks
 	g.waveManager.SetWaveStartCallback(func(wave int) {
+		waveCfg := g.generator.GenerateWave(wave)
+		if g.cfg.Gameplay.ArenaMode == ProceduralArenaMode {
+			g.arenaSystem.SetMode(waveCfg.ArenaMode)
+		}
+		g.arenaSystem.ResetRing()
+		g.weatherSystem.Start(waveCfg.Weather, g.cfg.Gameplay.Genre, waveCfg.WeatherIntensity, waveCfg.Seed)
+		g.obstacleSystem.SpawnWave(wave)
 		g.audio.PlaySFX("wave_start")
+		g.audio.CueMusic(audio.CueWaveStart)
+		// Hunting a marked target gets boss music
+		if _, ok := g.objectiveSystem.Target(); ok {
+			g.audio.CueMusic(audio.CueBoss)
+		}
 	})
 
 	g.waveManager.SetWaveCompleteCallback(func(wave int) {
@@ -293,6 +612,9 @@ func (g *Game) initializeSystems() {
 			if ct := tag.(*combat.CollisionTag); ct.Tag == "player" {
 				g.onPlayerDeath()
 			}
+			if ct := tag.(*combat.CollisionTag); ct.Tag == "obstacle" {
+				g.onObstacleDestroyed(event.Entity)
+			}
 		}
 	})
 
@@ -301,16 +623,59 @@ func (g *Game) initializeSystems() {
 	g.world.AddSystem(g.arenaSystem)
 }
 
+// configArenaMode returns the configured arena mode. Procedural arenas
+// start in wrap mode until the first wave picks one.
+func (g *Game) configArenaMode() engine.ArenaMode {
+	if g.cfg.Gameplay.ArenaMode == ProceduralArenaMode {
+		return engine.ArenaModeWrap
+	}
+	return engine.ArenaMode(g.cfg.Gameplay.ArenaMode)
+}
+
+// onSettingChange applies a changed setting. Every change goes into the
+// pending config to be saved, but only display toggles and volumes reach the
+// live config; the arena mode joins it at the next game and the rest after a
+// restart.
+func (g *Game) onSettingChange(s ux.Setting) {
+	g.menuController.Settings().Apply(g.pendingCfg)
+	g.menuController.Settings().ApplyLive(g.cfg)
+	switch {
+	case s.Key == "display.fullscreen":
+		ebiten.SetFullscreen(g.cfg.Display.Fullscreen)
+	case s.Key == "display.vsync":
+		ebiten.SetVsyncEnabled(g.cfg.Display.VSync)
+	case strings.HasPrefix(s.Key, "audio."):
+		g.audio.SetVolumes(g.cfg.Audio.MasterVolume, g.cfg.Audio.MusicVolume, g.cfg.Audio.SFXVolume)
+	}
+}
+
+// saveSettings writes changed settings back to the config file.
+func (g *Game) saveSettings() {
+	settings := g.menuController.Settings()
+	if !settings.Dirty() {
+		return
+	}
+	if err := config.Save(g.pendingCfg); err != nil {
+		log.Printf("Warning: failed to save settings: %v", err)
+		return
+	}
+	settings.MarkSaved()
+}
+
 // startNewGame resets the game state and spawns the player.
 func (g *Game) startNewGame() {
 	// Clear all entities
 	g.clearAllEntities()
 
 	// Reset game state
+	g.effectsSystem.Clear()
+	g.hitStop.Reset()
 	g.score = 0
 	g.combo = 0
 	g.comboTimer = 0
 	g.waveManager.Reset()
+	g.menuController.Settings().ApplyNextGame(g.cfg) // The settings screen may have changed the arena mode
+	g.arenaSystem.SetMode(g.configArenaMode())
 
 	// Enable tutorial for first-run (no save file exists)
 	if !g.hasSavedGame {
@@ -330,18 +695,22 @@ func (g *Game) startNewGame() {
 	g.waveManager.StartNextWave()
 }
 
-// spawnPlayer creates the player entity at screen center.
+// spawnPlayer creates the player entity at the world center, or at the
+// bottom of the stage in scroll mode.
 func (g *Game) spawnPlayer() {
-	width := float64(g.cfg.Display.Width)
-	height := float64(g.cfg.Display.Height)
+	worldWidth, worldHeight := g.cfg.WorldSize()
+	x := float64(worldWidth) / 2
+	y := float64(worldHeight) / 2
+
+	g.arenaSystem.ResetScroll()
+	if engine.ArenaMode(g.cfg.Gameplay.ArenaMode) == engine.ArenaModeScroll {
+		y = g.arenaSystem.ScrollOffset() + float64(g.cfg.Display.Height)/2
+	}
 
 	g.playerEntity = g.world.CreateEntity()
 
 	// Position at center
-	g.world.AddComponent(g.playerEntity, "position", &engine.Position{
-		X: width / 2,
-		Y: height / 2,
-	})
+	g.world.AddComponent(g.playerEntity, "position", &engine.Position{X: x, Y: y})
 
 	// Initial velocity (stationary)
 	g.world.AddComponent(g.playerEntity, "velocity", &engine.Velocity{VX: 0, VY: 0})
@@ -364,17 +733,29 @@ func (g *Game) spawnPlayer() {
 	// Weapon
 	primaryWeapon := combat.NewWeapon(combat.WeaponPrimary, DefaultPlayerWeaponDamage, DefaultPlayerWeaponCooldown)
 	g.world.AddComponent(g.playerEntity, "weapon", combat.NewWeaponComponent(primaryWeapon))
+	g.world.AddComponent(g.playerEntity, "powerups", combat.NewPowerUpComponent())
 
 	// Sprite
 	g.world.AddComponent(g.playerEntity, "sprite", &rendering.SpriteComponent{
 		Type:    rendering.SpriteTypeShip,
-		Variant: 0,
+		Variant: int(rendering.HullInterceptor),
 		Size:    PlayerSpriteSizePx,
 	})
+	g.world.AddComponent(g.playerEntity, "animation", rendering.NewAnimation())
+	g.world.AddComponent(g.playerEntity, "light", &rendering.Light{
+		Radius: PlayerLightRadius, Color: playerLightColor, Intensity: PlayerLightIdle,
+	})
 
 	// Connect player to systems
 	g.inputSystem.SetPlayerEntity(g.playerEntity)
 	g.enemyAISystem.SetPlayerEntity(g.playerEntity)
+	g.powerUpSystem.SetPlayerEntity(g.playerEntity)
+	g.objectiveSystem.SetPlayerEntity(g.playerEntity)
+	g.arenaSystem.SetFocus(g.playerEntity)
+
+	g.camera.CenterOn(x, y)
+	g.listenerX, g.listenerY = g.camera.Center()
+	g.syncView()
 }
 
 // clearAllEntities removes all entities from the world.
@@ -389,14 +770,77 @@ func (g *Game) clearAllEntities() {
 	g.playerEntity = 0
 }
 
+// onDamage flashes damaged entities, and shakes the camera and plays a hit
+// sound when the player is hit.
+func (g *Game) onDamage(event combat.DamageEvent) {
+	if g.cfg.Effects.HitFlash && event.Amount >= HitFlashMinDamage {
+		g.effectsSystem.Flash(event.Target, rendering.DefaultHitFlashDuration)
+	}
+	if anim, ok := g.world.GetComponent(event.Target, "animation"); ok && event.Amount >= HitFlashMinDamage {
+		anim.(*rendering.Animation).Play(rendering.AnimHit)
+	}
+	if event.Target == g.playerEntity {
+		g.camera.AddTrauma(math.Min(event.Amount/PlayerHitTraumaDamage, 1))
+		g.playSFXFrom("hit", event.Target)
+	}
+}
+
+// impact shakes the camera, spawns a shockwave and optionally freezes time
+// for a moment, honoring the player's effect settings.
+func (g *Game) impact(x, y, trauma, hitStop, radius float64, c color.RGBA) {
+	g.camera.AddTrauma(trauma)
+	if hitStop > 0 && g.cfg.Effects.HitStop {
+		g.hitStop.Trigger(hitStop)
+	}
+	if g.cfg.Effects.Shockwaves {
+		g.effectsSystem.SpawnShockwave(x, y, radius, ShockwaveDuration, c)
+	}
+	g.lightingSystem.SpawnFlash(x, y, radius*ExplosionLightScale, c, ExplosionLightDuration)
+}
+
+// spawnDyingGhost leaves a non-interactive copy of a dying ship that plays
+// its dying animation; the animation system removes it when done.
+func (g *Game) spawnDyingGhost(entity engine.Entity) {
+	posComp, hasPos := g.world.GetComponent(entity, "position")
+	spriteComp, hasSprite := g.world.GetComponent(entity, "sprite")
+	if !hasPos || !hasSprite {
+		return
+	}
+	ghost := g.world.CreateEntity()
+	pos := *posComp.(*engine.Position)
+	sprite := *spriteComp.(*rendering.SpriteComponent)
+	g.world.AddComponent(ghost, "position", &pos)
+	g.world.AddComponent(ghost, "sprite", &sprite)
+	if rotComp, ok := g.world.GetComponent(entity, "rotation"); ok {
+		rot := *rotComp.(*engine.Rotation)
+		g.world.AddComponent(ghost, "rotation", &rot)
+	}
+	anim := rendering.NewAnimation()
+	anim.Play(rendering.AnimDying)
+	g.world.AddComponent(ghost, "animation", anim)
+}
+
 // onEnemyKilled handles scoring when an enemy dies.
 func (g *Game) onEnemyKilled(entity engine.Entity) {
 	g.waveManager.OnEnemyKilled()
+	g.spawnDyingGhost(entity)
+
+	// Combo milestones and marked targets are big kills
+	target, isTarget := g.objectiveSystem.Target()
+	bigKill := (isTarget && target == entity) || (g.combo+1)%ComboTierDivisor == 0
 
-	// Get entity position for particle effect
+	// Get entity position for particle effect and power-up drops
 	if pos, ok := g.world.GetComponent(entity, "position"); ok {
 		p := pos.(*engine.Position)
-		g.particleSystem.Emit(p.X, p.Y, 20)
+		g.particleSystem.Burst(g.explosionFX, p.X, p.Y, 0)
+		g.particleSystem.Burst(g.sparkFX, p.X, p.Y, 0)
+		g.maybeDropPowerUp(p.X, p.Y)
+		if bigKill {
+			g.impact(p.X, p.Y, EnemyKillTrauma*2, BigKillHitStop, BigShockwaveRadius, killShockwaveColor)
+			g.audio.Duck(BigKillDuckDepth, BigKillDuckHold)
+		} else {
+			g.impact(p.X, p.Y, EnemyKillTrauma, 0, KillShockwaveRadius, killShockwaveColor)
+		}
 	}
 
 	// Mark tutorial kill action
@@ -411,14 +855,60 @@ func (g *Game) onEnemyKilled(entity engine.Entity) {
 	g.combo++
 	g.comboTimer = ComboTimerDuration
 	multiplier := 1 + g.combo/ComboTierDivisor
+	if pu := g.playerPowerUps(); pu != nil {
+		multiplier *= pu.ScoreMultiplier()
+	}
 	g.score += baseScore * int64(multiplier)
 
-	g.audio.PlaySFX("explosion")
+	g.playSFXFrom("explosion", entity)
+}
+
+// onObstacleDestroyed splits asteroids and scatters debris.
+func (g *Game) onObstacleDestroyed(entity engine.Entity) {
+	if pos, ok := g.world.GetComponent(entity, "position"); ok {
+		p := pos.(*engine.Position)
+		g.particleSystem.Emit(p.X, p.Y, ObstacleDebrisParticles)
+		g.particleSystem.Burst(g.smokeFX, p.X, p.Y, 0)
+		g.impact(p.X, p.Y, ObstacleBreakTrauma, 0, KillShockwaveRadius, obstacleShockwaveColor)
+	}
+	g.obstacleSystem.HandleDestroyed(entity)
+	g.score += ObstacleDestroyScore
+	g.playSFXFrom("explosion", entity)
+}
+
+// maybeDropPowerUp rolls for a power-up pickup at the given position.
+func (g *Game) maybeDropPowerUp(x, y float64) {
+	if g.dropRNG.Float64() >= PowerUpDropChance {
+		return
+	}
+	types := combat.AllPowerUpTypes()
+	t := types[g.dropRNG.Intn(len(types))]
+
+	e := g.powerUpSystem.SpawnPickup(x, y, t)
+	g.world.AddComponent(e, "sprite", &rendering.SpriteComponent{
+		Type:    rendering.SpriteTypePickup,
+		Variant: int(t),
+		Size:    combat.PowerUpPickupSize,
+	})
+}
+
+// playerPowerUps returns the player's power-up component, or nil.
+func (g *Game) playerPowerUps() *combat.PowerUpComponent {
+	if comp, ok := g.world.GetComponent(g.playerEntity, "powerups"); ok {
+		return comp.(*combat.PowerUpComponent)
+	}
+	return nil
 }
 
 // onPlayerDeath handles game over when player dies.
 func (g *Game) onPlayerDeath() {
+	if pos, ok := g.world.GetComponent(g.playerEntity, "position"); ok {
+		p := pos.(*engine.Position)
+		g.impact(p.X, p.Y, PlayerDeathTrauma, PlayerDeathHitStop, BigShockwaveRadius, deathShockwaveColor)
+	}
 	g.stateManager.GameOver(g.score, g.waveManager.CurrentWave())
+	g.audio.CueMusic(audio.CueGameOver)
+	g.audio.Duck(PlayerDeathDuckDepth, PlayerDeathDuckHold)
 	g.deleteSaveFile() // Clear save on game over
 }
 
@@ -547,15 +1037,52 @@ func (g *Game) Update() error {
 		g.stateManager.PauseGame()
 	}
 
+	g.updateListener(dt)
 	g.audio.Update()
 	return nil
 }
 
+// updateListener hears positional sounds from the camera center, moving
+// with the camera for Doppler.
+func (g *Game) updateListener(dt float64) {
+	x, y := g.camera.Center()
+	g.audio.SetListener(x, y, (x-g.listenerX)/dt, (y-g.listenerY)/dt)
+	g.listenerX, g.listenerY = x, y
+}
+
+// playSFXFrom plays a positional sound from an entity, moving with its
+// velocity. Entities without a position play it non-positionally.
+func (g *Game) playSFXFrom(name string, e engine.Entity) {
+	posComp, ok := g.world.GetComponent(e, "position")
+	if !ok {
+		g.audio.PlaySFX(name)
+		return
+	}
+	pos := posComp.(*engine.Position)
+	var vx, vy float64
+	if velComp, ok := g.world.GetComponent(e, "velocity"); ok {
+		vel := velComp.(*engine.Velocity)
+		vx, vy = vel.VX, vel.VY
+	}
+	g.audio.PlaySFXMoving(name, pos.X, pos.Y, vx, vy)
+}
+
 // Key state tracking for edge detection
 var prevKeys = make(map[ebiten.Key]bool)
 
 // handleMenuInput processes menu navigation.
 func (g *Game) handleMenuInput() {
+	// The controls screen takes the next key or button as a binding
+	if g.menuController.Capturing() {
+		if ebiten.IsKeyPressed(ebiten.KeyEscape) && !wasKeyPressed(ebiten.KeyEscape) {
+			g.menuController.Back()
+		} else if key, ok := engine.JustPressedBinding(); ok {
+			g.menuController.CaptureBinding(key)
+		}
+		updatePrevKeys()
+		return
+	}
+
 	// Simple menu controls (keyboard)
 	if ebiten.IsKeyPressed(ebiten.KeyUp) && !wasKeyPressed(ebiten.KeyUp) {
 		g.menuController.MoveUp()
@@ -569,8 +1096,17 @@ func (g *Game) handleMenuInput() {
 	if ebiten.IsKeyPressed(ebiten.KeySpace) && !wasKeyPressed(ebiten.KeySpace) {
 		g.menuController.Select()
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyEscape) && g.stateManager.IsPaused() {
-		g.stateManager.ResumeGame()
+	if ebiten.IsKeyPressed(ebiten.KeyLeft) && !wasKeyPressed(ebiten.KeyLeft) {
+		g.menuController.MoveLeft()
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyRight) && !wasKeyPressed(ebiten.KeyRight) {
+		g.menuController.MoveRight()
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyEscape) && !wasKeyPressed(ebiten.KeyEscape) {
+		// Escape leaves the settings screen before it resumes a paused game
+		if !g.menuController.Back() && g.stateManager.IsPaused() {
+			g.stateManager.ResumeGame()
+		}
 	}
 
 	updatePrevKeys()
@@ -585,6 +1121,8 @@ func wasKeyPressed(key ebiten.Key) bool {
 func updatePrevKeys() {
 	prevKeys[ebiten.KeyUp] = ebiten.IsKeyPressed(ebiten.KeyUp)
 	prevKeys[ebiten.KeyDown] = ebiten.IsKeyPressed(ebiten.KeyDown)
+	prevKeys[ebiten.KeyLeft] = ebiten.IsKeyPressed(ebiten.KeyLeft)
+	prevKeys[ebiten.KeyRight] = ebiten.IsKeyPressed(ebiten.KeyRight)
 	prevKeys[ebiten.KeyEnter] = ebiten.IsKeyPressed(ebiten.KeyEnter)
 	prevKeys[ebiten.KeySpace] = ebiten.IsKeyPressed(ebiten.KeySpace)
 	prevKeys[ebiten.KeyEscape] = ebiten.IsKeyPressed(ebiten.KeyEscape)
@@ -592,6 +1130,14 @@ func updatePrevKeys() {
 
 // updateGameplay runs all game systems for one tick.
 func (g *Game) updateGameplay(dt float64) {
+	// Hit-stop freezes the simulation; the camera keeps shaking and
+	// effects keep fading so the freeze reads as impact, not a stall
+	g.camera.Update(dt)
+	g.effectsSystem.Update(dt)
+	if dt = g.hitStop.Step(dt); dt == 0 {
+		return
+	}
+
 	// Update combo timer
 	if g.comboTimer > 0 {
 		g.comboTimer -= dt
@@ -601,9 +1147,13 @@ func (g *Game) updateGameplay(dt float64) {
 	}
 
 	// Update core systems
-	g.camera.Update(dt)
 	g.inputSystem.Update(dt)
+	g.updatePlayerAnimation()
+	g.updateEngineTrail()
+	g.weatherSystem.Update(dt)
 	g.world.Update(dt) // Updates physics and arena systems
+	g.obstacleSystem.Update(dt)
+	g.updateCamera(dt)
 
 	// Track tutorial actions
 	g.updateTutorialActions()
@@ -612,8 +1162,15 @@ func (g *Game) updateGameplay(dt float64) {
 	g.weaponSystem.Update(dt)
 	g.projectileSystem.Update(dt)
 	g.damageSystem.Update(dt)
+	g.powerUpSystem.Update(dt)
+	g.updatePowerUpEffects(dt)
+
+	// Sprite animation and lights
+	g.animationSystem.Update(dt)
+	g.lightingSystem.Update(dt)
 
 	// Update particle system
+	g.emitWeatherParticles(dt)
 	g.particleSystem.Update(dt)
 
 	// AI and wave management
@@ -632,12 +1189,262 @@ func (g *Game) updateGameplay(dt float64) {
 		g.waveManager.StartNextWave()
 	}
 
-	// Update HUD
-	health := 0.0
+	g.updateHUD(dt)
+}
+
+// updateHUD feeds the HUD the player's vitals and weapons, the boss and
+// off-screen enemies, then advances its animations.
+func (g *Game) updateHUD(dt float64) {
+	health, maxHealth := 0.0, DefaultPlayerHealth
 	if h, ok := g.world.GetComponent(g.playerEntity, "health"); ok {
-		health = h.(*combat.Health).Current
+		health, maxHealth = h.(*combat.Health).Current, h.(*combat.Health).Max
+	}
+	// The shield bar only shows while a shield bubble is up
+	shield, maxShield := 0.0, 0.0
+	if pu := g.playerPowerUps(); pu != nil {
+		if s, ok := pu.Get(combat.PowerUpShield); ok {
+			shield, maxShield = s.ShieldHP, combat.ShieldCapacity
+		}
+	}
+	g.hud.SetLimits(maxHealth, maxShield)
+	g.hud.Update(health, shield, g.score, g.waveManager.CurrentWave(), g.combo)
+	g.hud.SetComboTimer(g.comboTimer, ComboTimerDuration)
+	g.hud.SetWeapons(g.weaponStatus())
+	g.hud.SetBoss(g.bossStatus())
+	g.hud.SetStatus(g.hudStatus())
+	view := image.Rect(int(g.camera.X), int(g.camera.Y),
+		int(g.camera.X)+g.cfg.Display.Width, int(g.camera.Y)+g.cfg.Display.Height)
+	g.hud.TrackTargets(view, g.indicatorTargets())
+	g.updateObjectiveHUD()
+	g.hud.Tick(dt)
+}
+
+// weaponStatus describes the player's weapon slots for the HUD. Weapons
+// have no ammo limit.
+func (g *Game) weaponStatus() []ux.WeaponStatus {
+	comp, ok := g.world.GetComponent(g.playerEntity, "weapon")
+	if !ok {
+		return nil
+	}
+	weapons := comp.(*combat.WeaponComponent)
+	var slots []ux.WeaponStatus
+	for _, w := range []*combat.Weapon{weapons.Primary, weapons.Secondary} {
+		if w != nil {
+			slots = append(slots, ux.WeaponStatus{Name: w.Type.String(), Cooldown: w.CooldownFraction(), Ammo: -1})
+		}
+	}
+	return slots
+}
+
+// bossStatus describes the marked assassination target for the boss bar.
+func (g *Game) bossStatus() ux.BossStatus {
+	target, ok := g.objectiveSystem.Target()
+	if !ok {
+		return ux.BossStatus{}
+	}
+	h, ok := g.world.GetComponent(target, "health")
+	if !ok {
+		return ux.BossStatus{}
+	}
+	health := h.(*combat.Health)
+	return ux.BossStatus{Active: true, Name: BossBarName, Health: health.Current, MaxHealth: health.Max}
+}
+
+// hudStatus returns the HUD status line: the active weather and the arena
+// mode, when either is notable.
+func (g *Game) hudStatus() string {
+	var parts []string
+	if weather := g.weatherSystem.Current(); weather.Active {
+		parts = append(parts, weather.Name)
+	}
+	if name, ok := arenaModeNames[g.arenaSystem.Mode()]; ok {
+		parts = append(parts, name)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// indicatorTargets lists the enemies the HUD points at when off screen,
+// with the marked target flagged as the boss.
+func (g *Game) indicatorTargets() []ux.IndicatorTarget {
+	boss, hasBoss := g.objectiveSystem.Target()
+	var targets []ux.IndicatorTarget
+	g.world.ForEachEntity(func(e engine.Entity) {
+		tag, ok := g.world.GetComponent(e, "collisiontag")
+		if !ok || tag.(*combat.CollisionTag).Tag != "enemy" {
+			return
+		}
+		posComp, ok := g.world.GetComponent(e, "position")
+		if !ok {
+			return
+		}
+		pos := posComp.(*engine.Position)
+		kind := ux.IndicatorEnemy
+		if hasBoss && e == boss {
+			kind = ux.IndicatorBoss
+		}
+		targets = append(targets, ux.IndicatorTarget{X: pos.X, Y: pos.Y, Kind: kind})
+	})
+	return targets
+}
+
+// updateCamera follows the player, or tracks the stage in scroll mode, and
+// moves spawn and weather areas with the view.
+func (g *Game) updateCamera(dt float64) {
+	posComp, hasPos := g.world.GetComponent(g.playerEntity, "position")
+	velComp, hasVel := g.world.GetComponent(g.playerEntity, "velocity")
+	if hasPos && hasVel {
+		pos := posComp.(*engine.Position)
+		vel := velComp.(*engine.Velocity)
+		g.camera.Follow(pos.X, pos.Y, vel.VX, vel.VY, dt)
+	}
+	if engine.ArenaMode(g.cfg.Gameplay.ArenaMode) == engine.ArenaModeScroll {
+		g.camera.Y = g.arenaSystem.ScrollOffset()
+	}
+	g.syncView()
+}
+
+// syncView points view-relative systems at the camera's current view.
+func (g *Game) syncView() {
+	g.waveSpawner.SetViewOrigin(g.camera.X, g.camera.Y)
+	g.weatherSystem.SetView(g.camera.X, g.camera.Y, g.cfg.Display.Width, g.cfg.Display.Height)
+}
+
+// updatePlayerAnimation shows engine thrust while the thrust key is held.
+func (g *Game) updatePlayerAnimation() {
+	animComp, ok := g.world.GetComponent(g.playerEntity, "animation")
+	if !ok {
+		return
+	}
+	thrust := g.inputSystem.GetState().Thrust
+	if thrust {
+		animComp.(*rendering.Animation).Play(rendering.AnimThrust)
+	} else {
+		animComp.(*rendering.Animation).Play(rendering.AnimIdle)
+	}
+
+	// The engine flares while thrusting
+	if lightComp, ok := g.world.GetComponent(g.playerEntity, "light"); ok {
+		light := lightComp.(*rendering.Light)
+		light.Intensity = PlayerLightIdle
+		if thrust {
+			light.Intensity = PlayerLightThrust
+		}
+	}
+}
+
+// updateEngineTrail keeps the exhaust emitter behind the player's engine,
+// running only while thrusting.
+func (g *Game) updateEngineTrail() {
+	g.engineTrail.Active = false
+	posComp, hasPos := g.world.GetComponent(g.playerEntity, "position")
+	rotComp, hasRot := g.world.GetComponent(g.playerEntity, "rotation")
+	if !hasPos || !hasRot || !g.inputSystem.GetState().Thrust {
+		return
+	}
+	pos := posComp.(*engine.Position)
+	back := rotComp.(*engine.Rotation).Angle + math.Pi
+	g.engineTrail.X = pos.X + math.Cos(back)*EngineTrailOffset
+	g.engineTrail.Y = pos.Y + math.Sin(back)*EngineTrailOffset
+	g.engineTrail.Angle = back
+	g.engineTrail.Active = true
+}
+
+// onObjectiveSpawn attaches sprites to objective entities, tinting convoys as
+// friendly and marked targets as elite.
+func (g *Game) onObjectiveSpawn(e engine.Entity, role world.ObjectiveRole) {
+	switch role {
+	case world.ObjectiveRoleConvoy:
+		g.world.AddComponent(e, "sprite", &rendering.SpriteComponent{
+			Type:    rendering.SpriteTypeShip,
+			Variant: int(rendering.HullCarrier),
+			Size:    world.ConvoySize,
+		})
+		g.world.AddComponent(e, "animation", rendering.NewAnimation())
+		// Convoys fly a carrier hull, tinted as friendly
+		convoy := rendering.NewRenderable()
+		convoy.Tint = rendering.TintFriendly
+		g.world.AddComponent(e, "renderable", convoy)
+	case world.ObjectiveRoleTarget:
+		// Targets keep their own enemy sprite, enlarged and tinted as elite
+		target := rendering.NewRenderable()
+		target.Scale = ObjectiveTargetScale
+		target.Tint = rendering.TintElite
+		g.world.AddComponent(e, "renderable", target)
+	case world.ObjectiveRoleCollectible:
+		g.world.AddComponent(e, "sprite", &rendering.SpriteComponent{
+			Type:    rendering.SpriteTypePickup,
+			Variant: CollectibleSpriteVariant,
+			Size:    world.CollectibleSize,
+		})
+	}
+}
+
+// updateObjectiveHUD mirrors the current wave objective onto the HUD.
+func (g *Game) updateObjectiveHUD() {
+	o := g.waveManager.CurrentObjective()
+	if o == nil {
+		g.hud.SetObjective(ux.ObjectiveStatus{})
+		return
+	}
+	status := ux.ObjectiveStatus{
+		Description: o.Description,
+		Progress:    o.Fraction(),
+		Completed:   o.Completed,
+		Failed:      o.Failed,
+	}
+	switch o.Type {
+	case world.ObjectiveSurvive:
+		status.Remaining = math.Max(o.Goal-o.Elapsed, 0)
+	case world.ObjectiveAssassinate:
+		status.Remaining = math.Max(o.TimeLimit-o.Elapsed, 0)
+	}
+	g.hud.SetObjective(status)
+}
+
+// emitWeatherParticles feeds the active weather's particles to the particle system.
+func (g *Game) emitWeatherParticles(dt float64) {
+	for _, p := range g.weatherSystem.Particles(dt) {
+		g.particleSystem.EmitDirectionalColored(p.X, p.Y, p.Angle, WeatherParticleSpread, 1, p.Color)
+	}
+}
+
+// updatePowerUpEffects applies time slow, emits aura particles and refreshes HUD timers.
+func (g *Game) updatePowerUpEffects(dt float64) {
+	pu := g.playerPowerUps()
+	if pu == nil {
+		g.enemyAISystem.SetTimeScale(1.0)
+		g.hud.SetPowerUps(nil)
+		return
+	}
+
+	g.enemyAISystem.SetTimeScale(pu.TimeScale())
+
+	timers := make([]ux.PowerUpTimer, 0, len(pu.Active))
+	for _, p := range pu.Active {
+		def := combat.GetPowerUpDef(p.Type)
+		timers = append(timers, ux.PowerUpTimer{
+			Name:      def.Name,
+			Remaining: p.Remaining,
+			Duration:  def.MaxDuration, // Extended power-ups can outlast Duration
+			Stacks:    p.Stacks,
+		})
+	}
+	g.hud.SetPowerUps(timers)
+
+	g.auraTimer -= dt
+	if g.auraTimer > 0 || len(pu.Active) == 0 {
+		return
+	}
+	g.auraTimer = PowerUpAuraInterval
+
+	pos, ok := g.world.GetComponent(g.playerEntity, "position")
+	if !ok {
+		return
+	}
+	p := pos.(*engine.Position)
+	for _, active := range pu.Active {
+		g.particleSystem.EmitColored(p.X, p.Y, PowerUpAuraParticles, combat.GetPowerUpDef(active.Type).AuraColor)
 	}
-	g.hud.Update(health, 0, g.score, g.waveManager.CurrentWave(), g.combo)
 }
 
 // updateTutorialActions checks player input and marks tutorial progress.
@@ -662,13 +1469,27 @@ func (g *Game) updateTutorialActions() {
 
 // Draw renders the current frame.
 func (g *Game) Draw(screen *ebiten.Image) {
+	start := time.Now()
+
+	// The scene is drawn offscreen when post-processing so the HUD stays crisp
+	scene := screen
+	if g.postShader != nil {
+		scene = g.sceneTarget(screen)
+	}
+
 	// Background color based on genre
 	bgColor := g.getBackgroundColor()
-	screen.Fill(bgColor)
+	scene.Fill(bgColor)
+	g.drawCalls.Add(1)
+	g.drawBackground(scene)
 
 	// Draw gameplay elements if playing or paused
 	if g.stateManager.IsPlaying() || g.stateManager.IsPaused() {
-		g.drawGameplay(screen)
+		g.drawGameplay(scene)
+	}
+
+	if scene != screen {
+		g.drawPostFX(screen, scene)
 	}
 
 	// Draw HUD if playing
@@ -686,8 +1507,89 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		g.drawMenu(screen)
 	}
 
-	// Display FPS and version
-	ebitenutil.DebugPrint(screen, fmt.Sprintf("Velocity %s | FPS: %.0f", version.GetVersion(), ebiten.ActualFPS()))
+	// Display FPS, version and draw calls with the peak over the audit
+	// windows; the completed window's peak holds while the next one fills
+	g.drawCalls.Add(1)
+	draws := g.drawCalls.EndFrame()
+	if len(g.auditLog.Samples()) >= AuditWindowFrames {
+		g.drawPeak = g.auditLog.MaxDrawCalls()
+		g.auditLog.Reset()
+	}
+	g.auditLog.RecordFrame(float64(time.Since(start).Microseconds())/1000, g.world.EntityCount(), draws)
+	ebitenutil.DebugPrint(screen, fmt.Sprintf("Velocity %s | FPS: %.0f | Draws: %d (peak %d)",
+		version.GetVersion(), ebiten.ActualFPS(), draws, max(g.drawPeak, g.auditLog.MaxDrawCalls())))
+}
+
+// initPostFX compiles the post-processing shader for the genre's preset.
+// Without shader support the scene is drawn unprocessed.
+func (g *Game) initPostFX() {
+	g.postFX = rendering.NewPostFX(genre.GetPreset(g.cfg.Gameplay.Genre))
+	if !g.cfg.Effects.PostProcess || !g.postFX.Enabled() {
+		return
+	}
+	shader, err := ebiten.NewShader(rendering.PostFXShaderSource)
+	if err != nil {
+		log.Printf("Warning: post-processing disabled: %v", err)
+		return
+	}
+	g.postShader = shader
+}
+
+// sceneTarget returns the offscreen scene image, resizing it to match the screen.
+func (g *Game) sceneTarget(screen *ebiten.Image) *ebiten.Image {
+	size := screen.Bounds().Size()
+	if g.sceneImage == nil || g.sceneImage.Bounds().Size() != size {
+		if g.sceneImage != nil {
+			g.sceneImage.Deallocate()
+		}
+		g.sceneImage = ebiten.NewImage(size.X, size.Y)
+	}
+	return g.sceneImage
+}
+
+// drawPostFX draws the scene to the screen through the post-processing shader.
+func (g *Game) drawPostFX(screen, scene *ebiten.Image) {
+	g.postFrame++
+	size := scene.Bounds().Size()
+	opts := &ebiten.DrawRectShaderOptions{Uniforms: g.postFX.Uniforms(g.postFrame)}
+	opts.Images[0] = scene
+	screen.DrawRectShader(size.X, size.Y, g.postShader, opts)
+	g.drawCalls.Add(1)
+}
+
+// drawBackground tiles the genre's parallax layers across the screen, each
+// scrolling at its own fraction of the camera's movement.
+func (g *Game) drawBackground(screen *ebiten.Image) {
+	size := screen.Bounds().Size()
+	layers := g.renderer.GetOrCreateBackground(size.X, size.Y)
+	if len(layers) == 0 {
+		return
+	}
+	if layers[0].Image != g.backgroundSource {
+		for _, img := range g.backgroundImages {
+			img.Deallocate()
+		}
+		g.backgroundImages = g.backgroundImages[:0]
+		for _, l := range layers {
+			g.backgroundImages = append(g.backgroundImages, ebiten.NewImageFromImage(l.Image))
+		}
+		g.backgroundSource = layers[0].Image
+	}
+
+	for i, l := range layers {
+		ox, oy := l.Offset(g.camera.X, g.camera.Y)
+		for y := oy; y < float64(size.Y); y += float64(size.Y) {
+			for x := ox; x < float64(size.X); x += float64(size.X) {
+				opts := &ebiten.DrawImageOptions{}
+				opts.GeoM.Translate(x, y)
+				screen.DrawImage(g.backgroundImages[i], opts)
+				g.drawCalls.Add(1)
+			}
+			if !l.RepeatY {
+				break
+			}
+		}
+	}
 }
 
 // getBackgroundColor returns the background color for the current genre.
@@ -708,146 +1610,435 @@ func (g *Game) getBackgroundColor() color.RGBA {
 
 // drawGameplay renders all game entities using procedural sprites.
 func (g *Game) drawGameplay(screen *ebiten.Image) {
+	// Shake is applied at draw time so it never disturbs the simulation
+	g.shakeX, g.shakeY = g.camera.ShakeOffset()
+
 	// Set up viewport for culling
 	viewport := rendering.NewViewport(g.cfg.Display.Width, g.cfg.Display.Height)
+	viewport.FollowCamera(g.camera)
 	cullContext := rendering.NewCullContext(viewport, ViewportCullMargin)
 
-	// Create draw batches for efficient rendering
-	batches := rendering.CreateDrawBatches(g.world)
-	batches = rendering.SortBatchesByRenderOrder(batches)
+	// Only entities near the view are looked at when drawing; the grid
+	// refiles just the entities that changed since the last frame
+	g.spatialGrid.Sync(g.world)
+	g.visible = g.spatialGrid.Visible(cullContext, g.visible[:0])
+
+	g.drawArenaOverlay(screen)
 
-	// Render each batch
-	for _, batch := range batches {
-		for _, e := range batch.Entities {
-			g.drawEntity(screen, e, cullContext)
+	// Keep sprites and bare projectiles, in layer order
+	drawable := g.visible[:0]
+	for _, e := range g.visible {
+		_, hasSprite := g.world.GetComponent(e, "sprite")
+		_, hasProjectile := g.world.GetComponent(e, "projectile")
+		if hasSprite || hasProjectile {
+			drawable = append(drawable, e)
 		}
 	}
+	rendering.DefaultLayers.Sort(g.world, drawable)
+	split := rendering.DefaultLayers.SplitAt(g.world, drawable, rendering.LayerParticles)
+
+	// Layers below the particles are lit with the scene; layers from the
+	// particles up draw over the lighting
+	g.drawSprites(screen, drawable[:split])
+	if g.cfg.Effects.Lighting {
+		g.drawLighting(screen, cullContext)
+	}
+	g.drawParticles(screen)
+	g.drawShockwaves(screen)
+	g.drawSprites(screen, drawable[split:])
 
-	// Also render entities without sprite components (e.g., projectiles with projectile tag only)
-	g.world.ForEachEntity(func(e engine.Entity) {
-		// Skip entities that were already rendered via batches
-		if _, hasSprite := g.world.GetComponent(e, "sprite"); hasSprite {
-			return
+	g.drawMinimap(screen, viewport)
+}
+
+// multiplyBlend multiplies the destination by the source color, used to
+// darken the scene with the lightmap.
+var multiplyBlend = ebiten.Blend{
+	BlendFactorSourceRGB:        ebiten.BlendFactorDestinationColor,
+	BlendFactorSourceAlpha:      ebiten.BlendFactorZero,
+	BlendFactorDestinationRGB:   ebiten.BlendFactorZero,
+	BlendFactorDestinationAlpha: ebiten.BlendFactorOne,
+	BlendOperationRGB:           ebiten.BlendOperationAdd,
+	BlendOperationAlpha:         ebiten.BlendOperationAdd,
+}
+
+// drawLighting darkens the scene to the ambient light level everywhere but
+// around light sources, then adds a soft glow over each light.
+func (g *Game) drawLighting(screen *ebiten.Image, cullContext *rendering.CullContext) {
+	if g.lightTexture == nil {
+		g.lightTexture = ebiten.NewImageFromImage(rendering.GenerateLightTexture(rendering.LightTextureSize))
+	}
+	size := screen.Bounds().Size()
+	if g.lightmap == nil || g.lightmap.Bounds().Size() != size {
+		if g.lightmap != nil {
+			g.lightmap.Deallocate()
 		}
-		// Only render projectiles that don't have sprite components
-		if _, hasProjectile := g.world.GetComponent(e, "projectile"); hasProjectile {
-			g.drawEntity(screen, e, cullContext)
+		g.lightmap = ebiten.NewImage(size.X, size.Y)
+	}
+
+	var visible []rendering.LightSource
+	for _, s := range g.lightingSystem.Sources() {
+		if cullContext.ShouldRender(s.X, s.Y, s.Radius*2, s.Radius*2) {
+			visible = append(visible, s)
 		}
-	})
+	}
 
-	// Render particles
-	g.drawParticles(screen)
+	g.lightmap.Fill(g.lightingSystem.Ambient())
+	for _, s := range visible {
+		g.lightmap.DrawImage(g.lightTexture, g.lightOptions(s, s.Intensity))
+	}
+	screen.DrawImage(g.lightmap, &ebiten.DrawImageOptions{Blend: multiplyBlend})
+
+	for _, s := range visible {
+		screen.DrawImage(g.lightTexture, g.lightOptions(s, s.Intensity*rendering.LightGlowStrength))
+	}
+	g.drawCalls.Add(2*len(visible) + 2) // Lights, glows, the lightmap fill and composite
 }
 
-// drawParticles renders all active particles.
-func (g *Game) drawParticles(screen *ebiten.Image) {
-	particles := g.particleSystem.GetParticles()
-	for _, p := range particles {
-		col := computeParticleColor(p)
-		size := clampParticleSize(int(p.Size))
-		g.renderParticlePixels(screen, p.X, p.Y, size, col)
+// lightOptions positions and colors the light texture for a light source.
+func (g *Game) lightOptions(s rendering.LightSource, intensity float64) *ebiten.DrawImageOptions {
+	opts := &ebiten.DrawImageOptions{Blend: ebiten.BlendLighter}
+	half := float64(rendering.LightTextureSize) / 2
+	opts.GeoM.Translate(-half, -half)
+	opts.GeoM.Scale(s.Radius/half, s.Radius/half)
+	opts.GeoM.Translate(g.toScreen(s.X, s.Y))
+	v := float32(intensity)
+	opts.ColorScale.Scale(float32(s.Color.R)/255*v, float32(s.Color.G)/255*v, float32(s.Color.B)/255*v, v)
+	return opts
+}
+
+// toScreen converts world coordinates to screen coordinates, including camera shake.
+func (g *Game) toScreen(x, y float64) (float64, float64) {
+	sx, sy := g.camera.WorldToScreen(x, y)
+	return sx + g.shakeX, sy + g.shakeY
+}
+
+// drawShockwaves renders expanding rings with red and blue fringes offset to
+// either side for a chromatic aberration look.
+func (g *Game) drawShockwaves(screen *ebiten.Image) {
+	for _, s := range g.effectsSystem.Shockwaves() {
+		x, y := g.toScreen(s.X, s.Y)
+		alpha := uint8(s.Alpha() * 255)
+		r := float32(s.Radius)
+		fringe := float32(ShockwaveChromaticOffset)
+		vector.StrokeCircle(screen, float32(x)-fringe, float32(y), r, ArenaOverlayStroke, color.RGBA{R: alpha, A: alpha}, true)
+		vector.StrokeCircle(screen, float32(x)+fringe, float32(y), r, ArenaOverlayStroke, color.RGBA{B: alpha, A: alpha}, true)
+		c := s.Color
+		c.R = uint8(uint16(c.R) * uint16(alpha) / 255)
+		c.G = uint8(uint16(c.G) * uint16(alpha) / 255)
+		c.B = uint8(uint16(c.B) * uint16(alpha) / 255)
+		c.A = alpha
+		vector.StrokeCircle(screen, float32(x), float32(y), r, ArenaOverlayStroke, c, true)
+		g.drawCalls.Add(3)
 	}
 }
 
-// computeParticleColor applies alpha fade based on remaining life.
-func computeParticleColor(p rendering.Particle) color.Color {
-	alpha := float32(p.Life / p.MaxLife)
-	if alpha < 0 {
-		alpha = 0
+// drawArenaOverlay draws the safe zone ring, portals and electrified walls.
+func (g *Game) drawArenaOverlay(screen *ebiten.Image) {
+	worldWidth, worldHeight := g.cfg.WorldSize()
+	w, h := float64(worldWidth), float64(worldHeight)
+
+	switch g.arenaSystem.Mode() {
+	case engine.ArenaModeRing:
+		cx, cy, radius, _ := g.arenaSystem.Ring()
+		sx, sy := g.toScreen(cx, cy)
+		vector.StrokeCircle(screen, float32(sx), float32(sy), float32(radius), ArenaOverlayStroke, ringColor, true)
+		g.drawCalls.Add(1)
+	case engine.ArenaModePortal:
+		for _, pair := range g.arenaSystem.Portals() {
+			g.drawPortal(screen, pair.A, w, h)
+			g.drawPortal(screen, pair.B, w, h)
+		}
+	case engine.ArenaModeElectric:
+		x, y := g.toScreen(0, 0)
+		vector.StrokeRect(screen, float32(x), float32(y), float32(w), float32(h), ArenaOverlayStroke, electricColor, false)
+		g.drawCalls.Add(1)
 	}
-	col := p.Color
-	col.A = uint8(float32(col.A) * alpha)
-	return col
 }
 
-// clampParticleSize ensures minimum particle size of 1 pixel.
-func clampParticleSize(size int) int {
-	if size < 1 {
-		return 1
+// drawPortal draws a portal opening along its edge.
+func (g *Game) drawPortal(screen *ebiten.Image, p engine.Portal, w, h float64) {
+	var x0, y0, x1, y1 float64
+	switch p.Edge {
+	case engine.EdgeTop, engine.EdgeBottom:
+		y := 0.0
+		if p.Edge == engine.EdgeBottom {
+			y = h - 1
+		}
+		x0, y0, x1, y1 = p.Offset-p.Span/2, y, p.Offset+p.Span/2, y
+	default:
+		x := 0.0
+		if p.Edge == engine.EdgeRight {
+			x = w - 1
+		}
+		x0, y0, x1, y1 = x, p.Offset-p.Span/2, x, p.Offset+p.Span/2
 	}
-	return size
+	x0, y0 = g.toScreen(x0, y0)
+	x1, y1 = g.toScreen(x1, y1)
+	vector.StrokeLine(screen, float32(x0), float32(y0), float32(x1), float32(y1), ArenaOverlayStroke*2, portalColor, false)
+	g.drawCalls.Add(1)
 }
 
-// renderParticlePixels draws a filled square particle at the given position.
-func (g *Game) renderParticlePixels(screen *ebiten.Image, px, py float64, size int, col color.Color) {
-	halfSize := size / 2
-	for dy := 0; dy < size; dy++ {
-		for dx := 0; dx < size; dx++ {
-			x := int(px) + dx - halfSize
-			y := int(py) + dy - halfSize
-			if g.isWithinScreen(x, y) {
-				screen.Set(x, y, col)
+// drawMinimap renders the world overview in the top-right corner when the
+// world is larger than the screen.
+func (g *Game) drawMinimap(screen *ebiten.Image, viewport *rendering.Viewport) {
+	if g.minimap == nil {
+		return
+	}
+
+	var blips []rendering.MinimapBlip
+	g.world.ForEachEntity(func(e engine.Entity) {
+		posComp, ok := g.world.GetComponent(e, "position")
+		if !ok {
+			return
+		}
+		pos := posComp.(*engine.Position)
+		if _, ok := g.world.GetComponent(e, "collectible"); ok {
+			blips = append(blips, rendering.MinimapBlip{X: pos.X, Y: pos.Y, Color: minimapCollectibleColor})
+			return
+		}
+		if tag, ok := g.world.GetComponent(e, "collisiontag"); ok {
+			if c, ok := minimapColors[tag.(*combat.CollisionTag).Tag]; ok {
+				blips = append(blips, rendering.MinimapBlip{X: pos.X, Y: pos.Y, Color: c})
 			}
 		}
+	})
+
+	g.minimapImage.WritePixels(g.minimap.Render(blips, viewport).Pix)
+	opts := &ebiten.DrawImageOptions{}
+	opts.GeoM.Translate(float64(g.cfg.Display.Width-g.minimap.Width-MinimapMargin), MinimapMargin)
+	screen.DrawImage(g.minimapImage, opts)
+	g.drawCalls.Add(1)
+}
+
+// initParticleEffects builds the genre-colored explosion presets and the
+// player's engine trail emitter.
+func (g *Game) initParticleEffects() {
+	c := g.particleSystem.GenreColor()
+	g.explosionFX = rendering.ExplosionEmitter(c)
+	g.sparkFX = rendering.SparkEmitter(c)
+	g.smokeFX = rendering.SmokeEmitter()
+	g.engineTrail = g.particleSystem.AddEmitter(rendering.EngineTrailEmitter(playerLightColor), 0, 0)
+	g.engineTrail.Active = false
+}
+
+// particleBatch holds reusable vertex and index buffers for drawing every
+// particle as a quad, split by blend mode.
+type particleBatch struct {
+	white                   *ebiten.Image
+	normalVerts, lightVerts []ebiten.Vertex
+	normalIdx, lightIdx     []uint32
+}
+
+// drawParticles renders all active particles in at most two draw calls, one
+// for normally blended particles and one for additive ones.
+func (g *Game) drawParticles(screen *ebiten.Image) {
+	b := &g.particleBatch
+	if b.white == nil {
+		b.white = ebiten.NewImage(3, 3)
+		b.white.Fill(color.White)
+	}
+	b.normalVerts, b.normalIdx = b.normalVerts[:0], b.normalIdx[:0]
+	b.lightVerts, b.lightIdx = b.lightVerts[:0], b.lightIdx[:0]
+
+	w, h := float64(g.cfg.Display.Width), float64(g.cfg.Display.Height)
+	g.particleSystem.Visit(func(p *rendering.Particle) {
+		col, size := p.Appearance()
+		if col.A == 0 {
+			return
+		}
+		size = math.Max(size, 1)
+		sx, sy := g.toScreen(p.X, p.Y)
+		if sx+size < 0 || sy+size < 0 || sx-size > w || sy-size > h {
+			return
+		}
+		if p.Additive() {
+			b.lightVerts, b.lightIdx = appendParticleQuad(b.lightVerts, b.lightIdx, sx, sy, size, col)
+		} else {
+			b.normalVerts, b.normalIdx = appendParticleQuad(b.normalVerts, b.normalIdx, sx, sy, size, col)
+		}
+	})
+
+	src := b.white.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+	screen.DrawTriangles32(b.normalVerts, b.normalIdx, src, &ebiten.DrawTrianglesOptions{})
+	screen.DrawTriangles32(b.lightVerts, b.lightIdx, src, &ebiten.DrawTrianglesOptions{Blend: ebiten.BlendLighter})
+	g.drawCalls.Add(2)
+}
+
+// appendParticleQuad appends a square of the given size centered on (x, y).
+func appendParticleQuad(verts []ebiten.Vertex, idx []uint32, x, y, size float64, c color.RGBA) ([]ebiten.Vertex, []uint32) {
+	half := float32(size / 2)
+	cx, cy := float32(x), float32(y)
+	r, gr, bl, a := float32(c.R)/255, float32(c.G)/255, float32(c.B)/255, float32(c.A)/255
+	base := uint32(len(verts))
+	for _, corner := range [4][2]float32{{-1, -1}, {1, -1}, {-1, 1}, {1, 1}} {
+		verts = append(verts, ebiten.Vertex{
+			DstX: cx + corner[0]*half, DstY: cy + corner[1]*half,
+			SrcX: 1, SrcY: 1,
+			ColorR: r, ColorG: gr, ColorB: bl, ColorA: a,
+		})
 	}
+	idx = append(idx, base, base+1, base+2, base+1, base+3, base+2)
+	return verts, idx
 }
 
-// isWithinScreen checks if coordinates are within screen bounds.
-func (g *Game) isWithinScreen(x, y int) bool {
-	return x >= 0 && x < g.cfg.Display.Width && y >= 0 && y < g.cfg.Display.Height
+// drawSprites draws entities in the given order from the sprite atlas, in
+// as few calls as there are page switches, then their hit flashes.
+func (g *Game) drawSprites(screen *ebiten.Image, entities []engine.Entity) {
+	if len(entities) == 0 {
+		return
+	}
+	g.spriteBatch.Reset()
+	g.spriteFlashes = g.spriteFlashes[:0]
+	for _, e := range entities {
+		g.batchEntity(e)
+	}
+	g.drawCalls.Add(g.spriteAtlas.Flush(screen, &g.spriteBatch, ebiten.BlendSourceOver))
+	g.drawSpriteFlashes(screen)
+}
+
+// spriteFlash is a queued white hit flash over a sprite.
+type spriteFlash struct {
+	region   rendering.AtlasRegion
+	geom     ebiten.GeoM
+	strength float64
 }
 
-// drawEntity renders a single entity with its sprite and rotation.
-func (g *Game) drawEntity(screen *ebiten.Image, e engine.Entity, cullContext *rendering.CullContext) {
+// batchEntity queues a single visible entity's sprite, with its scale,
+// rotation, tint and fog alpha, into the frame's sprite batch.
+func (g *Game) batchEntity(e engine.Entity) {
 	posComp, hasPos := g.world.GetComponent(e, "position")
 	if !hasPos {
 		return
 	}
 	pos := posComp.(*engine.Position)
+	r := g.entityRenderable(e)
 
-	// Determine sprite size (default to PlayerSpriteSizePx for entities without sprite component)
-	spriteSize := PlayerSpriteSizePx
-	if spriteComp, hasSprite := g.world.GetComponent(e, "sprite"); hasSprite {
-		spriteSize = spriteComp.(*rendering.SpriteComponent).Size
+	// Nebula fog fades out anything near the edge of the player's visibility radius
+	alpha := r.Alpha * g.fogAlpha(e, pos)
+	if alpha <= 0 {
+		return
 	}
 
-	// Apply viewport culling
-	if !cullContext.ShouldRender(pos.X, pos.Y, float64(spriteSize), float64(spriteSize)) {
+	// Get or pack the sprite into the atlas
+	region, ok := g.spriteRegion(e)
+	if !ok {
 		return
 	}
 
-	// Get rotation if available
-	var angle float64
-	if rotComp, hasRot := g.world.GetComponent(e, "rotation"); hasRot {
-		angle = rotComp.(*engine.Rotation).Angle
+	angle := rendering.DrawAngle(g.entityAngle(e, r))
+	sx, sy := g.toScreen(pos.X, pos.Y)
+	cr, cg, cb, ca := rendering.ColorScale(g.entityTint(e, r.Tint), alpha)
+	g.spriteBatch.Add(region, sx, sy, r.Scale, angle, cr, cg, cb, ca)
+
+	// Recently damaged entities flash white
+	if flash := g.effectsSystem.FlashIntensity(e); flash > 0 {
+		var geom ebiten.GeoM
+		geom.Translate(-float64(region.Rect.Dx())/2, -float64(region.Rect.Dy())/2)
+		geom.Scale(r.Scale, r.Scale)
+		geom.Rotate(angle)
+		geom.Translate(sx, sy)
+		g.spriteFlashes = append(g.spriteFlashes, spriteFlash{region: region, geom: geom, strength: flash * alpha})
 	}
+}
 
-	// Get or generate the sprite image
-	img := g.getSpriteImage(e, spriteSize)
-	if img == nil {
-		return
+// drawSpriteFlashes draws the queued hit flashes over the batched sprites.
+func (g *Game) drawSpriteFlashes(screen *ebiten.Image) {
+	for _, f := range g.spriteFlashes {
+		var cm colorm.ColorM
+		cm.Scale(0, 0, 0, f.strength)
+		cm.Translate(1, 1, 1, 0)
+		colorm.DrawImage(screen, g.spriteAtlas.Image(f.region), cm, &colorm.DrawImageOptions{GeoM: f.geom})
 	}
+	g.drawCalls.Add(len(g.spriteFlashes))
+}
 
-	// Set up draw options with rotation and position
-	opts := &ebiten.DrawImageOptions{}
-
-	// Center the sprite for rotation
-	halfSize := float64(spriteSize) / 2
-	opts.GeoM.Translate(-halfSize, -halfSize)
-	opts.GeoM.Rotate(angle)
-	opts.GeoM.Translate(pos.X, pos.Y)
+// entityRenderable returns an entity's draw modifiers. Projectiles default to
+// facing their direction of travel.
+func (g *Game) entityRenderable(e engine.Entity) *rendering.Renderable {
+	if comp, ok := g.world.GetComponent(e, "renderable"); ok {
+		return comp.(*rendering.Renderable)
+	}
+	r := rendering.NewRenderable()
+	if _, hasProjectile := g.world.GetComponent(e, "projectile"); hasProjectile {
+		r.FaceVelocity = true
+	}
+	return r
+}
 
-	screen.DrawImage(img, opts)
+// entityAngle returns the facing of an entity, using its velocity when it has
+// no rotation and faces its direction of travel.
+func (g *Game) entityAngle(e engine.Entity, r *rendering.Renderable) float64 {
+	if rotComp, hasRot := g.world.GetComponent(e, "rotation"); hasRot {
+		return rotComp.(*engine.Rotation).Angle
+	}
+	if r.FaceVelocity {
+		if velComp, hasVel := g.world.GetComponent(e, "velocity"); hasVel {
+			vel := velComp.(*engine.Velocity)
+			if vel.VX != 0 || vel.VY != 0 {
+				return math.Atan2(vel.VY, vel.VX)
+			}
+		}
+	}
+	return rendering.SpriteForwardAngle
 }
 
-// getSpriteImage returns the ebiten.Image for an entity, generating and caching it if needed.
-func (g *Game) getSpriteImage(e engine.Entity, defaultSize int) *ebiten.Image {
-	cacheKey, rgbaImg := g.resolveEntitySprite(e)
-	if cacheKey == "" {
-		return nil
+// entityTint layers status effect tints over an entity's base tint.
+func (g *Game) entityTint(e engine.Entity, base color.RGBA) color.RGBA {
+	pu := g.playerPowerUps()
+	if pu == nil {
+		return base
+	}
+	if e == g.playerEntity {
+		if pu.Has(combat.PowerUpInvulnerability) {
+			return rendering.BlendTint(base, rendering.TintElite, StatusTintStrength)
+		}
+		if pu.Has(combat.PowerUpShield) {
+			return rendering.BlendTint(base, shieldTint, StatusTintStrength)
+		}
+		return base
+	}
+	if pu.Has(combat.PowerUpTimeSlow) {
+		if _, isEnemy := g.world.GetComponent(e, "enemy"); isEnemy {
+			return rendering.BlendTint(base, rendering.TintSlowed, StatusTintStrength)
+		}
 	}
+	return base
+}
 
-	if img, ok := g.ebitenImageCache[cacheKey]; ok {
-		return img
+// fogAlpha returns the opacity fog weather leaves an entity at: 1 well inside
+// the player's visibility radius, fading to 0 across FogFadeBand at its edge.
+func (g *Game) fogAlpha(e engine.Entity, pos *engine.Position) float64 {
+	radius, limited := g.weatherSystem.VisibilityRadius()
+	if !limited || e == g.playerEntity {
+		return 1
 	}
+	playerComp, ok := g.world.GetComponent(g.playerEntity, "position")
+	if !ok {
+		return 1
+	}
+	player := playerComp.(*engine.Position)
+	dist := math.Hypot(pos.X-player.X, pos.Y-player.Y)
+	return math.Max(0, math.Min((radius-dist)/FogFadeBand, 1))
+}
 
-	return g.cacheConvertedSprite(cacheKey, rgbaImg)
+// spriteRegion returns the atlas region of an entity's sprite, generating
+// and packing it if needed.
+func (g *Game) spriteRegion(e engine.Entity) (rendering.AtlasRegion, bool) {
+	cacheKey, rgbaImg := g.resolveEntitySprite(e)
+	if cacheKey == "" {
+		return rendering.AtlasRegion{}, false
+	}
+	return g.spriteAtlas.Region(cacheKey, rgbaImg)
 }
 
 // resolveEntitySprite determines the cache key and generates the sprite for an entity.
 func (g *Game) resolveEntitySprite(e engine.Entity) (string, *image.RGBA) {
 	if spriteComp, hasSprite := g.world.GetComponent(e, "sprite"); hasSprite {
-		return g.resolveSpriteComponent(spriteComp.(*rendering.SpriteComponent))
+		frame := 0
+		if animComp, hasAnim := g.world.GetComponent(e, "animation"); hasAnim {
+			frame = animComp.(*rendering.Animation).FrameIndex()
+		}
+		return g.resolveSpriteComponent(spriteComp.(*rendering.SpriteComponent), frame)
 	}
 
 	if _, hasProjectile := g.world.GetComponent(e, "projectile"); hasProjectile {
@@ -857,11 +2048,11 @@ func (g *Game) resolveEntitySprite(e engine.Entity) (string, *image.RGBA) {
 	return "", nil
 }
 
-// resolveSpriteComponent generates sprite based on the component type.
-func (g *Game) resolveSpriteComponent(sprite *rendering.SpriteComponent) (string, *image.RGBA) {
-	cacheKey := fmt.Sprintf("%s:%d:%d", g.renderer.GetGenre(), sprite.Type, sprite.Variant)
-	rgbaImg := g.generateSpriteByType(sprite)
-	return cacheKey, rgbaImg
+// resolveSpriteComponent generates the sprite frame based on the component type.
+func (g *Game) resolveSpriteComponent(sprite *rendering.SpriteComponent, frame int) (string, *image.RGBA) {
+	cacheKey := fmt.Sprintf("%s:%d:%d:%d", g.renderer.GetGenre(), sprite.Type, sprite.Variant, frame)
+	base := g.generateSpriteByType(sprite)
+	return cacheKey, g.renderer.GetOrCreateAnimationFrame(base, sprite.Type, sprite.Variant, frame)
 }
 
 // generateSpriteByType dispatches to the appropriate sprite generator.
@@ -873,6 +2064,10 @@ func (g *Game) generateSpriteByType(sprite *rendering.SpriteComponent) *image.RG
 		return g.renderer.GetOrCreateEnemySprite(sprite.Variant, sprite.Size)
 	case rendering.SpriteTypeProjectile:
 		return g.renderer.GetOrCreateProjectileSprite(sprite.Variant, sprite.Size)
+	case rendering.SpriteTypePickup:
+		return g.renderer.GetOrCreatePickupSprite(sprite.Variant, sprite.Size)
+	case rendering.SpriteTypeObstacle:
+		return g.renderer.GetOrCreateObstacleSprite(sprite.Variant, sprite.Size)
 	default:
 		return nil
 	}
@@ -885,21 +2080,9 @@ func (g *Game) resolveDefaultProjectile() (string, *image.RGBA) {
 	return cacheKey, rgbaImg
 }
 
-// cacheConvertedSprite converts RGBA to ebiten.Image and caches the result.
-func (g *Game) cacheConvertedSprite(cacheKey string, rgbaImg *image.RGBA) *ebiten.Image {
-	if rgbaImg == nil {
-		return nil
-	}
-	ebitenImg := ebiten.NewImageFromImage(rgbaImg)
-	g.ebitenImageCache[cacheKey] = ebitenImg
-	return ebitenImg
-}
-
 // drawHUD renders the heads-up display.
 func (g *Game) drawHUD(screen *ebiten.Image) {
-	hudText := fmt.Sprintf("Score: %d | Wave: %d | Combo: x%d | Health: %.0f",
-		g.hud.Score, g.hud.Wave, g.hud.Combo+1, g.hud.Health)
-	ebitenutil.DebugPrintAt(screen, hudText, 10, g.cfg.Display.Height-HUDBottomOffset)
+	g.drawCalls.Add(g.hudRenderer.Draw(screen, g.hud))
 }
 
 // drawTutorial renders the tutorial overlay.
@@ -919,18 +2102,24 @@ func (g *Game) drawTutorial(screen *ebiten.Image) {
 	text := prompt.Text
 	textWidth := len(text) * CharacterWidthApprox
 	x := (width - textWidth) / 2
-	ebitenutil.DebugPrintAt(screen, text, x, y)
+	g.printAt(screen, text, x, y)
 
 	// Draw key hint below
 	if prompt.KeyHint != "" {
 		hintText := fmt.Sprintf("Press: %s", prompt.KeyHint)
 		hintWidth := len(hintText) * CharacterWidthApprox
-		ebitenutil.DebugPrintAt(screen, hintText, (width-hintWidth)/2, y+TutorialKeyHintOffset)
+		g.printAt(screen, hintText, (width-hintWidth)/2, y+TutorialKeyHintOffset)
 	}
 
 	// Draw progress indicator
 	progressText := fmt.Sprintf("Tutorial %d/%d", g.tutorial.Step+1, TutorialStepCount)
-	ebitenutil.DebugPrintAt(screen, progressText, (width-len(progressText)*CharacterWidthApprox)/2, height-TutorialProgressOffset)
+	g.printAt(screen, progressText, (width-len(progressText)*CharacterWidthApprox)/2, height-TutorialProgressOffset)
+}
+
+// printAt draws debug-font text and counts the draw call.
+func (g *Game) printAt(screen *ebiten.Image, text string, x, y int) {
+	ebitenutil.DebugPrintAt(screen, text, x, y)
+	g.drawCalls.Add(1)
 }
 
 // drawMenu renders the current menu.
@@ -945,28 +2134,67 @@ func (g *Game) drawMenu(screen *ebiten.Image) {
 	width := g.cfg.Display.Width
 	height := g.cfg.Display.Height
 
-	ebitenutil.DebugPrintAt(screen, title, width/2-len(title)*(CharacterWidthApprox/2), height/3)
+	titleY, top, left := height/3, height/2, width/2-40
+	if g.menuController.InSettings() {
+		// Center the widest line so the value column fits on screen
+		widest := 0
+		for _, item := range items {
+			widest = max(widest, len(item.Label)+2)
+		}
+		titleY, top, left = SettingsTitleY, SettingsTopY, width/2-widest*CharacterWidthApprox/2
+	}
+	g.printAt(screen, title, width/2-len(title)*(CharacterWidthApprox/2), titleY)
 
 	// Draw menu items
 	for i, item := range items {
-		y := height/2 + i*MenuItemSpacing
+		y := top + i*MenuItemSpacing
 		prefix := "  "
 		if i == g.menuController.SelectionIndex() {
 			prefix = "> "
 		}
-		ebitenutil.DebugPrintAt(screen, prefix+item.Label, width/2-40, y)
+		g.printAt(screen, prefix+item.Label, left, y)
+	}
+
+	// Explain when the selected setting takes effect, or how rebinding went
+	if note := g.menuNote(); note != "" {
+		g.printAt(screen, note, width/2-len(note)*(CharacterWidthApprox/2), height-SettingsNoteOffset)
 	}
 
 	// Draw score on game over
 	if g.stateManager.State() == ux.StateGameOver {
 		scoreText := fmt.Sprintf("Final Score: %d | Wave Reached: %d",
 			g.stateManager.FinalScore(), g.stateManager.FinalWave())
-		ebitenutil.DebugPrintAt(screen, scoreText, width/2-len(scoreText)*(CharacterWidthApprox/2), height/2+GameOverScoreOffset)
+		g.printAt(screen, scoreText, width/2-len(scoreText)*(CharacterWidthApprox/2), height/2+GameOverScoreOffset)
+	}
+}
+
+// menuNote returns the footer for the settings and controls screens.
+func (g *Game) menuNote() string {
+	if !g.menuController.InControls() {
+		if s := g.menuController.SelectedSetting(); s != nil {
+			return s.Note
+		}
+		return ""
+	}
+	controls := g.menuController.Settings().Controls
+	switch {
+	case controls.Message() != "":
+		return controls.Message()
+	case controls.Capturing() != "":
+		return ux.CaptureNote
+	default:
+		return ux.ControlsHint
 	}
 }
 
 // getMenuTitle returns the title for the current menu state.
 func (g *Game) getMenuTitle() string {
+	if g.menuController.InControls() {
+		return "CONTROLS"
+	}
+	if g.menuController.InSettings() {
+		return "SETTINGS"
+	}
 	switch g.stateManager.State() {
 	case ux.StateMainMenu:
 		return "VELOCITY"
@@ -24,6 +24,8 @@ type Preset struct {
 	Saturation float64
 	NeonGlow   float64
 	GrainLevel float64
+	Scanlines  float64 // CRT scanline darkening, 0 (off) to 1
+	Vignette   float64 // Edge darkening, 0 (off) to 1
 	Colors     []color.RGBA
 }
 
@@ -34,31 +36,39 @@ func GetPreset(genreID string) Preset {
 		return Preset{
 			GenreID:    SciFi,
 			BloomScale: 1.2,
+			Vignette:   0.2,
 			Colors:     sciFiColors(),
 		}
 	case Horror:
 		return Preset{
 			GenreID:    Horror,
 			Saturation: 0.4,
+			GrainLevel: 0.2,
+			Vignette:   0.7,
 			Colors:     horrorColors(),
 		}
 	case Cyberpunk:
 		return Preset{
-			GenreID:  Cyberpunk,
-			NeonGlow: 1.5,
-			Colors:   cyberpunkColors(),
+			GenreID:   Cyberpunk,
+			NeonGlow:  1.5,
+			Scanlines: 0.35,
+			Vignette:  0.3,
+			Colors:    cyberpunkColors(),
 		}
 	case Fantasy:
 		return Preset{
 			GenreID:    Fantasy,
 			BloomScale: 0.8,
 			Saturation: 1.1,
+			Vignette:   0.25,
 			Colors:     fantasyColors(),
 		}
 	case PostApoc:
 		return Preset{
 			GenreID:    PostApoc,
 			GrainLevel: 0.6,
+			Scanlines:  0.15,
+			Vignette:   0.4,
 			Colors:     postApocColors(),
 		}
 	default:
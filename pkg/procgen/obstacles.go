@@ -0,0 +1,404 @@
+package procgen
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/opd-ai/velocity/pkg/combat"
+	"github.com/opd-ai/velocity/pkg/engine"
+	"github.com/opd-ai/velocity/pkg/rendering"
+)
+
+// ObstacleKind identifies the behavior of an arena obstacle.
+type ObstacleKind int
+
+const (
+	ObstacleAsteroid ObstacleKind = iota
+	ObstacleCover
+	ObstacleWall
+)
+
+// Obstacle layout constants.
+const (
+	// ObstacleBaseCount is the number of obstacle groups on wave 1.
+	ObstacleBaseCount = 3
+	// ObstacleWavesPerExtra is how many waves it takes to add another obstacle group.
+	ObstacleWavesPerExtra = 2
+	// ObstacleMaxCount caps the number of obstacle groups per wave.
+	ObstacleMaxCount = 10
+	// ObstacleSeedOffset decorrelates the obstacle layout from other wave rolls.
+	ObstacleSeedOffset = 104729
+	// ObstacleClearRadius keeps the arena center free for the player.
+	ObstacleClearRadius = 120.0
+	// ObstacleEdgeMargin keeps obstacles away from the arena edges.
+	ObstacleEdgeMargin = 40.0
+	// ObstaclePlacementAttempts bounds the search for a free spot.
+	ObstaclePlacementAttempts = 8
+)
+
+// Obstacle tuning constants.
+const (
+	// AsteroidMaxSize is the pixel size of a freshly spawned asteroid.
+	AsteroidMaxSize = 32
+	// AsteroidMinSize is the smallest asteroid produced by splitting.
+	AsteroidMinSize = 12
+	// AsteroidSplitCount is the number of fragments an asteroid breaks into.
+	AsteroidSplitCount = 2
+	// AsteroidHealthPerPixel scales asteroid health with size.
+	AsteroidHealthPerPixel = 1.5
+	// AsteroidMaxDrift is the top drift speed of a spawned asteroid.
+	AsteroidMaxDrift = 40.0
+	// AsteroidSplitSpeed is the speed fragments fly apart at.
+	AsteroidSplitSpeed = 60.0
+	// CoverSize is the pixel size of destructible cover blocks.
+	CoverSize = 20
+	// CoverHealth is the hit points of a cover block.
+	CoverHealth = 60.0
+	// WallSegmentSize is the pixel size of a single wall segment.
+	WallSegmentSize = 16
+	// WallMinSegments is the shortest wall.
+	WallMinSegments = 3
+	// WallMaxSegments is the longest wall.
+	WallMaxSegments = 6
+)
+
+// obstacleWeights holds the relative chance of asteroid, cover and wall per genre,
+// so fantasy arenas lean on ruins and cyberpunk arenas on barricades.
+var obstacleWeights = map[string][3]float64{
+	"scifi":     {0.6, 0.25, 0.15},
+	"fantasy":   {0.3, 0.4, 0.3},
+	"horror":    {0.2, 0.5, 0.3},
+	"cyberpunk": {0.2, 0.3, 0.5},
+	"postapoc":  {0.3, 0.5, 0.2},
+}
+
+// ObstacleConfig describes an obstacle group to place in the arena.
+// Walls expand into Segments wall blocks laid out horizontally or vertically.
+type ObstacleConfig struct {
+	Kind     ObstacleKind
+	X, Y     float64
+	VX, VY   float64
+	Size     int
+	Health   float64
+	Segments int
+	Vertical bool
+}
+
+// Obstacle component holds obstacle behavior data. Asteroids keep their drift
+// velocity here so drag does not bring them to a stop.
+type Obstacle struct {
+	Kind   ObstacleKind
+	Size   int
+	VX, VY float64
+}
+
+// GenerateObstacles produces the obstacle layout for a wave in an arena of the given size.
+func (g *Generator) GenerateObstacles(waveNumber, width, height int) []ObstacleConfig {
+	rng := engine.DeterministicRNG(g.seed + int64(waveNumber) + ObstacleSeedOffset)
+	count := ObstacleBaseCount + waveNumber/ObstacleWavesPerExtra
+	if count > ObstacleMaxCount {
+		count = ObstacleMaxCount
+	}
+
+	configs := make([]ObstacleConfig, 0, count)
+	for i := 0; i < count; i++ {
+		x, y := obstaclePosition(rng, float64(width), float64(height))
+		kind := g.pickObstacleKind(rng)
+		config := ObstacleConfig{Kind: kind, X: x, Y: y}
+
+		switch kind {
+		case ObstacleAsteroid:
+			angle := rng.Float64() * 2 * math.Pi
+			speed := rng.Float64() * AsteroidMaxDrift
+			config.VX = math.Cos(angle) * speed
+			config.VY = math.Sin(angle) * speed
+			config.Size = AsteroidMaxSize
+			config.Health = AsteroidMaxSize * AsteroidHealthPerPixel
+		case ObstacleCover:
+			config.Size = CoverSize
+			config.Health = CoverHealth
+		case ObstacleWall:
+			config.Size = WallSegmentSize
+			config.Segments = WallMinSegments + rng.Intn(WallMaxSegments-WallMinSegments+1)
+			config.Vertical = rng.Intn(2) == 0
+		}
+		configs = append(configs, config)
+	}
+	return configs
+}
+
+// pickObstacleKind rolls an obstacle kind using the genre weights.
+func (g *Generator) pickObstacleKind(rng *rand.Rand) ObstacleKind {
+	weights, ok := obstacleWeights[g.genreID]
+	if !ok {
+		weights = obstacleWeights["scifi"]
+	}
+	total := weights[0] + weights[1] + weights[2]
+	roll := rng.Float64() * total
+	for i, w := range weights {
+		if roll < w {
+			return ObstacleKind(i)
+		}
+		roll -= w
+	}
+	return ObstacleWall
+}
+
+// obstaclePosition picks a point inside the arena margins, away from the center.
+func obstaclePosition(rng *rand.Rand, width, height float64) (float64, float64) {
+	var x, y float64
+	for attempt := 0; attempt < ObstaclePlacementAttempts; attempt++ {
+		x = ObstacleEdgeMargin + rng.Float64()*math.Max(width-2*ObstacleEdgeMargin, 0)
+		y = ObstacleEdgeMargin + rng.Float64()*math.Max(height-2*ObstacleEdgeMargin, 0)
+		if math.Hypot(x-width/2, y-height/2) >= ObstacleClearRadius {
+			break
+		}
+	}
+	return x, y
+}
+
+// ObstacleSystem spawns, moves and splits arena obstacles and keeps ships out of them.
+type ObstacleSystem struct {
+	world     *engine.World
+	generator *Generator
+	width     int
+	height    int
+	toRemove  []engine.Entity
+}
+
+// NewObstacleSystem creates an obstacle system for an arena of the given size.
+func NewObstacleSystem(world *engine.World, generator *Generator, width, height int) *ObstacleSystem {
+	return &ObstacleSystem{
+		world:     world,
+		generator: generator,
+		width:     width,
+		height:    height,
+		toRemove:  make([]engine.Entity, 0, 16),
+	}
+}
+
+// SpawnWave clears the previous layout and places the obstacles for a wave.
+func (obs *ObstacleSystem) SpawnWave(waveNumber int) []engine.Entity {
+	obs.Clear()
+	var entities []engine.Entity
+	for _, config := range obs.generator.GenerateObstacles(waveNumber, obs.width, obs.height) {
+		entities = append(entities, obs.Spawn(config)...)
+	}
+	return entities
+}
+
+// Spawn creates the entities for a single obstacle group.
+func (obs *ObstacleSystem) Spawn(config ObstacleConfig) []engine.Entity {
+	if config.Kind != ObstacleWall {
+		return []engine.Entity{obs.spawnObstacle(config.Kind, config.X, config.Y, config.Size, config.Health, config.VX, config.VY)}
+	}
+
+	segments := make([]engine.Entity, 0, config.Segments)
+	for i := 0; i < config.Segments; i++ {
+		x, y := config.X, config.Y
+		offset := float64(i*config.Size) - float64((config.Segments-1)*config.Size)/2
+		if config.Vertical {
+			y += offset
+		} else {
+			x += offset
+		}
+		segments = append(segments, obs.spawnObstacle(ObstacleWall, x, y, config.Size, 0, 0, 0))
+	}
+	return segments
+}
+
+// spawnObstacle creates one obstacle entity. Walls get no health and cannot be destroyed.
+func (obs *ObstacleSystem) spawnObstacle(kind ObstacleKind, x, y float64, size int, health, vx, vy float64) engine.Entity {
+	e := obs.world.CreateEntity()
+	half := float64(size) / 2
+
+	obs.world.AddComponent(e, "position", &engine.Position{X: x, Y: y})
+	obs.world.AddComponent(e, "obstacle", &Obstacle{Kind: kind, Size: size, VX: vx, VY: vy})
+	obs.world.AddComponent(e, "collisiontag", &combat.CollisionTag{Tag: "obstacle"})
+	obs.world.AddComponent(e, "boundingbox", &combat.BoundingBox{
+		X: -half, Y: -half, Width: float64(size), Height: float64(size),
+	})
+	if kind == ObstacleAsteroid {
+		obs.world.AddComponent(e, "velocity", &engine.Velocity{VX: vx, VY: vy})
+		obs.world.AddComponent(e, "rotation", &engine.Rotation{Angle: math.Atan2(vy, vx)})
+	}
+	if health > 0 {
+		obs.world.AddComponent(e, "health", &combat.Health{Current: health, Max: health})
+	}
+
+	// Encode size into the variant so each asteroid size gets its own cached sprite
+	obs.world.AddComponent(e, "sprite", &rendering.SpriteComponent{
+		Type:    rendering.SpriteTypeObstacle,
+		Variant: size*rendering.ObstacleShapeCount + int(kind),
+		Size:    size,
+	})
+	return e
+}
+
+// Clear removes every obstacle from the world.
+func (obs *ObstacleSystem) Clear() {
+	obs.toRemove = obs.toRemove[:0]
+	obs.world.ForEachEntity(func(e engine.Entity) {
+		if _, ok := obs.world.GetComponent(e, "obstacle"); ok {
+			obs.toRemove = append(obs.toRemove, e)
+		}
+	})
+	for _, e := range obs.toRemove {
+		obs.world.RemoveEntity(e)
+	}
+}
+
+// ObstacleCount returns the number of obstacle entities in the world.
+func (obs *ObstacleSystem) ObstacleCount() int {
+	count := 0
+	obs.world.ForEachEntity(func(e engine.Entity) {
+		if _, ok := obs.world.GetComponent(e, "obstacle"); ok {
+			count++
+		}
+	})
+	return count
+}
+
+// HandleDestroyed should be called when an obstacle dies, before it is removed.
+// Asteroids large enough split into smaller fragments flying apart.
+func (obs *ObstacleSystem) HandleDestroyed(e engine.Entity) []engine.Entity {
+	obstacleComp, ok := obs.world.GetComponent(e, "obstacle")
+	if !ok {
+		return nil
+	}
+	obstacle := obstacleComp.(*Obstacle)
+	childSize := obstacle.Size / 2
+	if obstacle.Kind != ObstacleAsteroid || childSize < AsteroidMinSize {
+		return nil
+	}
+	posComp, ok := obs.world.GetComponent(e, "position")
+	if !ok {
+		return nil
+	}
+	pos := posComp.(*engine.Position)
+
+	base := math.Atan2(obstacle.VY, obstacle.VX) + math.Pi/2
+	fragments := make([]engine.Entity, 0, AsteroidSplitCount)
+	for i := 0; i < AsteroidSplitCount; i++ {
+		angle := base + float64(i)*2*math.Pi/AsteroidSplitCount
+		vx := obstacle.VX + math.Cos(angle)*AsteroidSplitSpeed
+		vy := obstacle.VY + math.Sin(angle)*AsteroidSplitSpeed
+		health := float64(childSize) * AsteroidHealthPerPixel
+		fragments = append(fragments, obs.spawnObstacle(ObstacleAsteroid, pos.X, pos.Y, childSize, health, vx, vy))
+	}
+	return fragments
+}
+
+// Update keeps asteroids drifting and pushes ships out of obstacles.
+func (obs *ObstacleSystem) Update(dt float64) {
+	obs.world.ForEachEntity(func(e engine.Entity) {
+		obstacleComp, ok := obs.world.GetComponent(e, "obstacle")
+		if !ok {
+			return
+		}
+		obstacle := obstacleComp.(*Obstacle)
+		if velComp, ok := obs.world.GetComponent(e, "velocity"); ok {
+			vel := velComp.(*engine.Velocity)
+			vel.VX, vel.VY = obstacle.VX, obstacle.VY
+		}
+		obs.pushShipsOut(e)
+	})
+}
+
+// Occludes reports whether the segment from (x1, y1) to (x2, y2) passes
+// through cover or a wall. Asteroids are too small and mobile to block
+// line of sight.
+func (obs *ObstacleSystem) Occludes(x1, y1, x2, y2 float64) bool {
+	blocked := false
+	obs.world.ForEachEntity(func(e engine.Entity) {
+		if blocked {
+			return
+		}
+		obstacleComp, ok := obs.world.GetComponent(e, "obstacle")
+		if !ok || obstacleComp.(*Obstacle).Kind == ObstacleAsteroid {
+			return
+		}
+		pos, box, ok := obs.bounds(e)
+		if !ok {
+			return
+		}
+		minX, minY := pos.X+box.X, pos.Y+box.Y
+		blocked = segmentHitsRect(x1, y1, x2, y2, minX, minY, minX+box.Width, minY+box.Height)
+	})
+	return blocked
+}
+
+// segmentHitsRect reports whether a segment crosses an axis-aligned
+// rectangle, clipping it against each pair of sides in turn.
+func segmentHitsRect(x1, y1, x2, y2, minX, minY, maxX, maxY float64) bool {
+	t0, t1 := 0.0, 1.0
+	clip := func(start, delta, lo, hi float64) bool {
+		if delta == 0 {
+			return start >= lo && start <= hi
+		}
+		a, b := (lo-start)/delta, (hi-start)/delta
+		if a > b {
+			a, b = b, a
+		}
+		t0, t1 = math.Max(t0, a), math.Min(t1, b)
+		return t0 <= t1
+	}
+	return clip(x1, x2-x1, minX, maxX) && clip(y1, y2-y1, minY, maxY)
+}
+
+// pushShipsOut separates overlapping ships from an obstacle along the axis of
+// least penetration and cancels their velocity into it.
+func (obs *ObstacleSystem) pushShipsOut(obstacle engine.Entity) {
+	oPos, oBox, ok := obs.bounds(obstacle)
+	if !ok {
+		return
+	}
+	obs.world.ForEachEntity(func(e engine.Entity) {
+		tagComp, ok := obs.world.GetComponent(e, "collisiontag")
+		if !ok {
+			return
+		}
+		if tag := tagComp.(*combat.CollisionTag).Tag; tag != "player" && tag != "enemy" {
+			return
+		}
+		pos, box, ok := obs.bounds(e)
+		if !ok {
+			return
+		}
+
+		overlapX := math.Min(pos.X+box.X+box.Width, oPos.X+oBox.X+oBox.Width) - math.Max(pos.X+box.X, oPos.X+oBox.X)
+		overlapY := math.Min(pos.Y+box.Y+box.Height, oPos.Y+oBox.Y+oBox.Height) - math.Max(pos.Y+box.Y, oPos.Y+oBox.Y)
+		if overlapX <= 0 || overlapY <= 0 {
+			return
+		}
+
+		var vel *engine.Velocity
+		if velComp, ok := obs.world.GetComponent(e, "velocity"); ok {
+			vel = velComp.(*engine.Velocity)
+		}
+		if overlapX < overlapY {
+			dir := math.Copysign(1, pos.X-oPos.X)
+			pos.X += dir * overlapX
+			if vel != nil && vel.VX*dir < 0 {
+				vel.VX = 0
+			}
+		} else {
+			dir := math.Copysign(1, pos.Y-oPos.Y)
+			pos.Y += dir * overlapY
+			if vel != nil && vel.VY*dir < 0 {
+				vel.VY = 0
+			}
+		}
+		obs.world.MarkChanged(e)
+	})
+}
+
+// bounds returns an entity's position and bounding box.
+func (obs *ObstacleSystem) bounds(e engine.Entity) (*engine.Position, *combat.BoundingBox, bool) {
+	posComp, hasPos := obs.world.GetComponent(e, "position")
+	boxComp, hasBox := obs.world.GetComponent(e, "boundingbox")
+	if !hasPos || !hasBox {
+		return nil, nil, false
+	}
+	return posComp.(*engine.Position), boxComp.(*combat.BoundingBox), true
+}
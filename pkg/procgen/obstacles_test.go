@@ -0,0 +1,201 @@
+package procgen
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/combat"
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+func TestGenerator_GenerateObstacles(t *testing.T) {
+	g := NewGenerator(42)
+
+	configs := g.GenerateObstacles(1, 800, 600)
+	if len(configs) != ObstacleBaseCount {
+		t.Fatalf("expected %d obstacles on wave 1, got %d", ObstacleBaseCount, len(configs))
+	}
+	if !reflect.DeepEqual(configs, g.GenerateObstacles(1, 800, 600)) {
+		t.Error("expected obstacle layout to be deterministic")
+	}
+	if n := len(g.GenerateObstacles(100, 800, 600)); n != ObstacleMaxCount {
+		t.Errorf("expected obstacle count capped at %d, got %d", ObstacleMaxCount, n)
+	}
+
+	for wave := 1; wave < 20; wave++ {
+		for _, c := range g.GenerateObstacles(wave, 800, 600) {
+			if c.X < ObstacleEdgeMargin || c.X > 800-ObstacleEdgeMargin || c.Y < ObstacleEdgeMargin || c.Y > 600-ObstacleEdgeMargin {
+				t.Errorf("wave %d: obstacle outside margins at (%.0f, %.0f)", wave, c.X, c.Y)
+			}
+			if c.Kind == ObstacleWall && (c.Segments < WallMinSegments || c.Segments > WallMaxSegments) {
+				t.Errorf("wave %d: wall has %d segments", wave, c.Segments)
+			}
+		}
+	}
+}
+
+func TestObstaclePosition_AvoidsCenter(t *testing.T) {
+	rng := engine.DeterministicRNG(3)
+	near := 0
+	for i := 0; i < 200; i++ {
+		x, y := obstaclePosition(rng, 800, 600)
+		if math.Hypot(x-400, y-300) < ObstacleClearRadius {
+			near++
+		}
+	}
+	// Placement only falls back to the center after ObstaclePlacementAttempts misses
+	if near > 2 {
+		t.Errorf("expected the arena center to stay clear, %d of 200 landed inside", near)
+	}
+}
+
+func TestGenerator_GenerateObstacles_GenreThemed(t *testing.T) {
+	count := func(genreID string) map[ObstacleKind]int {
+		g := NewGenerator(7)
+		g.SetGenre(genreID)
+		kinds := make(map[ObstacleKind]int)
+		for wave := 1; wave < 60; wave++ {
+			for _, c := range g.GenerateObstacles(wave, 800, 600) {
+				kinds[c.Kind]++
+			}
+		}
+		return kinds
+	}
+
+	scifi := count("scifi")
+	cyberpunk := count("cyberpunk")
+	if scifi[ObstacleAsteroid] <= cyberpunk[ObstacleAsteroid] {
+		t.Errorf("expected scifi to favor asteroids: scifi=%d cyberpunk=%d", scifi[ObstacleAsteroid], cyberpunk[ObstacleAsteroid])
+	}
+	if cyberpunk[ObstacleWall] <= scifi[ObstacleWall] {
+		t.Errorf("expected cyberpunk to favor walls: scifi=%d cyberpunk=%d", scifi[ObstacleWall], cyberpunk[ObstacleWall])
+	}
+}
+
+func TestObstacleSystem_SpawnWaveReplacesLayout(t *testing.T) {
+	world := engine.NewWorld()
+	obs := NewObstacleSystem(world, NewGenerator(42), 800, 600)
+
+	first := obs.SpawnWave(1)
+	if len(first) == 0 || obs.ObstacleCount() != len(first) {
+		t.Fatalf("expected spawned obstacles to be counted, got %d of %d", obs.ObstacleCount(), len(first))
+	}
+
+	second := obs.SpawnWave(2)
+	if obs.ObstacleCount() != len(second) {
+		t.Errorf("expected previous layout cleared, got %d obstacles for %d spawned", obs.ObstacleCount(), len(second))
+	}
+}
+
+func TestObstacleSystem_WallSegments(t *testing.T) {
+	world := engine.NewWorld()
+	obs := NewObstacleSystem(world, NewGenerator(1), 800, 600)
+
+	segments := obs.Spawn(ObstacleConfig{Kind: ObstacleWall, X: 100, Y: 100, Size: WallSegmentSize, Segments: 4})
+	if len(segments) != 4 {
+		t.Fatalf("expected 4 wall segments, got %d", len(segments))
+	}
+	for _, e := range segments {
+		if _, ok := world.GetComponent(e, "health"); ok {
+			t.Error("expected wall segments to be indestructible")
+		}
+	}
+	first, _ := world.GetComponent(segments[0], "position")
+	last, _ := world.GetComponent(segments[3], "position")
+	if span := last.(*engine.Position).X - first.(*engine.Position).X; span != 3*WallSegmentSize {
+		t.Errorf("expected horizontal span %d, got %f", 3*WallSegmentSize, span)
+	}
+}
+
+func TestObstacleSystem_Occludes(t *testing.T) {
+	world := engine.NewWorld()
+	obs := NewObstacleSystem(world, NewGenerator(1), 800, 600)
+	obs.Spawn(ObstacleConfig{Kind: ObstacleWall, X: 200, Y: 200, Size: WallSegmentSize, Segments: 3, Vertical: true})
+	obs.Spawn(ObstacleConfig{Kind: ObstacleAsteroid, X: 500, Y: 200, Size: AsteroidMaxSize})
+
+	tests := []struct {
+		name           string
+		x1, y1, x2, y2 float64
+		want           bool
+	}{
+		{"through wall", 100, 200, 300, 200, true},
+		{"diagonal through wall", 100, 150, 300, 250, true},
+		{"above wall", 100, 100, 300, 100, false},
+		{"stops short", 100, 200, 180, 200, false},
+		{"through asteroid", 400, 200, 600, 200, false},
+		{"inside wall", 200, 200, 200, 200, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := obs.Occludes(tt.x1, tt.y1, tt.x2, tt.y2); got != tt.want {
+				t.Errorf("Occludes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObstacleSystem_AsteroidSplits(t *testing.T) {
+	world := engine.NewWorld()
+	obs := NewObstacleSystem(world, NewGenerator(1), 800, 600)
+
+	big := obs.Spawn(ObstacleConfig{Kind: ObstacleAsteroid, X: 100, Y: 100, VX: 10, Size: AsteroidMaxSize, Health: 10})[0]
+	fragments := obs.HandleDestroyed(big)
+	if len(fragments) != AsteroidSplitCount {
+		t.Fatalf("expected %d fragments, got %d", AsteroidSplitCount, len(fragments))
+	}
+
+	comp, _ := world.GetComponent(fragments[0], "obstacle")
+	if size := comp.(*Obstacle).Size; size != AsteroidMaxSize/2 {
+		t.Errorf("expected fragment size %d, got %d", AsteroidMaxSize/2, size)
+	}
+
+	// Fragments of fragments eventually stop splitting
+	small := obs.Spawn(ObstacleConfig{Kind: ObstacleAsteroid, Size: AsteroidMinSize, Health: 1})[0]
+	if got := obs.HandleDestroyed(small); len(got) != 0 {
+		t.Errorf("expected minimum-size asteroid not to split, got %d fragments", len(got))
+	}
+
+	cover := obs.Spawn(ObstacleConfig{Kind: ObstacleCover, Size: CoverSize, Health: CoverHealth})[0]
+	if got := obs.HandleDestroyed(cover); len(got) != 0 {
+		t.Error("expected cover not to split")
+	}
+}
+
+func TestObstacleSystem_AsteroidKeepsDrifting(t *testing.T) {
+	world := engine.NewWorld()
+	obs := NewObstacleSystem(world, NewGenerator(1), 800, 600)
+	rock := obs.Spawn(ObstacleConfig{Kind: ObstacleAsteroid, X: 100, Y: 100, VX: 20, Size: AsteroidMaxSize, Health: 10})[0]
+
+	velComp, _ := world.GetComponent(rock, "velocity")
+	vel := velComp.(*engine.Velocity)
+	vel.VX = 5 // Simulate drag
+	obs.Update(1.0 / 60.0)
+
+	if vel.VX != 20 {
+		t.Errorf("expected drift velocity restored to 20, got %f", vel.VX)
+	}
+}
+
+func TestObstacleSystem_PushesShipsOut(t *testing.T) {
+	world := engine.NewWorld()
+	obs := NewObstacleSystem(world, NewGenerator(1), 800, 600)
+	obs.Spawn(ObstacleConfig{Kind: ObstacleCover, X: 100, Y: 100, Size: CoverSize, Health: CoverHealth})
+
+	ship := world.CreateEntity()
+	world.AddComponent(ship, "position", &engine.Position{X: 88, Y: 100})
+	world.AddComponent(ship, "velocity", &engine.Velocity{VX: 50})
+	world.AddComponent(ship, "collisiontag", &combat.CollisionTag{Tag: "player"})
+	world.AddComponent(ship, "boundingbox", &combat.BoundingBox{X: -8, Y: -8, Width: 16, Height: 16})
+
+	obs.Update(1.0 / 60.0)
+
+	posComp, _ := world.GetComponent(ship, "position")
+	if x := posComp.(*engine.Position).X; x != 82 {
+		t.Errorf("expected ship pushed left to x=82, got %f", x)
+	}
+	velComp, _ := world.GetComponent(ship, "velocity")
+	if vx := velComp.(*engine.Velocity).VX; vx != 0 {
+		t.Errorf("expected velocity into the obstacle cancelled, got %f", vx)
+	}
+}
@@ -1,11 +1,77 @@
 // Package procgen provides procedural content generation systems.
 package procgen
 
+import (
+	"math"
+
+	"github.com/opd-ai/velocity/pkg/engine"
+	"github.com/opd-ai/velocity/pkg/world"
+)
+
+// Weather selection constants.
+const (
+	// WeatherStartWave is the first wave that can roll hazardous weather.
+	WeatherStartWave = 3
+	// WeatherChance is the probability that an eligible wave has hazardous weather.
+	WeatherChance = 0.6
+	// WeatherBaseIntensity is the weather intensity at WeatherStartWave.
+	WeatherBaseIntensity = 0.4
+	// WeatherIntensityPerWave is the additional intensity gained per wave.
+	WeatherIntensityPerWave = 0.05
+	// WeatherSeedOffset decorrelates the weather roll from the enemy spawns.
+	WeatherSeedOffset = 2729
+)
+
+// Objective selection constants.
+const (
+	// ObjectiveStartWave is the first wave that can roll a special objective.
+	ObjectiveStartWave = 2
+	// ObjectiveSeedOffset decorrelates the objective roll from the weather roll.
+	ObjectiveSeedOffset = 7919
+	// SurviveBaseTime is the survival time in seconds at ObjectiveStartWave.
+	SurviveBaseTime = 30.0
+	// SurviveTimePerWave is the additional survival time per wave.
+	SurviveTimePerWave = 2.0
+	// SurviveMaxTime caps the survival time.
+	SurviveMaxTime = 60.0
+	// AssassinateTimeLimit is the time allowed to destroy the marked target.
+	AssassinateTimeLimit = 30.0
+	// CollectBaseCount is the number of items to collect at ObjectiveStartWave.
+	CollectBaseCount = 3
+	// CollectWavesPerItem is how many waves it takes to add another item.
+	CollectWavesPerItem = 3
+)
+
+// Arena mode selection constants, used when the arena mode is "procedural".
+const (
+	// ArenaModeStartWave is the first wave that can roll a special arena mode.
+	ArenaModeStartWave = 3
+	// ArenaModeChance is the probability that an eligible wave changes the arena.
+	ArenaModeChance = 0.5
+	// ArenaSeedOffset decorrelates the arena roll from the weather and objective rolls.
+	ArenaSeedOffset = 4391
+)
+
+// proceduralArenaModes are the arena modes the generator can roll. Scrolling
+// stages need a tall world and are only available through config.
+var proceduralArenaModes = []engine.ArenaMode{
+	engine.ArenaModeBounded,
+	engine.ArenaModeRing,
+	engine.ArenaModePortal,
+	engine.ArenaModeElectric,
+	engine.ArenaModeAsymmetric,
+}
+
 // WaveConfig describes a single procedural wave of enemies.
 type WaveConfig struct {
-	WaveNumber int
-	EnemyCount int
-	Seed       int64
+	WaveNumber       int
+	EnemyCount       int
+	Seed             int64
+	Weather          world.WeatherType
+	WeatherIntensity float64
+	Objective        world.ObjectiveType
+	ObjectiveGoal    float64
+	ArenaMode        engine.ArenaMode
 }
 
 // Generator produces procedural content from a seed.
@@ -26,9 +92,65 @@ func (g *Generator) SetGenre(genreID string) {
 
 // GenerateWave produces a wave configuration for the given wave number.
 func (g *Generator) GenerateWave(waveNumber int) WaveConfig {
-	return WaveConfig{
+	config := WaveConfig{
 		WaveNumber: waveNumber,
 		EnemyCount: waveNumber + 2,
 		Seed:       g.seed + int64(waveNumber),
 	}
+	config.Weather, config.WeatherIntensity = g.rollWeather(waveNumber, config.Seed)
+	config.Objective, config.ObjectiveGoal = g.rollObjective(waveNumber, config.Seed)
+	config.ArenaMode = g.rollArenaMode(waveNumber, config.Seed)
+	return config
+}
+
+// GenreID returns the genre used for generation.
+func (g *Generator) GenreID() string {
+	return g.genreID
+}
+
+// rollArenaMode picks the arena mode for a wave from the wave seed.
+func (g *Generator) rollArenaMode(waveNumber int, seed int64) engine.ArenaMode {
+	if waveNumber < ArenaModeStartWave {
+		return engine.ArenaModeWrap
+	}
+	rng := engine.DeterministicRNG(seed + ArenaSeedOffset)
+	if rng.Float64() >= ArenaModeChance {
+		return engine.ArenaModeWrap
+	}
+	return proceduralArenaModes[rng.Intn(len(proceduralArenaModes))]
+}
+
+// rollWeather picks the weather for a wave from the wave seed.
+func (g *Generator) rollWeather(waveNumber int, seed int64) (world.WeatherType, float64) {
+	if waveNumber < WeatherStartWave {
+		return world.WeatherClear, 0
+	}
+	rng := engine.DeterministicRNG(seed + WeatherSeedOffset)
+	if rng.Float64() >= WeatherChance {
+		return world.WeatherClear, 0
+	}
+	types := world.AllWeatherTypes()
+	intensity := WeatherBaseIntensity + float64(waveNumber-WeatherStartWave)*WeatherIntensityPerWave
+	return types[rng.Intn(len(types))], math.Min(intensity, 1.0)
+}
+
+// rollObjective picks the objective for a wave and its goal from the wave seed.
+func (g *Generator) rollObjective(waveNumber int, seed int64) (world.ObjectiveType, float64) {
+	if waveNumber < ObjectiveStartWave {
+		return world.ObjectiveEliminate, 0
+	}
+	rng := engine.DeterministicRNG(seed + ObjectiveSeedOffset)
+	types := world.AllObjectiveTypes()
+	t := types[rng.Intn(len(types))]
+
+	progress := float64(waveNumber - ObjectiveStartWave)
+	switch t {
+	case world.ObjectiveSurvive:
+		return t, math.Min(SurviveBaseTime+progress*SurviveTimePerWave, SurviveMaxTime)
+	case world.ObjectiveAssassinate:
+		return t, AssassinateTimeLimit
+	case world.ObjectiveCollect:
+		return t, float64(CollectBaseCount + (waveNumber-ObjectiveStartWave)/CollectWavesPerItem)
+	}
+	return t, 0
 }
@@ -3,7 +3,9 @@ package procgen
 import (
 	"testing"
 
+	"github.com/opd-ai/velocity/pkg/engine"
 	"github.com/opd-ai/velocity/pkg/procgen/genre"
+	"github.com/opd-ai/velocity/pkg/world"
 )
 
 func TestNewGenerator(t *testing.T) {
@@ -100,3 +102,95 @@ func TestWaveConfig(t *testing.T) {
 		t.Errorf("expected Seed 12345, got %d", config.Seed)
 	}
 }
+
+func TestGenerator_GenerateWave_Weather(t *testing.T) {
+	g := NewGenerator(7)
+
+	for wave := 0; wave < WeatherStartWave; wave++ {
+		if cfg := g.GenerateWave(wave); cfg.Weather != world.WeatherClear {
+			t.Errorf("wave %d: expected clear skies before WeatherStartWave, got %d", wave, cfg.Weather)
+		}
+	}
+
+	sawWeather := false
+	for wave := WeatherStartWave; wave < WeatherStartWave+30; wave++ {
+		cfg := g.GenerateWave(wave)
+		if cfg != g.GenerateWave(wave) {
+			t.Fatalf("wave %d: weather roll is not deterministic", wave)
+		}
+		if cfg.Weather != world.WeatherClear {
+			sawWeather = true
+			if cfg.WeatherIntensity <= 0 || cfg.WeatherIntensity > 1 {
+				t.Errorf("wave %d: intensity %f out of range", wave, cfg.WeatherIntensity)
+			}
+		}
+	}
+	if !sawWeather {
+		t.Error("expected at least one wave with hazardous weather")
+	}
+}
+
+func TestGenerator_WeatherIndependentOfSpawns(t *testing.T) {
+	// The spawner's first draw from the wave seed must not decide the weather
+	g := NewGenerator(7)
+	agree := 0
+	const waves = 200
+	for wave := WeatherStartWave; wave < WeatherStartWave+waves; wave++ {
+		cfg := g.GenerateWave(wave)
+		firstDraw := engine.DeterministicRNG(cfg.Seed).Float64()
+		if (firstDraw < WeatherChance) == (cfg.Weather != world.WeatherClear) {
+			agree++
+		}
+	}
+	if agree == waves {
+		t.Error("expected the weather roll to be independent of the spawner's first draw")
+	}
+}
+
+func TestGenerator_GenerateWave_ArenaMode(t *testing.T) {
+	g := NewGenerator(7)
+
+	for wave := 0; wave < ArenaModeStartWave; wave++ {
+		if cfg := g.GenerateWave(wave); cfg.ArenaMode != engine.ArenaModeWrap {
+			t.Errorf("wave %d: expected wrap before ArenaModeStartWave, got %q", wave, cfg.ArenaMode)
+		}
+	}
+
+	seen := make(map[engine.ArenaMode]bool)
+	for wave := ArenaModeStartWave; wave < ArenaModeStartWave+80; wave++ {
+		seen[g.GenerateWave(wave).ArenaMode] = true
+	}
+	if seen[engine.ArenaModeScroll] {
+		t.Error("expected scroll mode never to be rolled")
+	}
+	if len(seen) != len(proceduralArenaModes)+1 {
+		t.Errorf("expected wrap and every procedural mode to appear, saw %v", seen)
+	}
+}
+
+func TestGenerator_GenerateWave_Objective(t *testing.T) {
+	g := NewGenerator(7)
+
+	if cfg := g.GenerateWave(1); cfg.Objective != world.ObjectiveEliminate {
+		t.Errorf("expected plain elimination on wave 1, got %d", cfg.Objective)
+	}
+
+	seen := make(map[world.ObjectiveType]bool)
+	for wave := ObjectiveStartWave; wave < ObjectiveStartWave+60; wave++ {
+		cfg := g.GenerateWave(wave)
+		seen[cfg.Objective] = true
+		switch cfg.Objective {
+		case world.ObjectiveSurvive:
+			if cfg.ObjectiveGoal < SurviveBaseTime || cfg.ObjectiveGoal > SurviveMaxTime {
+				t.Errorf("wave %d: survival time %f out of range", wave, cfg.ObjectiveGoal)
+			}
+		case world.ObjectiveCollect:
+			if cfg.ObjectiveGoal < CollectBaseCount {
+				t.Errorf("wave %d: collect count %f too low", wave, cfg.ObjectiveGoal)
+			}
+		}
+	}
+	if len(seen) != len(world.AllObjectiveTypes()) {
+		t.Errorf("expected every objective type to appear, saw %d", len(seen))
+	}
+}
@@ -2,6 +2,7 @@
 package procgen
 
 import (
+	"image/color"
 	"math"
 	"math/rand"
 
@@ -34,8 +35,15 @@ const (
 	EnemySpriteSizePx = 16
 	// EnemyBoundingBoxOffset is the offset from sprite center for collision.
 	EnemyBoundingBoxOffset = -8
+	// EnemyLightRadius is the glow radius of enemy engines.
+	EnemyLightRadius = 36.0
+	// EnemyLightIntensity is the brightness of enemy engine glow.
+	EnemyLightIntensity = 0.6
 )
 
+// enemyLightColor is the hostile red glow of enemy engines.
+var enemyLightColor = color.RGBA{R: 255, G: 90, B: 70, A: 255}
+
 // EnemyConfig describes an enemy to spawn.
 type EnemyConfig struct {
 	Health float64
@@ -49,6 +57,7 @@ type WaveSpawner struct {
 	generator    *Generator
 	screenWidth  float64
 	screenHeight float64
+	viewX, viewY float64
 }
 
 // NewWaveSpawner creates a new wave spawner.
@@ -61,6 +70,13 @@ func NewWaveSpawner(world *engine.World, generator *Generator, width, height int
 	}
 }
 
+// SetViewOrigin sets the world position of the view's top-left corner so
+// enemies spawn just outside the visible area in large worlds.
+func (ws *WaveSpawner) SetViewOrigin(x, y float64) {
+	ws.viewX = x
+	ws.viewY = y
+}
+
 // SpawnWave creates all enemies for the given wave number.
 func (ws *WaveSpawner) SpawnWave(waveNumber int) []engine.Entity {
 	config := ws.generator.GenerateWave(waveNumber)
@@ -90,7 +106,7 @@ func (ws *WaveSpawner) calculateEnemyStats(waveNumber int) EnemyConfig {
 	}
 }
 
-// randomOffscreenPosition returns a position just outside the screen.
+// randomOffscreenPosition returns a position just outside the view.
 func (ws *WaveSpawner) randomOffscreenPosition(rng *rand.Rand) (float64, float64) {
 	margin := SpawnMargin
 
@@ -113,7 +129,7 @@ func (ws *WaveSpawner) randomOffscreenPosition(rng *rand.Rand) (float64, float64
 		y = rng.Float64() * ws.screenHeight
 	}
 
-	return x, y
+	return ws.viewX + x, ws.viewY + y
 }
 
 // spawnEnemy creates a single enemy entity.
@@ -139,6 +155,10 @@ func (ws *WaveSpawner) spawnEnemy(x, y float64, config EnemyConfig, variant int)
 		Variant: variant % EnemyVariantCount,
 		Size:    EnemySpriteSizePx,
 	})
+	ws.world.AddComponent(e, "animation", rendering.NewAnimation())
+	ws.world.AddComponent(e, "light", &rendering.Light{
+		Radius: EnemyLightRadius, Color: enemyLightColor, Intensity: EnemyLightIntensity,
+	})
 
 	ws.world.AddComponent(e, "enemy", &EnemyAI{
 		State:  EnemyStateApproach,
@@ -170,11 +190,17 @@ type EnemyAI struct {
 type EnemyAISystem struct {
 	world        *engine.World
 	playerEntity engine.Entity
+	timeScale    float64
 }
 
 // NewEnemyAISystem creates a new enemy AI system.
 func NewEnemyAISystem(world *engine.World) *EnemyAISystem {
-	return &EnemyAISystem{world: world}
+	return &EnemyAISystem{world: world, timeScale: 1.0}
+}
+
+// SetTimeScale scales enemy movement speed (e.g. 0.5 while time slow is active).
+func (ais *EnemyAISystem) SetTimeScale(scale float64) {
+	ais.timeScale = scale
 }
 
 // SetPlayerEntity sets the player entity for AI targeting.
@@ -202,10 +228,23 @@ func (ais *EnemyAISystem) Update(dt float64) {
 		}
 
 		ai := aiComp.(*EnemyAI)
-		ais.updateEnemy(e, ai, targetPos, dt)
+		ais.updateEnemy(e, ai, ais.targetPosition(ai, targetPos), dt)
 	})
 }
 
+// targetPosition returns the position of the enemy's assigned target,
+// falling back to the player once the target is gone.
+func (ais *EnemyAISystem) targetPosition(ai *EnemyAI, player *engine.Position) *engine.Position {
+	if ai.Target == 0 {
+		return player
+	}
+	if posComp, ok := ais.world.GetComponent(ai.Target, "position"); ok {
+		return posComp.(*engine.Position)
+	}
+	ai.Target = 0
+	return player
+}
+
 // updateEnemy updates a single enemy's movement.
 func (ais *EnemyAISystem) updateEnemy(e engine.Entity, ai *EnemyAI, target *engine.Position, dt float64) {
 	posComp, hasPos := ais.world.GetComponent(e, "position")
@@ -230,13 +269,14 @@ func (ais *EnemyAISystem) updateEnemy(e engine.Entity, ai *EnemyAI, target *engi
 		dy /= dist
 
 		// Set velocity toward player
-		vel.VX = dx * ai.Speed
-		vel.VY = dy * ai.Speed
+		vel.VX = dx * ai.Speed * ais.timeScale
+		vel.VY = dy * ai.Speed * ais.timeScale
 
 		// Update rotation to face player
 		if hasRot {
 			rot := rotComp.(*engine.Rotation)
 			rot.Angle = math.Atan2(dy, dx)
+			ais.world.MarkChanged(e)
 		}
 	}
 }
@@ -55,6 +55,12 @@ func TestWaveSpawner_SpawnWave(t *testing.T) {
 		if _, ok := world.GetComponent(e, "collisiontag"); !ok {
 			t.Errorf("Enemy %d missing collision tag", i)
 		}
+		if _, ok := world.GetComponent(e, "animation"); !ok {
+			t.Errorf("enemy %d missing animation component", i)
+		}
+		if _, ok := world.GetComponent(e, "light"); !ok {
+			t.Errorf("enemy %d missing engine light", i)
+		}
 		if _, ok := world.GetComponent(e, "sprite"); !ok {
 			t.Errorf("Enemy %d missing sprite component", i)
 		}
@@ -125,6 +131,25 @@ func TestWaveSpawner_OffscreenPositions(t *testing.T) {
 	}
 }
 
+func TestWaveSpawner_SetViewOrigin(t *testing.T) {
+	world := engine.NewWorld()
+	spawner := NewWaveSpawner(world, NewGenerator(12345), 800, 600)
+	spawner.SetViewOrigin(1000, 2000)
+
+	for i, e := range spawner.SpawnWave(1) {
+		posComp, _ := world.GetComponent(e, "position")
+		pos := posComp.(*engine.Position)
+
+		// Enemies should ring the view, not the world origin
+		nearView := pos.X >= 1000-SpawnMargin && pos.X <= 1800+SpawnMargin &&
+			pos.Y >= 2000-SpawnMargin && pos.Y <= 2600+SpawnMargin
+		inView := pos.X > 1000 && pos.X < 1800 && pos.Y > 2000 && pos.Y < 2600
+		if !nearView || inView {
+			t.Errorf("Enemy %d at (%f, %f) should spawn just outside the view", i, pos.X, pos.Y)
+		}
+	}
+}
+
 func TestNewEnemyAISystem(t *testing.T) {
 	world := engine.NewWorld()
 	ais := NewEnemyAISystem(world)
@@ -178,6 +203,62 @@ func TestEnemyAISystem_Update_MovesTowardPlayer(t *testing.T) {
 	}
 }
 
+func TestEnemyAISystem_SetTimeScale(t *testing.T) {
+	world := engine.NewWorld()
+	ais := NewEnemyAISystem(world)
+	ais.SetTimeScale(0.5)
+
+	player := world.CreateEntity()
+	world.AddComponent(player, "position", &engine.Position{X: 400, Y: 300})
+	ais.SetPlayerEntity(player)
+
+	enemy := world.CreateEntity()
+	world.AddComponent(enemy, "position", &engine.Position{X: 100, Y: 300})
+	world.AddComponent(enemy, "velocity", &engine.Velocity{})
+	world.AddComponent(enemy, "enemy", &EnemyAI{State: EnemyStateApproach, Speed: 50.0})
+
+	ais.Update(1.0 / 60.0)
+
+	velComp, _ := world.GetComponent(enemy, "velocity")
+	if vx := velComp.(*engine.Velocity).VX; vx != 25.0 {
+		t.Errorf("expected slowed VX=25, got %f", vx)
+	}
+}
+
+func TestEnemyAISystem_Update_ChasesAssignedTarget(t *testing.T) {
+	world := engine.NewWorld()
+	ais := NewEnemyAISystem(world)
+
+	player := world.CreateEntity()
+	world.AddComponent(player, "position", &engine.Position{X: 400, Y: 300})
+	ais.SetPlayerEntity(player)
+
+	convoy := world.CreateEntity()
+	world.AddComponent(convoy, "position", &engine.Position{X: 0, Y: 300})
+
+	enemy := world.CreateEntity()
+	world.AddComponent(enemy, "position", &engine.Position{X: 100, Y: 300})
+	world.AddComponent(enemy, "velocity", &engine.Velocity{})
+	ai := &EnemyAI{State: EnemyStateApproach, Speed: 50.0, Target: convoy}
+	world.AddComponent(enemy, "enemy", ai)
+
+	ais.Update(1.0 / 60.0)
+	velComp, _ := world.GetComponent(enemy, "velocity")
+	if vx := velComp.(*engine.Velocity).VX; vx >= 0 {
+		t.Errorf("expected enemy to chase the convoy (VX < 0), got %f", vx)
+	}
+
+	// Once the target is gone the enemy returns to the player
+	world.RemoveEntity(convoy)
+	ais.Update(1.0 / 60.0)
+	if vx := velComp.(*engine.Velocity).VX; vx <= 0 {
+		t.Errorf("expected enemy to fall back to the player (VX > 0), got %f", vx)
+	}
+	if ai.Target != 0 {
+		t.Error("expected stale target to be cleared")
+	}
+}
+
 func TestEnemyAISystem_Update_NoPlayerNoMove(t *testing.T) {
 	world := engine.NewWorld()
 	ais := NewEnemyAISystem(world)
@@ -1,7 +1,10 @@
 // Package procgen provides procedural content generation systems.
 package procgen
 
-import "github.com/opd-ai/velocity/pkg/engine"
+import (
+	"github.com/opd-ai/velocity/pkg/engine"
+	"github.com/opd-ai/velocity/pkg/world"
+)
 
 // Difficulty scaling constants.
 const (
@@ -9,6 +12,9 @@ const (
 	BaseDifficultyMultiplier = 1.0
 	// DifficultyIncreasePerWave is the additional difficulty per wave.
 	DifficultyIncreasePerWave = 0.1
+	// ConvoyRaiderRatio is the fraction of enemies sent after the convoy
+	// during protect objectives (every Nth enemy).
+	ConvoyRaiderRatio = 2
 )
 
 // WaveManager handles wave progression and difficulty ramping.
@@ -20,6 +26,7 @@ type WaveManager struct {
 	totalKills     int
 	waveKills      int
 	waveInProgress bool
+	objectives     *world.ObjectiveSystem
 	onWaveComplete func(waveNumber int)
 	onWaveStart    func(waveNumber int)
 }
@@ -44,6 +51,19 @@ func (wm *WaveManager) SetWaveStartCallback(fn func(waveNumber int)) {
 	wm.onWaveStart = fn
 }
 
+// SetObjectiveSystem enables per-wave objectives tracked by the given system.
+func (wm *WaveManager) SetObjectiveSystem(objectives *world.ObjectiveSystem) {
+	wm.objectives = objectives
+}
+
+// CurrentObjective returns the active wave objective, or nil if objectives are disabled.
+func (wm *WaveManager) CurrentObjective() *world.Objective {
+	if wm.objectives == nil {
+		return nil
+	}
+	return wm.objectives.Current()
+}
+
 // CurrentWave returns the current wave number.
 func (wm *WaveManager) CurrentWave() int {
 	return wm.currentWave
@@ -65,13 +85,39 @@ func (wm *WaveManager) StartNextWave() {
 	wm.waveKills = 0
 	wm.waveInProgress = true
 
-	wm.spawner.SpawnWave(wm.currentWave)
+	enemies := wm.spawner.SpawnWave(wm.currentWave)
+	wm.startObjective(enemies)
 
 	if wm.onWaveStart != nil {
 		wm.onWaveStart(wm.currentWave)
 	}
 }
 
+// startObjective assigns the generated objective for the current wave.
+func (wm *WaveManager) startObjective(enemies []engine.Entity) {
+	if wm.objectives == nil {
+		return
+	}
+	gen := wm.spawner.generator
+	config := gen.GenerateWave(wm.currentWave)
+	objective := world.NewWaveObjective(config.Objective, config.ObjectiveGoal, wm.currentWave, gen.GenreID())
+	wm.objectives.Start(objective, enemies, config.Seed)
+
+	// Send raiders after the convoy so protecting it takes effort
+	convoy, ok := wm.objectives.Convoy()
+	if !ok {
+		return
+	}
+	for i, e := range enemies {
+		if i%ConvoyRaiderRatio != 0 {
+			continue
+		}
+		if aiComp, hasAI := wm.world.GetComponent(e, "enemy"); hasAI {
+			aiComp.(*EnemyAI).Target = convoy
+		}
+	}
+}
+
 // OnEnemyKilled should be called when an enemy is destroyed.
 func (wm *WaveManager) OnEnemyKilled() {
 	wm.totalKills++
@@ -84,17 +130,41 @@ func (wm *WaveManager) Update(dt float64) {
 		return
 	}
 
-	// Check if all enemies are destroyed
-	enemyCount := wm.aiSystem.CountEnemies()
-	if enemyCount == 0 {
+	if wm.objectives != nil {
+		wm.objectives.Update(dt)
+	}
+
+	// The wave ends when all enemies are destroyed or the objective ends it early
+	objectiveEnded := wm.objectives != nil && wm.objectives.EndsWave()
+	if objectiveEnded || wm.aiSystem.CountEnemies() == 0 {
 		wm.waveInProgress = false
 
+		if objectiveEnded {
+			wm.clearEnemies()
+		}
+		if wm.objectives != nil {
+			wm.objectives.Resolve()
+		}
+
 		if wm.onWaveComplete != nil {
 			wm.onWaveComplete(wm.currentWave)
 		}
 	}
 }
 
+// clearEnemies removes surviving enemies when an objective ends the wave early.
+func (wm *WaveManager) clearEnemies() {
+	var survivors []engine.Entity
+	wm.world.ForEachEntity(func(e engine.Entity) {
+		if _, hasAI := wm.world.GetComponent(e, "enemy"); hasAI {
+			survivors = append(survivors, e)
+		}
+	})
+	for _, e := range survivors {
+		wm.world.RemoveEntity(e)
+	}
+}
+
 // DifficultyMultiplier returns a scaling factor for the current wave.
 func (wm *WaveManager) DifficultyMultiplier() float64 {
 	// Linear difficulty ramp: BaseDifficultyMultiplier at wave 1, increasing DifficultyIncreasePerWave per wave
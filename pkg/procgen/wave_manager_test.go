@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/opd-ai/velocity/pkg/engine"
+	"github.com/opd-ai/velocity/pkg/world"
 )
 
 func TestNewWaveManager(t *testing.T) {
@@ -239,3 +240,75 @@ func removeAllEnemies(world *engine.World) {
 		world.RemoveEntity(e)
 	}
 }
+
+// findObjectiveWave returns the first wave that rolls the given objective.
+func findObjectiveWave(t *testing.T, gen *Generator, want world.ObjectiveType) int {
+	t.Helper()
+	for wave := ObjectiveStartWave; wave < ObjectiveStartWave+100; wave++ {
+		if gen.GenerateWave(wave).Objective == want {
+			return wave
+		}
+	}
+	t.Fatalf("no wave rolled objective %d", want)
+	return 0
+}
+
+func TestWaveManager_SurviveObjectiveEndsWave(t *testing.T) {
+	w := engine.NewWorld()
+	gen := NewGenerator(12345)
+	spawner := NewWaveSpawner(w, gen, 800, 600)
+	ai := NewEnemyAISystem(w)
+	wm := NewWaveManager(w, spawner, ai)
+	objectives := world.NewObjectiveSystem(w, 800, 600)
+	wm.SetObjectiveSystem(objectives)
+
+	bonus := int64(0)
+	objectives.SetCompleteCallback(func(o *world.Objective) { bonus = o.Reward })
+
+	target := findObjectiveWave(t, gen, world.ObjectiveSurvive)
+	wm.currentWave = target - 1
+	wm.StartNextWave()
+
+	objective := wm.CurrentObjective()
+	if objective == nil || objective.Type != world.ObjectiveSurvive {
+		t.Fatalf("expected survive objective, got %+v", objective)
+	}
+
+	wm.Update(objective.Goal + 1)
+	if wm.WaveInProgress() {
+		t.Error("expected wave to end when the survival timer elapses")
+	}
+	if ai.CountEnemies() != 0 {
+		t.Errorf("expected surviving enemies cleared, %d remain", ai.CountEnemies())
+	}
+	if bonus != objective.Reward || bonus == 0 {
+		t.Errorf("expected bonus %d, got %d", objective.Reward, bonus)
+	}
+}
+
+func TestWaveManager_ProtectObjectiveSendsRaiders(t *testing.T) {
+	w := engine.NewWorld()
+	gen := NewGenerator(12345)
+	spawner := NewWaveSpawner(w, gen, 800, 600)
+	ai := NewEnemyAISystem(w)
+	wm := NewWaveManager(w, spawner, ai)
+	objectives := world.NewObjectiveSystem(w, 800, 600)
+	wm.SetObjectiveSystem(objectives)
+
+	wm.currentWave = findObjectiveWave(t, gen, world.ObjectiveProtect) - 1
+	wm.StartNextWave()
+
+	convoy, ok := objectives.Convoy()
+	if !ok {
+		t.Fatal("expected convoy spawned")
+	}
+	raiders := 0
+	w.ForEachEntity(func(e engine.Entity) {
+		if comp, ok := w.GetComponent(e, "enemy"); ok && comp.(*EnemyAI).Target == convoy {
+			raiders++
+		}
+	})
+	if raiders == 0 {
+		t.Error("expected some enemies to target the convoy")
+	}
+}
@@ -0,0 +1,341 @@
+package rendering
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+// AnimState is the behavior an animated sprite is showing.
+type AnimState int
+
+const (
+	AnimIdle AnimState = iota
+	AnimThrust
+	AnimHit
+	AnimDying
+	AnimStateCount
+)
+
+// Animation timing constants.
+const (
+	// AnimFramesPerState is the number of generated frames for each state.
+	AnimFramesPerState = 4
+	// DefaultFrameDuration is how long each frame is shown, in seconds.
+	DefaultFrameDuration = 0.08
+)
+
+// Animation frame effect constants.
+const (
+	// CorePulseBrightness is the peak blend toward white, out of 255, of an idle sprite's core.
+	CorePulseBrightness = 60
+	// HitWhiteBlend is how far hit frames blend toward white (0-1).
+	HitWhiteBlend = 0.7
+)
+
+// String returns the state name.
+func (s AnimState) String() string {
+	switch s {
+	case AnimIdle:
+		return "idle"
+	case AnimThrust:
+		return "thrust"
+	case AnimHit:
+		return "hit"
+	case AnimDying:
+		return "dying"
+	default:
+		return "unknown"
+	}
+}
+
+// oneShot reports whether a state plays once instead of looping.
+func (s AnimState) oneShot() bool {
+	return s == AnimHit || s == AnimDying
+}
+
+// Animation is a component that steps an entity's sprite through frames.
+// Idle and thrust loop; hit plays once and returns to the looping state it
+// interrupted; dying plays once and holds its last frame.
+type Animation struct {
+	State         AnimState
+	Frame         int
+	FrameDuration float64
+	Finished      bool
+
+	timer  float64
+	resume AnimState
+}
+
+// NewAnimation creates an idle animation with the default frame duration.
+func NewAnimation() *Animation {
+	return &Animation{FrameDuration: DefaultFrameDuration}
+}
+
+// Play switches to a state. Looping states requested during a one-shot are
+// queued until it ends, and nothing interrupts dying.
+func (a *Animation) Play(s AnimState) {
+	switch {
+	case a.State == AnimDying:
+		return
+	case !s.oneShot() && a.State.oneShot():
+		a.resume = s
+		return
+	case !s.oneShot() && a.State == s:
+		return
+	case s.oneShot() && !a.State.oneShot():
+		a.resume = a.State
+	}
+	a.State = s
+	a.Frame = 0
+	a.timer = 0
+}
+
+// Update advances the animation by dt seconds.
+func (a *Animation) Update(dt float64) {
+	if a.Finished || a.FrameDuration <= 0 {
+		return
+	}
+	a.timer += dt
+	for a.timer >= a.FrameDuration {
+		a.timer -= a.FrameDuration
+		a.advance()
+		if a.Finished {
+			return
+		}
+	}
+}
+
+// advance moves to the next frame, handling loops and one-shot endings.
+func (a *Animation) advance() {
+	if a.Frame < AnimFramesPerState-1 {
+		a.Frame++
+		return
+	}
+	switch a.State {
+	case AnimDying:
+		a.Finished = true
+	case AnimHit:
+		a.State = a.resume
+		a.Frame = 0
+	default:
+		a.Frame = 0
+	}
+}
+
+// FrameIndex returns the sprite cache frame for the current state and frame.
+// Index 0 is reserved for the static, unanimated sprite.
+func (a *Animation) FrameIndex() int {
+	return AnimFrameIndex(a.State, a.Frame)
+}
+
+// AnimFrameIndex returns the sprite cache frame for a state and frame.
+func AnimFrameIndex(s AnimState, frame int) int {
+	return int(s)*AnimFramesPerState + frame + 1
+}
+
+// AnimFrameFromIndex splits a sprite cache frame back into state and frame.
+func AnimFrameFromIndex(index int) (AnimState, int) {
+	index--
+	return AnimState(index / AnimFramesPerState), index % AnimFramesPerState
+}
+
+// AnimationSystem advances animations and removes entities whose dying
+// animation has finished.
+type AnimationSystem struct {
+	world    *engine.World
+	toRemove []engine.Entity
+}
+
+// NewAnimationSystem creates an animation system for the given world.
+func NewAnimationSystem(world *engine.World) *AnimationSystem {
+	return &AnimationSystem{
+		world:    world,
+		toRemove: make([]engine.Entity, 0, 8),
+	}
+}
+
+// Update advances every animation by dt seconds.
+func (as *AnimationSystem) Update(dt float64) {
+	as.toRemove = as.toRemove[:0]
+	as.world.ForEachEntity(func(e engine.Entity) {
+		comp, ok := as.world.GetComponent(e, "animation")
+		if !ok {
+			return
+		}
+		anim := comp.(*Animation)
+		anim.Update(dt)
+		if anim.State == AnimDying && anim.Finished {
+			as.toRemove = append(as.toRemove, e)
+		}
+	})
+	for _, e := range as.toRemove {
+		as.world.RemoveEntity(e)
+	}
+}
+
+// GenerateAnimationFrame derives one animation frame from a static sprite.
+// Sprites face up (nose at the top row), so thrust exhaust is drawn below.
+func GenerateAnimationFrame(base *image.RGBA, s AnimState, frame int) *image.RGBA {
+	switch s {
+	case AnimThrust:
+		return drawThrustFrame(base, frame)
+	case AnimHit:
+		return drawHitFrame(base, frame)
+	case AnimDying:
+		return drawDyingFrame(base, frame)
+	default:
+		return drawIdleFrame(base, frame)
+	}
+}
+
+// framePhase returns a smooth 0..1..0 cycle over the frames of a state.
+func framePhase(frame int) float64 {
+	return (1 - math.Cos(2*math.Pi*float64(frame)/AnimFramesPerState)) / 2
+}
+
+// drawIdleFrame pulses the sprite's core and flaps its outer columns.
+func drawIdleFrame(base *image.RGBA, frame int) *image.RGBA {
+	size := base.Bounds().Dx()
+	img := image.NewRGBA(base.Bounds())
+	center := float64(size-1) / 2
+	coreRadius := float64(size) / 4
+	pulse := CorePulseBrightness * framePhase(frame)
+
+	// Wings (outer quarter columns) bob up, rest, down, rest
+	flap := [AnimFramesPerState]int{0, -1, 0, 1}[frame%AnimFramesPerState]
+	wing := size / 4
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			srcY := y
+			if x < wing || x >= size-wing {
+				srcY = y - flap
+			}
+			if srcY < 0 || srcY >= size {
+				continue
+			}
+			c := base.RGBAAt(x, srcY)
+			if c.A == 0 {
+				continue
+			}
+			if math.Hypot(float64(x)-center, float64(y)-center) <= coreRadius {
+				c = blendColor(c, color.RGBA{R: 255, G: 255, B: 255}, pulse/255)
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// drawThrustFrame lights the engine at the rear of the hull and adds a
+// flickering plume below it where there is room.
+func drawThrustFrame(base *image.RGBA, frame int) *image.RGBA {
+	img := cloneRGBA(base)
+	size := base.Bounds().Dx()
+	mid := size / 2
+
+	// Find the lowest hull pixel near the center line
+	bottom := -1
+	for y := size - 1; y >= 0 && bottom < 0; y-- {
+		if base.RGBAAt(mid, y).A > 0 || base.RGBAAt(mid-1, y).A > 0 {
+			bottom = y
+		}
+	}
+	if bottom < 0 {
+		bottom = size / 2
+	}
+
+	// Glow strength and plume length flicker between frames
+	heat := [AnimFramesPerState]float64{0.4, 0.9, 0.6, 1.0}[frame%AnimFramesPerState]
+	length := 1 + [AnimFramesPerState]int{0, 2, 1, 3}[frame%AnimFramesPerState]
+	hot := color.RGBA{R: 255, G: 240, B: 160, A: 255}
+	cool := color.RGBA{R: 255, G: 120, B: 40, A: 200}
+
+	for y := bottom - 1; y <= bottom+length && y < size; y++ {
+		if y < 0 {
+			continue
+		}
+		for x := mid - 1; x <= mid; x++ {
+			c := img.RGBAAt(x, y)
+			switch {
+			case y <= bottom && c.A > 0:
+				img.SetRGBA(x, y, blendColor(c, hot, heat))
+			case y > bottom && c.A == 0:
+				flame := hot
+				if y-bottom > length/2 {
+					flame = cool
+				}
+				img.SetRGBA(x, y, flame)
+			}
+		}
+	}
+	return img
+}
+
+// drawHitFrame alternates between a whitened and a normal sprite.
+func drawHitFrame(base *image.RGBA, frame int) *image.RGBA {
+	img := cloneRGBA(base)
+	if frame%2 == 1 {
+		return img
+	}
+	for i := 0; i < len(img.Pix); i += 4 {
+		if img.Pix[i+3] == 0 {
+			continue
+		}
+		for c := 0; c < 3; c++ {
+			v := float64(img.Pix[i+c])
+			img.Pix[i+c] = uint8(v + (255-v)*HitWhiteBlend)
+		}
+	}
+	return img
+}
+
+// drawDyingFrame dissolves the sprite in a fixed pixel order, scorching and
+// fading the pixels that remain.
+func drawDyingFrame(base *image.RGBA, frame int) *image.RGBA {
+	size := base.Bounds().Dx()
+	img := image.NewRGBA(base.Bounds())
+	cutoff := float64(frame+1) / float64(AnimFramesPerState+1)
+	fade := 1 - cutoff
+	ember := color.RGBA{R: 255, G: 140, B: 40, A: 255}
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			c := base.RGBAAt(x, y)
+			if c.A == 0 || dissolveOrder(x, y) < cutoff {
+				continue
+			}
+			c = blendColor(c, ember, cutoff)
+			c.A = uint8(float64(c.A) * fade)
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// dissolveOrder returns a stable pseudo-random value in [0, 1) per pixel.
+func dissolveOrder(x, y int) float64 {
+	h := uint32(x)*73856093 ^ uint32(y)*19349663
+	h ^= h >> 13
+	h *= 0x5bd1e995
+	h ^= h >> 15
+	return float64(h%1024) / 1024
+}
+
+// blendColor linearly interpolates from a toward b by t, keeping a's alpha.
+func blendColor(a, b color.RGBA, t float64) color.RGBA {
+	mix := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*t)
+	}
+	return color.RGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: a.A}
+}
+
+// cloneRGBA returns a copy of an image.
+func cloneRGBA(src *image.RGBA) *image.RGBA {
+	dst := image.NewRGBA(src.Bounds())
+	copy(dst.Pix, src.Pix)
+	return dst
+}
@@ -0,0 +1,127 @@
+package rendering
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+func TestAnimation_LoopsIdle(t *testing.T) {
+	a := NewAnimation()
+	for i := 0; i < AnimFramesPerState; i++ {
+		a.Update(a.FrameDuration)
+	}
+	if a.State != AnimIdle || a.Frame != 0 || a.Finished {
+		t.Errorf("expected idle to loop back to frame 0, got %v frame %d", a.State, a.Frame)
+	}
+}
+
+func TestAnimation_HitReturnsToInterruptedState(t *testing.T) {
+	a := NewAnimation()
+	a.Play(AnimThrust)
+	a.Play(AnimHit)
+	if a.State != AnimHit {
+		t.Fatalf("expected hit state, got %v", a.State)
+	}
+
+	// Looping requests during the one-shot are queued
+	a.Play(AnimIdle)
+	if a.State != AnimHit {
+		t.Fatal("expected hit not to be interrupted by idle")
+	}
+
+	for i := 0; i < AnimFramesPerState; i++ {
+		a.Update(a.FrameDuration)
+	}
+	if a.State != AnimIdle || a.Frame != 0 {
+		t.Errorf("expected return to queued idle after hit, got %v frame %d", a.State, a.Frame)
+	}
+}
+
+func TestAnimation_DyingHoldsAndFinishes(t *testing.T) {
+	a := NewAnimation()
+	a.Play(AnimDying)
+	a.Play(AnimHit)
+	if a.State != AnimDying {
+		t.Fatal("expected nothing to interrupt dying")
+	}
+	a.Update(10)
+	if !a.Finished || a.Frame != AnimFramesPerState-1 {
+		t.Errorf("expected dying to hold its last frame, got frame %d finished=%v", a.Frame, a.Finished)
+	}
+}
+
+func TestAnimFrameIndex_RoundTrip(t *testing.T) {
+	seen := map[int]bool{0: true}
+	for s := AnimIdle; s < AnimStateCount; s++ {
+		for f := 0; f < AnimFramesPerState; f++ {
+			index := AnimFrameIndex(s, f)
+			if seen[index] {
+				t.Fatalf("frame index %d reused", index)
+			}
+			seen[index] = true
+			if gs, gf := AnimFrameFromIndex(index); gs != s || gf != f {
+				t.Errorf("AnimFrameFromIndex(%d) = (%v, %d), want (%v, %d)", index, gs, gf, s, f)
+			}
+		}
+	}
+}
+
+func TestAnimationSystem_RemovesFinishedDying(t *testing.T) {
+	world := engine.NewWorld()
+	sys := NewAnimationSystem(world)
+
+	ghost := world.CreateEntity()
+	dying := NewAnimation()
+	dying.Play(AnimDying)
+	world.AddComponent(ghost, "animation", dying)
+
+	ship := world.CreateEntity()
+	world.AddComponent(ship, "animation", NewAnimation())
+
+	sys.Update(1)
+	if _, ok := world.GetComponent(ghost, "animation"); ok {
+		t.Error("expected finished dying entity removed")
+	}
+	if _, ok := world.GetComponent(ship, "animation"); !ok {
+		t.Error("expected looping entity kept")
+	}
+}
+
+func TestGenerateAnimationFrame(t *testing.T) {
+	base := GenerateShipSprite(rand.New(rand.NewSource(5)), "scifi", 16)
+
+	for s := AnimIdle; s < AnimStateCount; s++ {
+		frames := make([][]byte, AnimFramesPerState)
+		for f := range frames {
+			img := GenerateAnimationFrame(base, s, f)
+			if img.Bounds() != base.Bounds() {
+				t.Fatalf("%v frame %d: bounds %v, want %v", s, f, img.Bounds(), base.Bounds())
+			}
+			frames[f] = img.Pix
+		}
+		if bytes.Equal(frames[0], frames[1]) && bytes.Equal(frames[1], frames[2]) {
+			t.Errorf("%v: expected frames to differ", s)
+		}
+	}
+
+	if !bytes.Equal(GenerateAnimationFrame(base, AnimThrust, 1).Pix, GenerateAnimationFrame(base, AnimThrust, 1).Pix) {
+		t.Error("expected frame generation to be deterministic")
+	}
+}
+
+func TestRenderer_GetOrCreateAnimationFrame(t *testing.T) {
+	r := NewRenderer()
+	base := r.GetOrCreateShipSprite(0, 16)
+
+	if r.GetOrCreateAnimationFrame(base, SpriteTypeShip, 0, 0) != base {
+		t.Error("expected frame 0 to be the static sprite")
+	}
+	frame := AnimFrameIndex(AnimThrust, 2)
+	first := r.GetOrCreateAnimationFrame(base, SpriteTypeShip, 0, frame)
+	if first == base || r.GetOrCreateAnimationFrame(base, SpriteTypeShip, 0, frame) != first {
+		t.Error("expected animation frames cached separately from the base sprite")
+	}
+}
@@ -0,0 +1,212 @@
+package rendering
+
+import (
+	"image"
+	"image/draw"
+	"math"
+)
+
+// Atlas packing constants.
+const (
+	// DefaultAtlasPageSize is the width and height of each atlas page in pixels.
+	DefaultAtlasPageSize = 1024
+	// AtlasPadding is the transparent gutter kept around each sprite so
+	// rotated or scaled draws never sample a neighbor.
+	AtlasPadding = 2
+)
+
+// AtlasRegion locates a packed sprite within an atlas page.
+type AtlasRegion struct {
+	Page int
+	Rect image.Rectangle
+}
+
+// atlasShelf is a horizontal strip of a page holding sprites up to its height.
+type atlasShelf struct {
+	y, height, nextX int
+}
+
+// atlasPage is one texture of the atlas and the shelves packed into it.
+type atlasPage struct {
+	img     *image.RGBA
+	shelves []atlasShelf
+	nextY   int
+}
+
+// Atlas packs generated sprites into a few large pages so many different
+// sprites can be drawn from the same texture in one call. Sprites are placed
+// with a shelf packer and never move once added.
+type Atlas struct {
+	pageSize int
+	pages    []*atlasPage
+	regions  map[string]AtlasRegion
+}
+
+// NewAtlas creates an empty atlas with square pages of the given size.
+func NewAtlas(pageSize int) *Atlas {
+	return &Atlas{
+		pageSize: pageSize,
+		regions:  make(map[string]AtlasRegion),
+	}
+}
+
+// Lookup returns the region of a previously added sprite.
+func (a *Atlas) Lookup(key string) (AtlasRegion, bool) {
+	r, ok := a.regions[key]
+	return r, ok
+}
+
+// Add packs img under key and returns its region. Adding an existing key
+// returns the original region without copying. It returns false when the
+// sprite cannot fit on a page.
+func (a *Atlas) Add(key string, img *image.RGBA) (AtlasRegion, bool) {
+	if r, ok := a.regions[key]; ok {
+		return r, true
+	}
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	if w == 0 || h == 0 || w+2*AtlasPadding > a.pageSize || h+2*AtlasPadding > a.pageSize {
+		return AtlasRegion{}, false
+	}
+
+	for i, page := range a.pages {
+		if x, y, ok := page.place(w+AtlasPadding, h+AtlasPadding, a.pageSize); ok {
+			return a.store(key, i, x, y, img), true
+		}
+	}
+	a.pages = append(a.pages, &atlasPage{
+		img:   image.NewRGBA(image.Rect(0, 0, a.pageSize, a.pageSize)),
+		nextY: AtlasPadding,
+	})
+	i := len(a.pages) - 1
+	x, y, _ := a.pages[i].place(w+AtlasPadding, h+AtlasPadding, a.pageSize)
+	return a.store(key, i, x, y, img), true
+}
+
+// store copies img into a page and records its region.
+func (a *Atlas) store(key string, page, x, y int, img *image.RGBA) AtlasRegion {
+	rect := image.Rect(x, y, x+img.Bounds().Dx(), y+img.Bounds().Dy())
+	draw.Draw(a.pages[page].img, rect, img, img.Bounds().Min, draw.Src)
+	r := AtlasRegion{Page: page, Rect: rect}
+	a.regions[key] = r
+	return r
+}
+
+// place finds room for a w x h cell (padding included) on the page, reusing
+// a shelf that is tall enough or opening a new one.
+func (p *atlasPage) place(w, h, pageSize int) (int, int, bool) {
+	for i := range p.shelves {
+		s := &p.shelves[i]
+		if h <= s.height && s.nextX+w <= pageSize {
+			x := s.nextX
+			s.nextX += w
+			return x, s.y, true
+		}
+	}
+	if p.nextY+h > pageSize {
+		return 0, 0, false
+	}
+	s := atlasShelf{y: p.nextY, height: h, nextX: AtlasPadding + w}
+	p.shelves = append(p.shelves, s)
+	p.nextY += h
+	return AtlasPadding, s.y, true
+}
+
+// Page returns the pixels of an atlas page.
+func (a *Atlas) Page(i int) *image.RGBA {
+	return a.pages[i].img
+}
+
+// PageCount returns the number of pages in use.
+func (a *Atlas) PageCount() int {
+	return len(a.pages)
+}
+
+// Len returns the number of packed sprites.
+func (a *Atlas) Len() int {
+	return len(a.regions)
+}
+
+// packedPixels returns img's pixels without row padding, as texture uploads
+// expect.
+func packedPixels(img *image.RGBA) []byte {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	if img.Stride == 4*w {
+		return img.Pix[:4*w*h]
+	}
+	pix := make([]byte, 0, 4*w*h)
+	for y := 0; y < h; y++ {
+		start := y * img.Stride
+		pix = append(pix, img.Pix[start:start+4*w]...)
+	}
+	return pix
+}
+
+// BatchVertex is a textured, tinted vertex of a sprite quad. Colors are
+// premultiplied by alpha.
+type BatchVertex struct {
+	DstX, DstY                     float32
+	SrcX, SrcY                     float32
+	ColorR, ColorG, ColorB, ColorA float32
+}
+
+// BatchRun is a contiguous range of indices that all sample the same page
+// and can be drawn in a single call.
+type BatchRun struct {
+	Page       int
+	Start, End int
+}
+
+// SpriteBatch collects sprite quads in draw order and splits them into runs
+// by atlas page, so a frame's sprites need only as many draw calls as page
+// switches.
+type SpriteBatch struct {
+	Vertices []BatchVertex
+	Indices  []uint32
+	runs     []BatchRun
+}
+
+// Reset empties the batch, keeping its buffers for reuse.
+func (b *SpriteBatch) Reset() {
+	b.Vertices = b.Vertices[:0]
+	b.Indices = b.Indices[:0]
+	b.runs = b.runs[:0]
+}
+
+// Add appends region as a quad centered on (x, y), scaled and rotated by
+// angle radians about its center and tinted by the premultiplied color
+// (r, g, bl, a).
+func (b *SpriteBatch) Add(region AtlasRegion, x, y, scale, angle float64, r, g, bl, a float32) {
+	w := float64(region.Rect.Dx())
+	h := float64(region.Rect.Dy())
+	sin, cos := math.Sincos(angle)
+	src := region.Rect
+
+	base := uint32(len(b.Vertices))
+	for _, corner := range [4][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+		lx := (float64(corner[0]) - 0.5) * w * scale
+		ly := (float64(corner[1]) - 0.5) * h * scale
+		b.Vertices = append(b.Vertices, BatchVertex{
+			DstX:   float32(x + lx*cos - ly*sin),
+			DstY:   float32(y + lx*sin + ly*cos),
+			SrcX:   float32(src.Min.X + corner[0]*src.Dx()),
+			SrcY:   float32(src.Min.Y + corner[1]*src.Dy()),
+			ColorR: r, ColorG: g, ColorB: bl, ColorA: a,
+		})
+	}
+
+	if n := len(b.runs); n == 0 || b.runs[n-1].Page != region.Page {
+		b.runs = append(b.runs, BatchRun{Page: region.Page, Start: len(b.Indices), End: len(b.Indices)})
+	}
+	b.Indices = append(b.Indices, base, base+1, base+2, base+1, base+3, base+2)
+	b.runs[len(b.runs)-1].End = len(b.Indices)
+}
+
+// Runs returns the page runs in draw order.
+func (b *SpriteBatch) Runs() []BatchRun {
+	return b.runs
+}
+
+// Len returns the number of quads in the batch.
+func (b *SpriteBatch) Len() int {
+	return len(b.Indices) / 6
+}
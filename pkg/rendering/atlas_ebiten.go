@@ -0,0 +1,68 @@
+//go:build !noebiten
+
+package rendering
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// AtlasRenderer uploads atlas pages to the GPU and draws sprite batches from
+// them, one DrawTriangles call per page run.
+type AtlasRenderer struct {
+	atlas    *Atlas
+	pages    []*ebiten.Image
+	vertices []ebiten.Vertex
+}
+
+// NewAtlasRenderer creates an atlas renderer with pages of the given size.
+func NewAtlasRenderer(pageSize int) *AtlasRenderer {
+	return &AtlasRenderer{atlas: NewAtlas(pageSize)}
+}
+
+// Region returns the atlas region for key, packing and uploading img the
+// first time the key is seen. It returns false if img does not fit a page.
+func (ar *AtlasRenderer) Region(key string, img *image.RGBA) (AtlasRegion, bool) {
+	if r, ok := ar.atlas.Lookup(key); ok {
+		return r, true
+	}
+	if img == nil {
+		return AtlasRegion{}, false
+	}
+	r, ok := ar.atlas.Add(key, img)
+	if !ok {
+		return r, false
+	}
+	for len(ar.pages) < ar.atlas.PageCount() {
+		ar.pages = append(ar.pages, ebiten.NewImage(ar.atlas.pageSize, ar.atlas.pageSize))
+	}
+	ar.pages[r.Page].SubImage(r.Rect).(*ebiten.Image).WritePixels(packedPixels(img))
+	return r, true
+}
+
+// Image returns a sprite's region as an image for one-off draws.
+func (ar *AtlasRenderer) Image(r AtlasRegion) *ebiten.Image {
+	return ar.pages[r.Page].SubImage(r.Rect).(*ebiten.Image)
+}
+
+// Flush draws every quad in the batch onto dst and returns the number of
+// draw calls issued. The batch's colors are premultiplied.
+func (ar *AtlasRenderer) Flush(dst *ebiten.Image, batch *SpriteBatch, blend ebiten.Blend) int {
+	ar.vertices = ar.vertices[:0]
+	for _, v := range batch.Vertices {
+		ar.vertices = append(ar.vertices, ebiten.Vertex{
+			DstX: v.DstX, DstY: v.DstY,
+			SrcX: v.SrcX, SrcY: v.SrcY,
+			ColorR: v.ColorR, ColorG: v.ColorG, ColorB: v.ColorB, ColorA: v.ColorA,
+		})
+	}
+	opts := &ebiten.DrawTrianglesOptions{
+		ColorScaleMode: ebiten.ColorScaleModePremultipliedAlpha,
+		Blend:          blend,
+	}
+	for _, run := range batch.Runs() {
+		dst.DrawTriangles32(ar.vertices, batch.Indices[run.Start:run.End], ar.pages[run.Page], opts)
+	}
+	return len(batch.Runs())
+}
@@ -0,0 +1,148 @@
+package rendering
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func solidSprite(w, h int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = c.R, c.G, c.B, c.A
+	}
+	return img
+}
+
+func TestAtlas_AddCopiesPixels(t *testing.T) {
+	a := NewAtlas(64)
+	red := color.RGBA{R: 255, A: 255}
+	r, ok := a.Add("red", solidSprite(8, 6, red))
+	if !ok {
+		t.Fatal("expected sprite to fit")
+	}
+	if r.Rect.Dx() != 8 || r.Rect.Dy() != 6 {
+		t.Errorf("expected 8x6 region, got %v", r.Rect)
+	}
+	page := a.Page(r.Page)
+	if got := page.RGBAAt(r.Rect.Min.X, r.Rect.Min.Y); got != red {
+		t.Errorf("expected copied pixel, got %v", got)
+	}
+	// The gutter around the sprite stays transparent
+	if got := page.RGBAAt(r.Rect.Min.X-1, r.Rect.Min.Y); got.A != 0 {
+		t.Errorf("expected transparent padding, got %v", got)
+	}
+}
+
+func TestAtlas_AddIsIdempotent(t *testing.T) {
+	a := NewAtlas(64)
+	first, _ := a.Add("ship", solidSprite(8, 8, color.RGBA{A: 255}))
+	second, _ := a.Add("ship", solidSprite(16, 16, color.RGBA{A: 255}))
+	if first != second || a.Len() != 1 {
+		t.Errorf("expected existing region, got %v then %v", first, second)
+	}
+	if r, ok := a.Lookup("ship"); !ok || r != first {
+		t.Error("expected Lookup to find the sprite")
+	}
+	if _, ok := a.Lookup("missing"); ok {
+		t.Error("expected Lookup to miss an unknown key")
+	}
+}
+
+func TestAtlas_RegionsDoNotOverlap(t *testing.T) {
+	a := NewAtlas(128)
+	var regions []AtlasRegion
+	for i := 0; i < 60; i++ {
+		size := 8 + i%4*6
+		r, ok := a.Add(fmt.Sprint(i), solidSprite(size, size, color.RGBA{A: 255}))
+		if !ok {
+			t.Fatalf("sprite %d did not fit", i)
+		}
+		if !r.Rect.In(image.Rect(0, 0, 128, 128)) {
+			t.Fatalf("region %v outside page", r.Rect)
+		}
+		for _, other := range regions {
+			if other.Page == r.Page && other.Rect.Inset(-AtlasPadding+1).Overlaps(r.Rect) {
+				t.Fatalf("regions %v and %v overlap or share padding", other.Rect, r.Rect)
+			}
+		}
+		regions = append(regions, r)
+	}
+	if a.PageCount() < 2 {
+		t.Errorf("expected overflow onto a second page, got %d pages", a.PageCount())
+	}
+}
+
+func TestAtlas_RejectsOversized(t *testing.T) {
+	a := NewAtlas(32)
+	if _, ok := a.Add("huge", solidSprite(40, 8, color.RGBA{A: 255})); ok {
+		t.Error("expected sprite wider than a page to be rejected")
+	}
+	if a.PageCount() != 0 {
+		t.Errorf("expected no pages, got %d", a.PageCount())
+	}
+}
+
+func TestPackedPixels(t *testing.T) {
+	img := solidSprite(4, 4, color.RGBA{R: 9, A: 255})
+	sub := img.SubImage(image.Rect(1, 1, 3, 3)).(*image.RGBA)
+	pix := packedPixels(sub)
+	if len(pix) != 2*2*4 {
+		t.Fatalf("expected 16 bytes, got %d", len(pix))
+	}
+	if pix[0] != 9 || pix[15] != 255 {
+		t.Errorf("unexpected pixels %v", pix)
+	}
+}
+
+func TestSpriteBatch_RunsSplitOnPageChange(t *testing.T) {
+	var b SpriteBatch
+	p0 := AtlasRegion{Page: 0, Rect: image.Rect(0, 0, 8, 8)}
+	p1 := AtlasRegion{Page: 1, Rect: image.Rect(0, 0, 8, 8)}
+	for _, r := range []AtlasRegion{p0, p0, p1, p1, p1, p0} {
+		b.Add(r, 0, 0, 1, 0, 1, 1, 1, 1)
+	}
+	runs := b.Runs()
+	if len(runs) != 3 {
+		t.Fatalf("expected 3 runs, got %d", len(runs))
+	}
+	if runs[0].End-runs[0].Start != 12 || runs[1].End-runs[1].Start != 18 || runs[2].Page != 0 {
+		t.Errorf("unexpected runs %+v", runs)
+	}
+	if b.Len() != 6 {
+		t.Errorf("expected 6 quads, got %d", b.Len())
+	}
+
+	b.Reset()
+	if b.Len() != 0 || len(b.Runs()) != 0 || len(b.Vertices) != 0 {
+		t.Error("expected Reset to empty the batch")
+	}
+}
+
+func TestSpriteBatch_QuadGeometry(t *testing.T) {
+	var b SpriteBatch
+	r := AtlasRegion{Rect: image.Rect(10, 20, 18, 24)}
+	b.Add(r, 100, 50, 2, math.Pi/2, 0.5, 0.5, 0.5, 0.5)
+
+	// An 8x4 sprite at scale 2 rotated a quarter turn spans 8 wide and 16 tall
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, v := range b.Vertices {
+		minX, maxX = math.Min(minX, float64(v.DstX)), math.Max(maxX, float64(v.DstX))
+		minY, maxY = math.Min(minY, float64(v.DstY)), math.Max(maxY, float64(v.DstY))
+	}
+	if math.Abs(maxX-minX-8) > 1e-3 || math.Abs(maxY-minY-16) > 1e-3 {
+		t.Errorf("expected 8x16 footprint, got %vx%v", maxX-minX, maxY-minY)
+	}
+	if math.Abs((minX+maxX)/2-100) > 1e-3 || math.Abs((minY+maxY)/2-50) > 1e-3 {
+		t.Errorf("expected quad centered on (100, 50)")
+	}
+	if b.Vertices[0].SrcX != 10 || b.Vertices[0].SrcY != 20 || b.Vertices[3].SrcX != 18 || b.Vertices[3].SrcY != 24 {
+		t.Errorf("unexpected texture coordinates %+v", b.Vertices)
+	}
+	if b.Vertices[0].ColorA != 0.5 {
+		t.Errorf("expected vertex color to carry tint")
+	}
+}
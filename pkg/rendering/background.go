@@ -0,0 +1,341 @@
+package rendering
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/opd-ai/velocity/pkg/procgen/genre"
+)
+
+// Background generation constants.
+const (
+	// BackgroundSeedOffset separates background randomness from sprite randomness.
+	BackgroundSeedOffset = 7919
+	// NoiseOctaves is the number of value noise layers summed for nebulas and fog.
+	NoiseOctaves = 3
+)
+
+// BackgroundLayer is one pre-rendered parallax layer. Layers tile
+// horizontally; horizon layers such as skylines stay anchored to the bottom
+// of the screen instead of tiling vertically.
+type BackgroundLayer struct {
+	Image    *image.RGBA
+	Parallax float64 // Fraction of camera movement the layer follows; 0 stays fixed
+	RepeatY  bool
+}
+
+// Offset returns where to draw the layer's first tile for a camera at
+// (camX, camY). Both values are in (-size, 0]; a non-repeating layer always
+// returns 0 for y.
+func (l BackgroundLayer) Offset(camX, camY float64) (float64, float64) {
+	b := l.Image.Bounds()
+	x := -wrapFloat(camX*l.Parallax, float64(b.Dx()))
+	if !l.RepeatY {
+		return x, 0
+	}
+	return x, -wrapFloat(camY*l.Parallax, float64(b.Dy()))
+}
+
+// GenerateBackground creates the genre's parallax layers, farthest first,
+// each w by h pixels. The same seed always produces the same layers.
+func GenerateBackground(genreID string, seed int64, w, h int) []BackgroundLayer {
+	rng := rand.New(rand.NewSource(seed + BackgroundSeedOffset))
+	palette := getPalette(genreID, sciFiFallbackPalette)
+
+	switch genreID {
+	case genre.Fantasy:
+		return []BackgroundLayer{
+			{Image: starLayer(rng, w, h, w*h/6000, palette[3], 0.5), Parallax: 0.05, RepeatY: true},
+			{Image: noiseLayer(rng, w, h, 4, palette[3], 0.5, 0.35), Parallax: 0.15, RepeatY: true},
+			{Image: castleLayer(rng, w, h, darkenColor(palette[2], 0.25)), Parallax: 0.3},
+		}
+	case genre.Horror:
+		return []BackgroundLayer{
+			{Image: noiseLayer(rng, w, h, 3, palette[0], 0.4, 0.45), Parallax: 0.08, RepeatY: true},
+			{Image: spireLayer(rng, w, h, darkenColor(palette[3], 0.5)), Parallax: 0.25},
+			{Image: noiseLayer(rng, w, h, 5, palette[4], 0.55, 0.2), Parallax: 0.45, RepeatY: true},
+		}
+	case genre.Cyberpunk:
+		return []BackgroundLayer{
+			{Image: noiseLayer(rng, w, h, 3, palette[3], 0.45, 0.3), Parallax: 0.05, RepeatY: true},
+			{Image: skylineLayer(rng, w, h, skylineStyle{
+				body: darkenColor(palette[3], 0.35), windows: palette[:3], minHeight: 0.3, maxHeight: 0.7, windowChance: 0.15,
+			}), Parallax: 0.15},
+			{Image: skylineLayer(rng, w, h, skylineStyle{
+				body: darkenColor(palette[4], 0.4), windows: palette[:3], minHeight: 0.15, maxHeight: 0.45, windowChance: 0.3,
+			}), Parallax: 0.35},
+		}
+	case genre.PostApoc:
+		return []BackgroundLayer{
+			{Image: noiseLayer(rng, w, h, 3, palette[4], 0.35, 0.35), Parallax: 0.08, RepeatY: true},
+			{Image: skylineLayer(rng, w, h, skylineStyle{
+				body: darkenColor(palette[1], 0.35), windows: palette[:1], minHeight: 0.2, maxHeight: 0.55,
+				windowChance: 0.03, ruined: true,
+			}), Parallax: 0.25},
+		}
+	default: // scifi
+		return []BackgroundLayer{
+			{Image: starLayer(rng, w, h, w*h/1500, palette[2], 0.45), Parallax: 0.05, RepeatY: true},
+			{Image: noiseLayer(rng, w, h, 4, palette[1], 0.45, 0.3), Parallax: 0.15, RepeatY: true},
+			{Image: starLayer(rng, w, h, w*h/8000, palette[2], 1), Parallax: 0.3, RepeatY: true},
+		}
+	}
+}
+
+// sciFiFallbackPalette colors backgrounds of genres without a palette.
+var sciFiFallbackPalette = []color.RGBA{
+	{R: 80, G: 120, B: 200, A: 255},
+	{R: 100, G: 180, B: 255, A: 255},
+	{R: 200, G: 200, B: 220, A: 255},
+	{R: 50, G: 80, B: 120, A: 255},
+	{R: 255, G: 100, B: 100, A: 255},
+}
+
+// starLayer scatters stars of varying brightness; the brightest get a small cross.
+func starLayer(rng *rand.Rand, w, h, count int, c color.RGBA, maxBrightness float64) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < count; i++ {
+		x, y := rng.Intn(w), rng.Intn(h)
+		b := maxBrightness * (0.3 + 0.7*rng.Float64())
+		star := premultiply(c, b)
+		img.SetRGBA(x, y, star)
+		if b > 0.8 {
+			dim := premultiply(c, b*0.35)
+			for _, d := range [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+				img.SetRGBA(wrapInt(x+d[0], w), wrapInt(y+d[1], h), dim)
+			}
+		}
+	}
+	return img
+}
+
+// noiseLayer draws soft tileable clouds of color: nebulas, clouds, fog and
+// dust. Noise below threshold is clear; cells sets the size of the features.
+func noiseLayer(rng *rand.Rand, w, h, cells int, c color.RGBA, threshold, maxAlpha float64) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	octaves := make([]*valueNoise, NoiseOctaves)
+	for i := range octaves {
+		octaves[i] = newValueNoise(rng, cells<<i)
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			u, v := float64(x)/float64(w), float64(y)/float64(h)
+			n, amp, total := 0.0, 1.0, 0.0
+			for _, o := range octaves {
+				n += o.at(u, v) * amp
+				total += amp
+				amp /= 2
+			}
+			a := smoothstep(threshold, 1, n/total) * maxAlpha
+			if a > 0 {
+				img.SetRGBA(x, y, premultiply(c, a))
+			}
+		}
+	}
+	return img
+}
+
+// skylineStyle describes a city skyline silhouette.
+type skylineStyle struct {
+	body         color.RGBA
+	windows      []color.RGBA
+	minHeight    float64 // Fractions of the layer height
+	maxHeight    float64
+	windowChance float64
+	ruined       bool // Jagged broken roofs instead of flat ones
+}
+
+// skylineLayer draws a row of buildings along the bottom edge, wrapping
+// horizontally so the layer tiles.
+func skylineLayer(rng *rand.Rand, w, h int, style skylineStyle) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; {
+		bw := 12 + rng.Intn(40)
+		bh := int(float64(h) * (style.minHeight + rng.Float64()*(style.maxHeight-style.minHeight)))
+		roof := flatRoof
+		if style.ruined {
+			roof = brokenRoof(rng, bw, bh)
+		}
+		for i := 0; i < bw; i++ {
+			fillColumn(img, wrapInt(x+i, w), h-bh+roof(i), h, style.body)
+		}
+		drawWindows(img, rng, x, h-bh, bw, bh, w, style)
+		x += bw + rng.Intn(6)
+	}
+	return img
+}
+
+// flatRoof is the roof profile of intact buildings.
+func flatRoof(int) int { return 0 }
+
+// brokenRoof returns a roof profile that collapses toward one side of a
+// building from a random break point, with jagged rubble along the edge.
+func brokenRoof(rng *rand.Rand, bw, bh int) func(i int) int {
+	breakAt := rng.Intn(bw)
+	depth := rng.Intn(bh/2 + 1)
+	mirror := rng.Intn(2) == 0
+	jag := make([]int, bw)
+	for i := range jag {
+		jag[i] = rng.Intn(3)
+	}
+	return func(i int) int {
+		if mirror {
+			i = bw - 1 - i
+		}
+		if i < breakAt {
+			return 0
+		}
+		return depth*(i-breakAt)/(bw-breakAt) + jag[i]
+	}
+}
+
+// drawWindows lights a grid of windows on a building, wrapping horizontally.
+func drawWindows(img *image.RGBA, rng *rand.Rand, x, top, bw, bh, w int, style skylineStyle) {
+	if len(style.windows) == 0 {
+		return
+	}
+	for wy := top + 4; wy < top+bh-2; wy += 4 {
+		for wx := x + 2; wx < x+bw-2; wx += 4 {
+			if rng.Float64() >= style.windowChance || img.RGBAAt(wrapInt(wx, w), wy).A == 0 {
+				continue
+			}
+			c := style.windows[rng.Intn(len(style.windows))]
+			img.SetRGBA(wrapInt(wx, w), wy, c)
+			img.SetRGBA(wrapInt(wx+1, w), wy, c)
+		}
+	}
+}
+
+// castleLayer draws hills topped with crenellated castles and pointed towers.
+func castleLayer(rng *rand.Rand, w, h int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	hill := newValueNoise(rng, 3)
+	for x := 0; x < w; x++ {
+		top := h - int(float64(h)*(0.08+0.1*hill.at(float64(x)/float64(w), 0)))
+		fillColumn(img, x, top, h, c)
+	}
+	for x := rng.Intn(60); x < w; x += 120 + rng.Intn(160) {
+		base := h - int(float64(h)*0.14)
+		wallW, wallH := 40+rng.Intn(40), 20+rng.Intn(20)
+		for i := 0; i < wallW; i++ {
+			top := base - wallH
+			if (i/4)%2 == 0 {
+				top -= 4 // Crenellations
+			}
+			fillColumn(img, wrapInt(x+i, w), top, h, c)
+		}
+		for _, tx := range []int{x - 4, x + wallW - 6} {
+			drawTower(img, tx, base-wallH-16-rng.Intn(20), 10, w, h, c)
+		}
+	}
+	return img
+}
+
+// drawTower draws a tower of the given width with a pointed roof.
+func drawTower(img *image.RGBA, x, top, width, w, h int, c color.RGBA) {
+	half := width / 2
+	for i := 0; i < width; i++ {
+		roof := abs(i-half) * 2
+		fillColumn(img, wrapInt(x+i, w), top-width+roof, h, c)
+	}
+}
+
+// spireLayer draws gothic spires and dead trees rising from the bottom edge.
+func spireLayer(rng *rand.Rand, w, h int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		fillColumn(img, x, h-6-rng.Intn(3), h, c)
+	}
+	for x := rng.Intn(40); x < w; x += 30 + rng.Intn(90) {
+		height := int(float64(h) * (0.15 + 0.3*rng.Float64()))
+		if rng.Intn(2) == 0 {
+			drawTower(img, x, h-height, 8+rng.Intn(8), w, h, c)
+		} else {
+			drawDeadTree(img, rng, x, h, height, w, c)
+		}
+	}
+	return img
+}
+
+// drawDeadTree draws a bare trunk with a few crooked branches.
+func drawDeadTree(img *image.RGBA, rng *rand.Rand, x, h, height, w int, c color.RGBA) {
+	for i := 0; i < 3; i++ {
+		fillColumn(img, wrapInt(x+i, w), h-height, h, c)
+	}
+	for b := 0; b < 3+rng.Intn(3); b++ {
+		y := h - height + rng.Intn(height/2+1)
+		dir := 1
+		if rng.Intn(2) == 0 {
+			dir = -1
+		}
+		for i := 0; i < 6+rng.Intn(10); i++ {
+			setPixel(img, wrapInt(x+1+dir*i, w), y-i/2, c)
+		}
+	}
+}
+
+// fillColumn fills pixels from top (inclusive) to bottom (exclusive) in column x.
+func fillColumn(img *image.RGBA, x, top, bottom int, c color.RGBA) {
+	for y := top; y < bottom; y++ {
+		setPixel(img, x, y, c)
+	}
+}
+
+// premultiply scales a color and its alpha by a, as image.RGBA expects.
+func premultiply(c color.RGBA, a float64) color.RGBA {
+	a = math.Max(0, math.Min(a, 1))
+	return color.RGBA{
+		R: uint8(float64(c.R) * a), G: uint8(float64(c.G) * a), B: uint8(float64(c.B) * a), A: uint8(255 * a),
+	}
+}
+
+// smoothstep eases v from 0 at edge0 to 1 at edge1.
+func smoothstep(edge0, edge1, v float64) float64 {
+	t := math.Max(0, math.Min((v-edge0)/(edge1-edge0), 1))
+	return t * t * (3 - 2*t)
+}
+
+// wrapInt wraps v into [0, n).
+func wrapInt(v, n int) int {
+	return ((v % n) + n) % n
+}
+
+// wrapFloat wraps v into [0, n).
+func wrapFloat(v, n float64) float64 {
+	v = math.Mod(v, n)
+	if v < 0 {
+		v += n
+	}
+	return v
+}
+
+// valueNoise is smoothly interpolated lattice noise that wraps at the
+// edges of the unit square, so layers built from it tile seamlessly.
+type valueNoise struct {
+	cells  int
+	values []float64
+}
+
+// newValueNoise creates noise with the given number of lattice cells per side.
+func newValueNoise(rng *rand.Rand, cells int) *valueNoise {
+	values := make([]float64, cells*cells)
+	for i := range values {
+		values[i] = rng.Float64()
+	}
+	return &valueNoise{cells: cells, values: values}
+}
+
+// at samples the noise at (u, v) in the unit square.
+func (n *valueNoise) at(u, v float64) float64 {
+	x, y := u*float64(n.cells), v*float64(n.cells)
+	x0, y0 := int(math.Floor(x)), int(math.Floor(y))
+	fx, fy := smoothstep(0, 1, x-float64(x0)), smoothstep(0, 1, y-float64(y0))
+	corner := func(cx, cy int) float64 {
+		return n.values[wrapInt(cy, n.cells)*n.cells+wrapInt(cx, n.cells)]
+	}
+	top := corner(x0, y0) + (corner(x0+1, y0)-corner(x0, y0))*fx
+	bottom := corner(x0, y0+1) + (corner(x0+1, y0+1)-corner(x0, y0+1))*fx
+	return top + (bottom-top)*fy
+}
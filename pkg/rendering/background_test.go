@@ -0,0 +1,111 @@
+package rendering
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/procgen/genre"
+)
+
+func TestGenerateBackground_AllGenres(t *testing.T) {
+	for _, id := range genre.All() {
+		layers := GenerateBackground(id, 42, 160, 120)
+		if len(layers) < 2 {
+			t.Errorf("genre %s: expected multiple layers, got %d", id, len(layers))
+		}
+		for i, l := range layers {
+			if b := l.Image.Bounds(); b.Dx() != 160 || b.Dy() != 120 {
+				t.Errorf("genre %s layer %d: unexpected size %v", id, i, b)
+			}
+			if i > 0 && l.Parallax <= layers[i-1].Parallax {
+				t.Errorf("genre %s: expected layers ordered far to near", id)
+			}
+			if !hasVisiblePixels(l.Image.Pix) {
+				t.Errorf("genre %s layer %d: expected visible content", id, i)
+			}
+		}
+	}
+}
+
+func TestGenerateBackground_Deterministic(t *testing.T) {
+	a := GenerateBackground(genre.SciFi, 7, 120, 90)
+	b := GenerateBackground(genre.SciFi, 7, 120, 90)
+	c := GenerateBackground(genre.SciFi, 8, 120, 90)
+	for i := range a {
+		if !bytes.Equal(a[i].Image.Pix, b[i].Image.Pix) {
+			t.Errorf("layer %d differs for the same seed", i)
+		}
+	}
+	if bytes.Equal(a[0].Image.Pix, c[0].Image.Pix) {
+		t.Error("expected a different seed to change the starfield")
+	}
+}
+
+func TestGenerateBackground_SkylineOnHorizon(t *testing.T) {
+	layers := GenerateBackground(genre.Cyberpunk, 3, 200, 100)
+	city := layers[len(layers)-1]
+	if city.RepeatY {
+		t.Error("expected skyline not to tile vertically")
+	}
+	if city.Image.RGBAAt(50, 0).A != 0 {
+		t.Error("expected open sky above the skyline")
+	}
+	filled := 0
+	for x := 0; x < 200; x++ {
+		if city.Image.RGBAAt(x, 99).A != 0 {
+			filled++
+		}
+	}
+	if filled < 100 {
+		t.Errorf("expected buildings along most of the bottom edge, got %d of 200 pixels", filled)
+	}
+}
+
+func TestBackgroundLayer_Offset(t *testing.T) {
+	layers := GenerateBackground(genre.Fantasy, 1, 100, 80)
+	clouds, castles := layers[1], layers[2]
+
+	x, y := clouds.Offset(1000, -1000)
+	if x > 0 || x <= -100 || y > 0 || y <= -80 {
+		t.Errorf("expected offset within one tile, got (%f, %f)", x, y)
+	}
+	if _, y := castles.Offset(1000, 500); y != 0 {
+		t.Errorf("expected horizon layer to stay anchored, got y %f", y)
+	}
+	if x, _ := castles.Offset(0, 0); x != 0 {
+		t.Errorf("expected no offset at the origin, got %f", x)
+	}
+}
+
+func TestValueNoise_Tiles(t *testing.T) {
+	n := newValueNoise(rand.New(rand.NewSource(12345)), 4)
+	if a, b := n.at(0, 0.3), n.at(1, 0.3); a != b {
+		t.Errorf("expected noise to wrap horizontally, got %f and %f", a, b)
+	}
+	if a, b := n.at(0.6, 0), n.at(0.6, 1); a != b {
+		t.Errorf("expected noise to wrap vertically, got %f and %f", a, b)
+	}
+}
+
+func TestRenderer_GetOrCreateBackground(t *testing.T) {
+	r := NewRenderer()
+	r.SetSeed(5)
+	a := r.GetOrCreateBackground(64, 48)
+	if b := r.GetOrCreateBackground(64, 48); &a[0] != &b[0] {
+		t.Error("expected cached layers for the same size")
+	}
+	if c := r.GetOrCreateBackground(80, 48); c[0].Image.Bounds().Dx() != 80 {
+		t.Error("expected layers regenerated for a new size")
+	}
+}
+
+// hasVisiblePixels returns true if any pixel has non-zero alpha.
+func hasVisiblePixels(pix []byte) bool {
+	for i := 3; i < len(pix); i += 4 {
+		if pix[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -2,7 +2,12 @@
 // dynamic lighting, draw batching, and viewport culling.
 package rendering
 
-import "github.com/opd-ai/velocity/pkg/engine"
+import (
+	"math"
+	"sort"
+
+	"github.com/opd-ai/velocity/pkg/engine"
+)
 
 // Viewport represents the visible area of the game world.
 type Viewport struct {
@@ -36,33 +41,66 @@ func (v *Viewport) ContainsRect(x, y, width, height float64) bool {
 		y+height >= v.Y && y < v.Y+v.Height
 }
 
-// CullContext holds culling state for a frame.
+// FollowCamera moves the viewport to what the camera shows this frame,
+// including its shake offset.
+func (v *Viewport) FollowCamera(c *engine.Camera) {
+	shakeX, shakeY := c.ShakeOffset()
+	v.X, v.Y = c.X-shakeX, c.Y-shakeY
+	if c.ViewWidth > 0 && c.ViewHeight > 0 {
+		v.Width, v.Height = c.ViewWidth, c.ViewHeight
+	}
+}
+
+// DefaultCullSize is the extent assumed for entities with neither a sprite
+// nor a bounding box.
+const DefaultCullSize = 32.0
+
+// Bounded is implemented by collision boxes that can widen an entity's cull
+// bounds beyond its sprite. It returns the box's offset from the entity
+// position and its size.
+type Bounded interface {
+	Bounds() (x, y, width, height float64)
+}
+
+// CullContext holds culling state for a frame. The viewport, grown by the
+// margin, is kept as world-space edges so ShouldRender is a plain comparison
+// with no per-call work or allocation.
 type CullContext struct {
-	viewport      *Viewport
-	margin        float64
-	culledCount   int
-	renderedCount int
+	viewport               *Viewport
+	margin                 float64
+	minX, minY, maxX, maxY float64
+	culledCount            int
+	renderedCount          int
 }
 
 // NewCullContext creates a new culling context for the given viewport.
 func NewCullContext(viewport *Viewport, margin float64) *CullContext {
-	return &CullContext{
-		viewport: viewport,
-		margin:   margin,
-	}
+	cc := &CullContext{margin: margin}
+	cc.SetViewport(viewport)
+	return cc
 }
 
-// ShouldRender returns true if the entity should be rendered.
-func (cc *CullContext) ShouldRender(x, y, width, height float64) bool {
-	// Expand viewport by margin for entities partially off-screen
-	expanded := &Viewport{
-		X:      cc.viewport.X - cc.margin,
-		Y:      cc.viewport.Y - cc.margin,
-		Width:  cc.viewport.Width + 2*cc.margin,
-		Height: cc.viewport.Height + 2*cc.margin,
-	}
+// SetViewport points the context at a moved or resized viewport and resets
+// the frame counters, so one context can be reused every frame.
+func (cc *CullContext) SetViewport(viewport *Viewport) {
+	cc.viewport = viewport
+	cc.minX = viewport.X - cc.margin
+	cc.minY = viewport.Y - cc.margin
+	cc.maxX = viewport.X + viewport.Width + cc.margin
+	cc.maxY = viewport.Y + viewport.Height + cc.margin
+	cc.Reset()
+}
 
-	if expanded.ContainsRect(x-width/2, y-height/2, width, height) {
+// Bounds returns the world-space rectangle that is tested against.
+func (cc *CullContext) Bounds() (minX, minY, maxX, maxY float64) {
+	return cc.minX, cc.minY, cc.maxX, cc.maxY
+}
+
+// ShouldRender returns true if a width x height box centered on (x, y)
+// overlaps the viewport.
+func (cc *CullContext) ShouldRender(x, y, width, height float64) bool {
+	if x+width/2 >= cc.minX && x-width/2 < cc.maxX &&
+		y+height/2 >= cc.minY && y-height/2 < cc.maxY {
 		cc.renderedCount++
 		return true
 	}
@@ -71,6 +109,62 @@ func (cc *CullContext) ShouldRender(x, y, width, height float64) bool {
 	return false
 }
 
+// ShouldRenderRotated is ShouldRender for a box rotated by angle radians,
+// testing the axis-aligned box that encloses it.
+func (cc *CullContext) ShouldRenderRotated(x, y, width, height, angle float64) bool {
+	w, h := RotatedExtent(width, height, angle)
+	return cc.ShouldRender(x, y, w, h)
+}
+
+// ShouldRenderEntity culls an entity by its sprite size, scale, rotation
+// and bounding box. Entities without a position are never rendered.
+func (cc *CullContext) ShouldRenderEntity(world *engine.World, e engine.Entity) bool {
+	posComp, hasPos := world.GetComponent(e, "position")
+	if !hasPos {
+		return false
+	}
+	pos := posComp.(*engine.Position)
+	x, y, w, h := EntityCullBox(world, e, pos)
+	return cc.ShouldRender(x, y, w, h)
+}
+
+// RotatedExtent returns the size of the axis-aligned box enclosing a
+// width x height box rotated by angle radians.
+func RotatedExtent(width, height, angle float64) (float64, float64) {
+	sin, cos := math.Sincos(angle)
+	sin, cos = math.Abs(sin), math.Abs(cos)
+	return width*cos + height*sin, width*sin + height*cos
+}
+
+// EntityCullBox returns the center and size of the world-space box that
+// encloses an entity's sprite, after scale and rotation, and its bounding
+// box.
+func EntityCullBox(world *engine.World, e engine.Entity, pos *engine.Position) (x, y, width, height float64) {
+	size := DefaultCullSize
+	if spriteComp, ok := world.GetComponent(e, "sprite"); ok {
+		size = float64(spriteComp.(*SpriteComponent).Size)
+	}
+	if r, ok := world.GetComponent(e, "renderable"); ok {
+		size *= r.(*Renderable).Scale
+	}
+	w, h := size, size
+	if rotComp, ok := world.GetComponent(e, "rotation"); ok {
+		w, h = RotatedExtent(size, size, rotComp.(*engine.Rotation).Angle)
+	}
+	minX, minY, maxX, maxY := pos.X-w/2, pos.Y-h/2, pos.X+w/2, pos.Y+h/2
+
+	if boxComp, ok := world.GetComponent(e, "boundingbox"); ok {
+		if box, ok := boxComp.(Bounded); ok {
+			bx, by, bw, bh := box.Bounds()
+			minX = math.Min(minX, pos.X+bx)
+			minY = math.Min(minY, pos.Y+by)
+			maxX = math.Max(maxX, pos.X+bx+bw)
+			maxY = math.Max(maxY, pos.Y+by+bh)
+		}
+	}
+	return (minX + maxX) / 2, (minY + maxY) / 2, maxX - minX, maxY - minY
+}
+
 // GetCulledCount returns the number of entities culled this frame.
 func (cc *CullContext) GetCulledCount() int {
 	return cc.culledCount
@@ -87,23 +181,14 @@ func (cc *CullContext) Reset() {
 	cc.renderedCount = 0
 }
 
-// FilterVisibleEntities returns only entities within the viewport.
+// FilterVisibleEntities returns only entities within the viewport, sized by
+// their sprites and bounding boxes.
 func FilterVisibleEntities(world *engine.World, viewport *Viewport, margin float64) []engine.Entity {
 	cc := NewCullContext(viewport, margin)
 	var visible []engine.Entity
 
 	world.ForEachEntity(func(e engine.Entity) {
-		posComp, hasPos := world.GetComponent(e, "position")
-		if !hasPos {
-			return
-		}
-
-		pos := posComp.(*engine.Position)
-
-		// Default sprite size for culling; could be fetched from sprite component
-		spriteSize := 32.0
-
-		if cc.ShouldRender(pos.X, pos.Y, spriteSize, spriteSize) {
+		if cc.ShouldRenderEntity(world, e) {
 			visible = append(visible, e)
 		}
 	})
@@ -111,31 +196,23 @@ func FilterVisibleEntities(world *engine.World, viewport *Viewport, margin float
 	return visible
 }
 
-// RenderOrder defines the order in which sprite types are rendered.
+// RenderOrder lists the built-in sprite types bottom to top, following
+// their default layers.
 var RenderOrder = []SpriteType{
-	SpriteTypeProjectile,
+	SpriteTypeObstacle,
+	SpriteTypePickup,
 	SpriteTypeEnemy,
 	SpriteTypeShip,
+	SpriteTypeProjectile,
 }
 
-// SortBatchesByRenderOrder sorts batches according to render order.
+// SortBatchesByRenderOrder sorts batches by the layers of their sprite types
+// in DefaultLayers.
 func SortBatchesByRenderOrder(batches []DrawBatch) []DrawBatch {
-	orderMap := make(map[SpriteType]int)
-	for i, st := range RenderOrder {
-		orderMap[st] = i
-	}
-
 	sorted := make([]DrawBatch, len(batches))
 	copy(sorted, batches)
-
-	// Simple insertion sort for small slice
-	for i := 1; i < len(sorted); i++ {
-		j := i
-		for j > 0 && orderMap[sorted[j].Type] < orderMap[sorted[j-1].Type] {
-			sorted[j], sorted[j-1] = sorted[j-1], sorted[j]
-			j--
-		}
-	}
-
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return DefaultLayers.SpriteLayer(sorted[i].Type) < DefaultLayers.SpriteLayer(sorted[j].Type)
+	})
 	return sorted
 }
@@ -1,6 +1,7 @@
 package rendering
 
 import (
+	"math"
 	"testing"
 
 	"github.com/opd-ai/velocity/pkg/engine"
@@ -158,21 +159,26 @@ func TestSortBatchesByRenderOrder(t *testing.T) {
 
 	sorted := SortBatchesByRenderOrder(batches)
 
-	// Expected order: Projectile, Enemy, Ship
-	if sorted[0].Type != SpriteTypeProjectile {
-		t.Errorf("expected first to be Projectile, got %d", sorted[0].Type)
+	// Expected order: Enemy, Ship (player), Projectile
+	if sorted[0].Type != SpriteTypeEnemy {
+		t.Errorf("expected first to be Enemy, got %d", sorted[0].Type)
 	}
-	if sorted[1].Type != SpriteTypeEnemy {
-		t.Errorf("expected second to be Enemy, got %d", sorted[1].Type)
+	if sorted[1].Type != SpriteTypeShip {
+		t.Errorf("expected second to be Ship, got %d", sorted[1].Type)
 	}
-	if sorted[2].Type != SpriteTypeShip {
-		t.Errorf("expected third to be Ship, got %d", sorted[2].Type)
+	if sorted[2].Type != SpriteTypeProjectile {
+		t.Errorf("expected third to be Projectile, got %d", sorted[2].Type)
 	}
 }
 
 func TestRenderOrder(t *testing.T) {
-	if len(RenderOrder) != 3 {
-		t.Errorf("expected 3 sprite types in render order, got %d", len(RenderOrder))
+	if len(RenderOrder) != 5 {
+		t.Errorf("expected 5 sprite types in render order, got %d", len(RenderOrder))
+	}
+	for i := 1; i < len(RenderOrder); i++ {
+		if DefaultLayers.SpriteLayer(RenderOrder[i-1]) >= DefaultLayers.SpriteLayer(RenderOrder[i]) {
+			t.Errorf("render order %d is not above %d", RenderOrder[i], RenderOrder[i-1])
+		}
 	}
 }
 
@@ -195,3 +201,105 @@ func BenchmarkFilterVisibleEntities(b *testing.B) {
 		FilterVisibleEntities(world, vp, 32)
 	}
 }
+
+// testBox stands in for a collision bounding box.
+type testBox struct{ x, y, w, h float64 }
+
+func (b *testBox) Bounds() (float64, float64, float64, float64) { return b.x, b.y, b.w, b.h }
+
+func TestCullContext_ShouldRenderDoesNotAllocate(t *testing.T) {
+	cc := NewCullContext(NewViewport(800, 600), 32)
+	allocs := testing.AllocsPerRun(100, func() {
+		cc.ShouldRender(400, 300, 32, 32)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestCullContext_SetViewport(t *testing.T) {
+	vp := NewViewport(800, 600)
+	cc := NewCullContext(vp, 0)
+	cc.ShouldRender(400, 300, 10, 10)
+
+	vp.X = 1000
+	cc.SetViewport(vp)
+	if cc.GetRenderedCount() != 0 {
+		t.Error("expected SetViewport to reset counters")
+	}
+	if cc.ShouldRender(400, 300, 10, 10) {
+		t.Error("expected old position to be culled after the viewport moved")
+	}
+	if !cc.ShouldRender(1400, 300, 10, 10) {
+		t.Error("expected point in moved viewport to render")
+	}
+}
+
+func TestViewport_FollowCamera(t *testing.T) {
+	cam := engine.NewCamera()
+	cam.ViewWidth, cam.ViewHeight = 640, 480
+	cam.X, cam.Y = 200, 100
+	vp := NewViewport(800, 600)
+	vp.FollowCamera(cam)
+	if vp.X != 200 || vp.Y != 100 || vp.Width != 640 || vp.Height != 480 {
+		t.Errorf("expected viewport to match camera, got %+v", vp)
+	}
+}
+
+func TestRotatedExtent(t *testing.T) {
+	w, h := RotatedExtent(40, 10, math.Pi/2)
+	if math.Abs(w-10) > 1e-9 || math.Abs(h-40) > 1e-9 {
+		t.Errorf("quarter turn: got %vx%v, want 10x40", w, h)
+	}
+	w, h = RotatedExtent(10, 10, math.Pi/4)
+	if math.Abs(w-10*math.Sqrt2) > 1e-9 || math.Abs(h-w) > 1e-9 {
+		t.Errorf("eighth turn: got %vx%v", w, h)
+	}
+}
+
+func TestEntityCullBox(t *testing.T) {
+	world := engine.NewWorld()
+	pos := &engine.Position{X: 100, Y: 100}
+
+	plain := world.CreateEntity()
+	if _, _, w, h := EntityCullBox(world, plain, pos); w != DefaultCullSize || h != DefaultCullSize {
+		t.Errorf("expected default size, got %vx%v", w, h)
+	}
+
+	scaled := world.CreateEntity()
+	world.AddComponent(scaled, "sprite", &SpriteComponent{Size: 20})
+	world.AddComponent(scaled, "renderable", &Renderable{Scale: 3, Alpha: 1})
+	if _, _, w, _ := EntityCullBox(world, scaled, pos); w != 60 {
+		t.Errorf("expected sprite size times scale, got %v", w)
+	}
+
+	boxed := world.CreateEntity()
+	world.AddComponent(boxed, "sprite", &SpriteComponent{Size: 10})
+	world.AddComponent(boxed, "boundingbox", &testBox{x: 0, y: -5, w: 40, h: 10})
+	x, y, w, h := EntityCullBox(world, boxed, pos)
+	if w != 45 || h != 10 || x != 117.5 || y != 100 {
+		t.Errorf("expected box widened to collision bounds, got center (%v,%v) size %vx%v", x, y, w, h)
+	}
+}
+
+func TestCullContext_ShouldRenderEntityUsesSpriteSize(t *testing.T) {
+	world := engine.NewWorld()
+	// A large sprite centered off-screen still reaches into view
+	big := world.CreateEntity()
+	world.AddComponent(big, "position", &engine.Position{X: -60, Y: 300})
+	world.AddComponent(big, "sprite", &SpriteComponent{Size: 160})
+	small := world.CreateEntity()
+	world.AddComponent(small, "position", &engine.Position{X: -60, Y: 300})
+	world.AddComponent(small, "sprite", &SpriteComponent{Size: 8})
+
+	cc := NewCullContext(NewViewport(800, 600), 0)
+	if !cc.ShouldRenderEntity(world, big) {
+		t.Error("expected large sprite overlapping the edge to render")
+	}
+	if cc.ShouldRenderEntity(world, small) {
+		t.Error("expected small sprite off-screen to be culled")
+	}
+	if cc.ShouldRenderEntity(world, world.CreateEntity()) {
+		t.Error("expected entity without position to be culled")
+	}
+}
@@ -0,0 +1,123 @@
+package rendering
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+// Effect tuning constants.
+const (
+	// DefaultHitFlashDuration is how long a damaged entity flashes, in seconds.
+	DefaultHitFlashDuration = 0.1
+	// MaxShockwaves caps concurrent shockwaves; the oldest is dropped first.
+	MaxShockwaves = 32
+)
+
+// HitFlash is a component that flashes an entity's sprite after it takes damage.
+// It stays attached once added and is simply idle at zero Remaining.
+type HitFlash struct {
+	Remaining float64
+	Duration  float64
+}
+
+// Intensity returns the flash strength from 1 (just hit) down to 0.
+func (h *HitFlash) Intensity() float64 {
+	if h.Duration <= 0 {
+		return 0
+	}
+	return h.Remaining / h.Duration
+}
+
+// Shockwave is an expanding ring left by explosions and heavy impacts.
+type Shockwave struct {
+	X, Y      float64
+	Radius    float64
+	MaxRadius float64
+	Life      float64
+	MaxLife   float64
+	Color     color.RGBA
+}
+
+// Alpha returns the ring opacity, fading out as it expands.
+func (s Shockwave) Alpha() float64 {
+	if s.MaxLife <= 0 {
+		return 0
+	}
+	return s.Life / s.MaxLife
+}
+
+// EffectsSystem drives short-lived combat feedback: hit flashes and shockwaves.
+type EffectsSystem struct {
+	world      *engine.World
+	shockwaves []Shockwave
+}
+
+// NewEffectsSystem creates an effects system for the given world.
+func NewEffectsSystem(world *engine.World) *EffectsSystem {
+	return &EffectsSystem{
+		world:      world,
+		shockwaves: make([]Shockwave, 0, MaxShockwaves),
+	}
+}
+
+// Flash starts or restarts a hit flash on an entity.
+func (es *EffectsSystem) Flash(e engine.Entity, duration float64) {
+	if comp, ok := es.world.GetComponent(e, "hitflash"); ok {
+		flash := comp.(*HitFlash)
+		flash.Remaining = duration
+		flash.Duration = duration
+		return
+	}
+	es.world.AddComponent(e, "hitflash", &HitFlash{Remaining: duration, Duration: duration})
+}
+
+// FlashIntensity returns the current flash strength of an entity, or 0.
+func (es *EffectsSystem) FlashIntensity(e engine.Entity) float64 {
+	if comp, ok := es.world.GetComponent(e, "hitflash"); ok {
+		return comp.(*HitFlash).Intensity()
+	}
+	return 0
+}
+
+// SpawnShockwave starts a ring expanding from (x, y) to maxRadius over duration seconds.
+func (es *EffectsSystem) SpawnShockwave(x, y, maxRadius, duration float64, c color.RGBA) {
+	if len(es.shockwaves) >= MaxShockwaves {
+		es.shockwaves = append(es.shockwaves[:0], es.shockwaves[1:]...)
+	}
+	es.shockwaves = append(es.shockwaves, Shockwave{
+		X: x, Y: y, MaxRadius: maxRadius, Life: duration, MaxLife: duration, Color: c,
+	})
+}
+
+// Shockwaves returns the active shockwaves.
+func (es *EffectsSystem) Shockwaves() []Shockwave {
+	return es.shockwaves
+}
+
+// Clear removes all shockwaves.
+func (es *EffectsSystem) Clear() {
+	es.shockwaves = es.shockwaves[:0]
+}
+
+// Update ages hit flashes and shockwaves.
+func (es *EffectsSystem) Update(dt float64) {
+	es.world.ForEachEntity(func(e engine.Entity) {
+		if comp, ok := es.world.GetComponent(e, "hitflash"); ok {
+			flash := comp.(*HitFlash)
+			flash.Remaining = math.Max(flash.Remaining-dt, 0)
+		}
+	})
+
+	alive := es.shockwaves[:0]
+	for _, s := range es.shockwaves {
+		s.Life -= dt
+		if s.Life <= 0 {
+			continue
+		}
+		s.Radius = s.MaxRadius * (1 - s.Alpha())
+		alive = append(alive, s)
+	}
+	es.shockwaves = alive
+}
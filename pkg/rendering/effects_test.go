@@ -0,0 +1,66 @@
+package rendering
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+func TestEffectsSystem_HitFlash(t *testing.T) {
+	world := engine.NewWorld()
+	es := NewEffectsSystem(world)
+	e := world.CreateEntity()
+
+	if es.FlashIntensity(e) != 0 {
+		t.Fatal("expected no flash before a hit")
+	}
+	es.Flash(e, 0.1)
+	if es.FlashIntensity(e) != 1 {
+		t.Errorf("expected full flash on hit, got %f", es.FlashIntensity(e))
+	}
+
+	es.Update(0.05)
+	if got := es.FlashIntensity(e); got < 0.49 || got > 0.51 {
+		t.Errorf("expected half flash, got %f", got)
+	}
+
+	// A second hit restarts the flash
+	es.Flash(e, 0.1)
+	if es.FlashIntensity(e) != 1 {
+		t.Errorf("expected flash restarted, got %f", es.FlashIntensity(e))
+	}
+
+	es.Update(1)
+	if es.FlashIntensity(e) != 0 {
+		t.Errorf("expected flash to fade out, got %f", es.FlashIntensity(e))
+	}
+}
+
+func TestEffectsSystem_Shockwaves(t *testing.T) {
+	es := NewEffectsSystem(engine.NewWorld())
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+	es.SpawnShockwave(10, 20, 100, 1, white)
+	es.Update(0.25)
+	waves := es.Shockwaves()
+	if len(waves) != 1 {
+		t.Fatalf("expected 1 shockwave, got %d", len(waves))
+	}
+	if waves[0].Radius != 25 || waves[0].Alpha() != 0.75 {
+		t.Errorf("expected radius 25 and alpha 0.75, got %f and %f", waves[0].Radius, waves[0].Alpha())
+	}
+
+	es.Update(1)
+	if len(es.Shockwaves()) != 0 {
+		t.Error("expected shockwave expired")
+	}
+
+	for i := 0; i < MaxShockwaves+5; i++ {
+		es.SpawnShockwave(float64(i), 0, 10, 1, white)
+	}
+	waves = es.Shockwaves()
+	if len(waves) != MaxShockwaves || waves[0].X != 5 {
+		t.Errorf("expected oldest shockwaves dropped, got %d starting at x=%f", len(waves), waves[0].X)
+	}
+}
@@ -0,0 +1,227 @@
+package rendering
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+// RenderLayer orders what is drawn: lower layers are drawn first and so
+// appear underneath. Built-in layers are spaced apart so mods can register
+// layers between them.
+type RenderLayer int
+
+// Built-in render layers, bottom to top. LayerAuto, the zero value, means an
+// entity takes the layer of its sprite type.
+const (
+	LayerAuto        RenderLayer = 0
+	LayerBackground  RenderLayer = 100
+	LayerGround      RenderLayer = 200 // Ground hazards and obstacles
+	LayerPickups     RenderLayer = 300
+	LayerEnemies     RenderLayer = 400
+	LayerPlayer      RenderLayer = 500
+	LayerProjectiles RenderLayer = 600
+	LayerParticles   RenderLayer = 700
+	LayerUI          RenderLayer = 800
+)
+
+// DefaultEntityLayer is used for entities whose sprite type has no layer, so
+// new kinds of entity draw among the enemies instead of under the scene.
+const DefaultEntityLayer = LayerEnemies
+
+// LayerInfo describes a registered render layer.
+type LayerInfo struct {
+	Layer RenderLayer
+	Name  string
+	// YSort draws entities lower on screen later, so they overlap those
+	// behind them. Z-index still takes precedence within the layer.
+	YSort bool
+}
+
+// layerKey is an entity's precomputed position in the draw order.
+type layerKey struct {
+	entity engine.Entity
+	layer  RenderLayer
+	z      int
+	y      float64
+	ySort  bool
+}
+
+// LayerRegistry knows the render layers and which sprite types belong to
+// which layer, and sorts entities into draw order.
+type LayerRegistry struct {
+	mu           sync.Mutex
+	layers       map[RenderLayer]LayerInfo
+	spriteLayers map[SpriteType]RenderLayer
+	keys         []layerKey
+}
+
+// NewLayerRegistry creates a registry with the built-in layers and sprite
+// type assignments.
+func NewLayerRegistry() *LayerRegistry {
+	r := &LayerRegistry{
+		layers: map[RenderLayer]LayerInfo{
+			LayerBackground:  {Layer: LayerBackground, Name: "background"},
+			LayerGround:      {Layer: LayerGround, Name: "ground"},
+			LayerPickups:     {Layer: LayerPickups, Name: "pickups"},
+			LayerEnemies:     {Layer: LayerEnemies, Name: "enemies"},
+			LayerPlayer:      {Layer: LayerPlayer, Name: "player"},
+			LayerProjectiles: {Layer: LayerProjectiles, Name: "projectiles"},
+			LayerParticles:   {Layer: LayerParticles, Name: "particles"},
+			LayerUI:          {Layer: LayerUI, Name: "ui"},
+		},
+		spriteLayers: map[SpriteType]RenderLayer{
+			SpriteTypeObstacle:   LayerGround,
+			SpriteTypePickup:     LayerPickups,
+			SpriteTypeEnemy:      LayerEnemies,
+			SpriteTypeShip:       LayerPlayer,
+			SpriteTypeProjectile: LayerProjectiles,
+		},
+	}
+	return r
+}
+
+// DefaultLayers is the registry the game draws with. Mods register their
+// layers and sprite types here before the first frame.
+var DefaultLayers = NewLayerRegistry()
+
+// RegisterLayer adds a named layer. It fails if the layer value is reserved
+// or already taken by a different name.
+func (r *LayerRegistry) RegisterLayer(layer RenderLayer, name string, ySort bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if layer <= LayerAuto {
+		return fmt.Errorf("rendering: layer %d for %q must be positive", layer, name)
+	}
+	if existing, ok := r.layers[layer]; ok && existing.Name != name {
+		return fmt.Errorf("rendering: layer %d already registered as %q", layer, existing.Name)
+	}
+	r.layers[layer] = LayerInfo{Layer: layer, Name: name, YSort: ySort}
+	return nil
+}
+
+// SetYSort turns y-sorting on or off for a registered layer.
+func (r *LayerRegistry) SetYSort(layer RenderLayer, ySort bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if info, ok := r.layers[layer]; ok {
+		info.YSort = ySort
+		r.layers[layer] = info
+	}
+}
+
+// AssignSpriteType puts every sprite of the given type on a layer.
+func (r *LayerRegistry) AssignSpriteType(t SpriteType, layer RenderLayer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spriteLayers[t] = layer
+}
+
+// Layer returns a registered layer by value.
+func (r *LayerRegistry) Layer(layer RenderLayer) (LayerInfo, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	info, ok := r.layers[layer]
+	return info, ok
+}
+
+// Layers returns the registered layers, bottom to top.
+func (r *LayerRegistry) Layers() []LayerInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make([]LayerInfo, 0, len(r.layers))
+	for _, info := range r.layers {
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Layer < result[j].Layer })
+	return result
+}
+
+// SpriteLayer returns the layer for a sprite type.
+func (r *LayerRegistry) SpriteLayer(t SpriteType) RenderLayer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.spriteLayer(t)
+}
+
+// spriteLayer returns the layer for a sprite type; r.mu must be held.
+func (r *LayerRegistry) spriteLayer(t SpriteType) RenderLayer {
+	if layer, ok := r.spriteLayers[t]; ok {
+		return layer
+	}
+	return DefaultEntityLayer
+}
+
+// EntityLayer returns the layer and z-index an entity draws at. A
+// renderable's layer overrides the sprite type's; bare projectiles use the
+// projectile layer.
+func (r *LayerRegistry) EntityLayer(world *engine.World, e engine.Entity) (RenderLayer, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.entityLayer(world, e)
+}
+
+// entityLayer implements EntityLayer; r.mu must be held.
+func (r *LayerRegistry) entityLayer(world *engine.World, e engine.Entity) (RenderLayer, int) {
+	layer, z := LayerAuto, 0
+	if comp, ok := world.GetComponent(e, "renderable"); ok {
+		rd := comp.(*Renderable)
+		layer, z = rd.Layer, rd.Z
+	}
+	if layer != LayerAuto {
+		return layer, z
+	}
+	if comp, ok := world.GetComponent(e, "sprite"); ok {
+		return r.spriteLayer(comp.(*SpriteComponent).Type), z
+	}
+	if _, ok := world.GetComponent(e, "projectile"); ok {
+		return LayerProjectiles, z
+	}
+	return DefaultEntityLayer, z
+}
+
+// Sort orders entities in place for drawing: by layer, then z-index, then
+// by y on layers that y-sort. Ties keep their given order.
+func (r *LayerRegistry) Sort(world *engine.World, entities []engine.Entity) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.keys = r.keys[:0]
+	for _, e := range entities {
+		layer, z := r.entityLayer(world, e)
+		k := layerKey{entity: e, layer: layer, z: z, ySort: r.layers[layer].YSort}
+		if k.ySort {
+			if pos, ok := world.GetComponent(e, "position"); ok {
+				k.y = pos.(*engine.Position).Y
+			}
+		}
+		r.keys = append(r.keys, k)
+	}
+
+	sort.SliceStable(r.keys, func(i, j int) bool {
+		a, b := r.keys[i], r.keys[j]
+		if a.layer != b.layer {
+			return a.layer < b.layer
+		}
+		if a.z != b.z {
+			return a.z < b.z
+		}
+		return a.ySort && a.y < b.y
+	})
+	for i, k := range r.keys {
+		entities[i] = k.entity
+	}
+}
+
+// SplitAt returns how many of the sorted entities draw below layer, so a
+// pass such as particles can be drawn between them.
+func (r *LayerRegistry) SplitAt(world *engine.World, sorted []engine.Entity, layer RenderLayer) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return sort.Search(len(sorted), func(i int) bool {
+		l, _ := r.entityLayer(world, sorted[i])
+		return l >= layer
+	})
+}
@@ -0,0 +1,144 @@
+package rendering
+
+import (
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+func TestLayerRegistry_BuiltinOrder(t *testing.T) {
+	layers := NewLayerRegistry().Layers()
+	want := []string{"background", "ground", "pickups", "enemies", "player", "projectiles", "particles", "ui"}
+	if len(layers) != len(want) {
+		t.Fatalf("expected %d layers, got %d", len(want), len(layers))
+	}
+	for i, name := range want {
+		if layers[i].Name != name {
+			t.Errorf("layer %d: expected %q, got %q", i, name, layers[i].Name)
+		}
+	}
+}
+
+func TestLayerRegistry_RegisterLayer(t *testing.T) {
+	r := NewLayerRegistry()
+	if err := r.RegisterLayer(LayerEnemies+50, "shields", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info, ok := r.Layer(LayerEnemies + 50); !ok || info.Name != "shields" {
+		t.Errorf("expected registered layer, got %+v", info)
+	}
+	if err := r.RegisterLayer(LayerPlayer, "decals", false); err == nil {
+		t.Error("expected error registering over a built-in layer")
+	}
+	if err := r.RegisterLayer(LayerAuto, "auto", false); err == nil {
+		t.Error("expected error registering the auto layer")
+	}
+	if err := r.RegisterLayer(LayerEnemies+50, "shields", true); err != nil {
+		t.Errorf("expected re-registering the same name to update it, got %v", err)
+	}
+}
+
+func TestLayerRegistry_UnknownSpriteTypeAboveBackground(t *testing.T) {
+	r := NewLayerRegistry()
+	modType := SpriteType(42)
+	if layer := r.SpriteLayer(modType); layer <= LayerBackground {
+		t.Errorf("expected unregistered sprite type above the background, got %d", layer)
+	}
+	r.AssignSpriteType(modType, LayerPickups)
+	if layer := r.SpriteLayer(modType); layer != LayerPickups {
+		t.Errorf("expected assigned layer, got %d", layer)
+	}
+}
+
+func TestLayerRegistry_EntityLayer(t *testing.T) {
+	r := NewLayerRegistry()
+	world := engine.NewWorld()
+
+	enemy := world.CreateEntity()
+	world.AddComponent(enemy, "sprite", &SpriteComponent{Type: SpriteTypeEnemy})
+	if layer, _ := r.EntityLayer(world, enemy); layer != LayerEnemies {
+		t.Errorf("expected sprite type layer, got %d", layer)
+	}
+
+	// A renderable with LayerAuto keeps the sprite type's layer but sets Z
+	rd := NewRenderable()
+	rd.Z = 3
+	world.AddComponent(enemy, "renderable", rd)
+	if layer, z := r.EntityLayer(world, enemy); layer != LayerEnemies || z != 3 {
+		t.Errorf("expected enemies layer at z 3, got %d at z %d", layer, z)
+	}
+
+	rd.Layer = LayerUI
+	if layer, _ := r.EntityLayer(world, enemy); layer != LayerUI {
+		t.Errorf("expected renderable to override layer, got %d", layer)
+	}
+
+	bullet := world.CreateEntity()
+	world.AddComponent(bullet, "projectile", struct{}{})
+	if layer, _ := r.EntityLayer(world, bullet); layer != LayerProjectiles {
+		t.Errorf("expected bare projectile on projectile layer, got %d", layer)
+	}
+}
+
+func TestLayerRegistry_Sort(t *testing.T) {
+	r := NewLayerRegistry()
+	world := engine.NewWorld()
+	add := func(st SpriteType, z int, y float64) engine.Entity {
+		e := world.CreateEntity()
+		world.AddComponent(e, "sprite", &SpriteComponent{Type: st})
+		world.AddComponent(e, "position", &engine.Position{Y: y})
+		rd := NewRenderable()
+		rd.Z = z
+		world.AddComponent(e, "renderable", rd)
+		return e
+	}
+	bullet := add(SpriteTypeProjectile, 0, 0)
+	player := add(SpriteTypeShip, 0, 0)
+	eliteEnemy := add(SpriteTypeEnemy, 1, 0)
+	enemy := add(SpriteTypeEnemy, 0, 0)
+	rock := add(SpriteTypeObstacle, 0, 0)
+
+	entities := []engine.Entity{bullet, player, eliteEnemy, enemy, rock}
+	r.Sort(world, entities)
+	want := []engine.Entity{rock, enemy, eliteEnemy, player, bullet}
+	for i := range want {
+		if entities[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, entities)
+		}
+	}
+	if n := r.SplitAt(world, entities, LayerPlayer); n != 3 {
+		t.Errorf("expected 3 entities below the player layer, got %d", n)
+	}
+}
+
+func TestLayerRegistry_YSort(t *testing.T) {
+	r := NewLayerRegistry()
+	world := engine.NewWorld()
+	var entities []engine.Entity
+	for _, y := range []float64{300, 100, 200} {
+		e := world.CreateEntity()
+		world.AddComponent(e, "sprite", &SpriteComponent{Type: SpriteTypeObstacle})
+		world.AddComponent(e, "position", &engine.Position{Y: y})
+		entities = append(entities, e)
+	}
+
+	unsorted := append([]engine.Entity(nil), entities...)
+	r.Sort(world, unsorted)
+	for i := range entities {
+		if unsorted[i] != entities[i] {
+			t.Fatal("expected stable order without y-sorting")
+		}
+	}
+
+	r.SetYSort(LayerGround, true)
+	r.Sort(world, entities)
+	prev := -1.0
+	for _, e := range entities {
+		pos, _ := world.GetComponent(e, "position")
+		y := pos.(*engine.Position).Y
+		if y < prev {
+			t.Fatalf("expected ascending y, got %v after %v", y, prev)
+		}
+		prev = y
+	}
+}
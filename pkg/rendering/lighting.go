@@ -0,0 +1,200 @@
+package rendering
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+// Lighting tuning constants.
+const (
+	// LightTextureSize is the pixel size of the radial light texture.
+	LightTextureSize = 128
+	// LightGlowStrength scales the additive glow drawn over lit areas.
+	LightGlowStrength = 0.35
+	// ProjectileLightRadius is the glow radius of projectiles without a light.
+	ProjectileLightRadius = 28.0
+	// ProjectileLightIntensity is the glow strength of projectiles without a light.
+	ProjectileLightIntensity = 0.7
+)
+
+// ProjectileLightColor is the glow color of projectiles without a light.
+var ProjectileLightColor = color.RGBA{R: 255, G: 230, B: 160, A: 255}
+
+// Light is a component that makes an entity emit light. Transient lights
+// such as explosion flashes set Lifetime and fade out; the lighting system
+// removes their entities once they go dark.
+type Light struct {
+	Radius    float64
+	Color     color.RGBA
+	Intensity float64 // 0 (dark) to 1 (full color); may exceed 1 for overexposure
+	Lifetime  float64 // Seconds remaining for transient lights; 0 for permanent lights
+	MaxLife   float64
+}
+
+// LightSource is a light resolved to a world position for drawing.
+type LightSource struct {
+	X, Y      float64
+	Radius    float64
+	Color     color.RGBA
+	Intensity float64
+}
+
+// GenreAmbient returns the base light level of a genre's arenas. Horror arenas
+// are dark enough that ships are mostly seen by their own lights.
+func GenreAmbient(genreID string) color.RGBA {
+	switch genreID {
+	case "horror":
+		return color.RGBA{R: 40, G: 34, B: 48, A: 255}
+	case "cyberpunk":
+		return color.RGBA{R: 170, G: 150, B: 200, A: 255}
+	case "postapoc":
+		return color.RGBA{R: 210, G: 190, B: 160, A: 255}
+	case "fantasy":
+		return color.RGBA{R: 220, G: 210, B: 235, A: 255}
+	default: // scifi
+		return color.RGBA{R: 200, G: 210, B: 230, A: 255}
+	}
+}
+
+// LightingSystem manages light emitters and the arena's ambient light.
+type LightingSystem struct {
+	world    *engine.World
+	ambient  color.RGBA
+	toRemove []engine.Entity
+}
+
+// NewLightingSystem creates a lighting system using the genre's ambient light.
+func NewLightingSystem(world *engine.World, genreID string) *LightingSystem {
+	return &LightingSystem{
+		world:   world,
+		ambient: GenreAmbient(genreID),
+	}
+}
+
+// Ambient returns the light level of unlit areas.
+func (ls *LightingSystem) Ambient() color.RGBA {
+	return ls.ambient
+}
+
+// SetAmbient overrides the light level of unlit areas.
+func (ls *LightingSystem) SetAmbient(c color.RGBA) {
+	ls.ambient = c
+}
+
+// SpawnFlash creates a transient light at (x, y) that fades out over duration seconds.
+func (ls *LightingSystem) SpawnFlash(x, y, radius float64, c color.RGBA, duration float64) engine.Entity {
+	e := ls.world.CreateEntity()
+	ls.world.AddComponent(e, "position", &engine.Position{X: x, Y: y})
+	ls.world.AddComponent(e, "light", &Light{
+		Radius: radius, Color: c, Intensity: 1, Lifetime: duration, MaxLife: duration,
+	})
+	return e
+}
+
+// Update fades transient lights and removes them once they expire.
+func (ls *LightingSystem) Update(dt float64) {
+	ls.toRemove = ls.toRemove[:0]
+
+	ls.world.ForEachEntity(func(e engine.Entity) {
+		comp, ok := ls.world.GetComponent(e, "light")
+		if !ok {
+			return
+		}
+		light := comp.(*Light)
+		if light.MaxLife <= 0 {
+			return
+		}
+		light.Lifetime -= dt
+		if light.Lifetime <= 0 {
+			ls.toRemove = append(ls.toRemove, e)
+			return
+		}
+		light.Intensity = light.Lifetime / light.MaxLife
+	})
+
+	for _, e := range ls.toRemove {
+		ls.world.RemoveEntity(e)
+	}
+}
+
+// Sources returns every light in the world. Projectiles glow by default.
+func (ls *LightingSystem) Sources() []LightSource {
+	var sources []LightSource
+	ls.world.ForEachEntity(func(e engine.Entity) {
+		posComp, ok := ls.world.GetComponent(e, "position")
+		if !ok {
+			return
+		}
+		pos := posComp.(*engine.Position)
+		if comp, ok := ls.world.GetComponent(e, "light"); ok {
+			light := comp.(*Light)
+			if light.Intensity > 0 && light.Radius > 0 {
+				sources = append(sources, LightSource{
+					X: pos.X, Y: pos.Y, Radius: light.Radius, Color: light.Color, Intensity: light.Intensity,
+				})
+			}
+			return
+		}
+		if _, ok := ls.world.GetComponent(e, "projectile"); ok {
+			sources = append(sources, LightSource{
+				X: pos.X, Y: pos.Y, Radius: ProjectileLightRadius,
+				Color: ProjectileLightColor, Intensity: ProjectileLightIntensity,
+			})
+		}
+	})
+	return sources
+}
+
+// LightFalloff returns the brightness of a light at a fraction of its radius,
+// from 1 at the center to 0 at the edge.
+func LightFalloff(d float64) float64 {
+	if d >= 1 {
+		return 0
+	}
+	f := 1 - math.Max(d, 0)
+	return f * f
+}
+
+// GenerateLightTexture creates a white radial gradient used to stamp lights
+// onto a lightmap. Colors are premultiplied by the falloff.
+func GenerateLightTexture(size int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	half := float64(size) / 2
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			d := math.Hypot(float64(x)+0.5-half, float64(y)+0.5-half) / half
+			v := uint8(LightFalloff(d) * 255)
+			img.SetRGBA(x, y, color.RGBA{R: v, G: v, B: v, A: v})
+		}
+	}
+	return img
+}
+
+// BuildLightmap renders a lightmap in software. Each pixel is the ambient
+// light plus every source's falloff, where (originX, originY) is the world
+// position of the top-left pixel. The scene is multiplied by the result.
+func BuildLightmap(w, h int, ambient color.RGBA, sources []LightSource, originX, originY float64) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, g, b := float64(ambient.R), float64(ambient.G), float64(ambient.B)
+			wx, wy := originX+float64(x)+0.5, originY+float64(y)+0.5
+			for _, s := range sources {
+				f := LightFalloff(math.Hypot(wx-s.X, wy-s.Y)/s.Radius) * s.Intensity
+				r += float64(s.Color.R) * f
+				g += float64(s.Color.G) * f
+				b += float64(s.Color.B) * f
+			}
+			img.SetRGBA(x, y, color.RGBA{R: clampByte(r), G: clampByte(g), B: clampByte(b), A: 255})
+		}
+	}
+	return img
+}
+
+// clampByte rounds a channel value into the 0-255 range.
+func clampByte(v float64) uint8 {
+	return uint8(math.Max(0, math.Min(math.Round(v), 255)))
+}
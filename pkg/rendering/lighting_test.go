@@ -0,0 +1,99 @@
+package rendering
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+func TestGenreAmbient_HorrorIsDark(t *testing.T) {
+	horror := GenreAmbient("horror")
+	for _, id := range []string{"scifi", "fantasy", "cyberpunk", "postapoc"} {
+		if c := GenreAmbient(id); c.R <= horror.R || c.G <= horror.G {
+			t.Errorf("expected %s ambient %v brighter than horror %v", id, c, horror)
+		}
+	}
+}
+
+func TestLightingSystem_FlashFadesAndExpires(t *testing.T) {
+	world := engine.NewWorld()
+	ls := NewLightingSystem(world, "scifi")
+	e := ls.SpawnFlash(10, 10, 50, color.RGBA{R: 255, A: 255}, 0.5)
+
+	ls.Update(0.25)
+	comp, ok := world.GetComponent(e, "light")
+	if !ok {
+		t.Fatal("expected flash to still be lit")
+	}
+	if got := comp.(*Light).Intensity; got < 0.49 || got > 0.51 {
+		t.Errorf("expected intensity 0.5 halfway through, got %f", got)
+	}
+
+	ls.Update(0.3)
+	if world.EntityCount() != 0 {
+		t.Error("expected expired flash to be removed")
+	}
+}
+
+func TestLightingSystem_PermanentLightPersists(t *testing.T) {
+	world := engine.NewWorld()
+	ls := NewLightingSystem(world, "scifi")
+	e := world.CreateEntity()
+	world.AddComponent(e, "position", &engine.Position{X: 5, Y: 5})
+	world.AddComponent(e, "light", &Light{Radius: 40, Color: color.RGBA{G: 255, A: 255}, Intensity: 0.8})
+
+	ls.Update(10)
+	sources := ls.Sources()
+	if len(sources) != 1 || sources[0].Intensity != 0.8 {
+		t.Errorf("expected one permanent light at 0.8, got %+v", sources)
+	}
+}
+
+func TestLightingSystem_ProjectilesGlow(t *testing.T) {
+	world := engine.NewWorld()
+	ls := NewLightingSystem(world, "horror")
+	e := world.CreateEntity()
+	world.AddComponent(e, "position", &engine.Position{X: 20, Y: 30})
+	world.AddComponent(e, "projectile", struct{}{})
+
+	sources := ls.Sources()
+	if len(sources) != 1 {
+		t.Fatalf("expected projectile light, got %d sources", len(sources))
+	}
+	if sources[0].X != 20 || sources[0].Radius != ProjectileLightRadius {
+		t.Errorf("unexpected projectile light %+v", sources[0])
+	}
+}
+
+func TestLightFalloff(t *testing.T) {
+	if LightFalloff(0) != 1 {
+		t.Error("expected full brightness at the center")
+	}
+	if LightFalloff(1) != 0 || LightFalloff(2) != 0 {
+		t.Error("expected no light at or beyond the radius")
+	}
+	if LightFalloff(0.25) <= LightFalloff(0.75) {
+		t.Error("expected light to fall off with distance")
+	}
+}
+
+func TestGenerateLightTexture(t *testing.T) {
+	img := GenerateLightTexture(32)
+	if center, corner := img.RGBAAt(16, 16), img.RGBAAt(0, 0); center.A <= corner.A || corner.A != 0 {
+		t.Errorf("expected bright center and transparent corners, got %v and %v", center, corner)
+	}
+}
+
+func TestBuildLightmap(t *testing.T) {
+	ambient := GenreAmbient("horror")
+	light := LightSource{X: 110, Y: 110, Radius: 8, Color: color.RGBA{R: 255, G: 255, B: 255, A: 255}, Intensity: 1}
+	lm := BuildLightmap(20, 20, ambient, []LightSource{light}, 100, 100)
+
+	if c := lm.RGBAAt(0, 0); c.R != ambient.R || c.G != ambient.G || c.B != ambient.B {
+		t.Errorf("expected ambient light far from sources, got %v", c)
+	}
+	if c := lm.RGBAAt(10, 10); c.R < 240 {
+		t.Errorf("expected near full light at the source, got %v", c)
+	}
+}
@@ -0,0 +1,93 @@
+package rendering
+
+import (
+	"image"
+	"image/color"
+)
+
+// Minimap colors.
+var (
+	// MinimapBackground is the translucent fill behind the minimap.
+	MinimapBackground = color.RGBA{R: 0, G: 0, B: 0, A: 160}
+	// MinimapFrame is the color of the minimap border and view rectangle.
+	MinimapFrame = color.RGBA{R: 200, G: 200, B: 200, A: 255}
+)
+
+// MinimapBlip is a single entity marker on the minimap, in world coordinates.
+type MinimapBlip struct {
+	X, Y  float64
+	Color color.RGBA
+}
+
+// Minimap draws a scaled-down overview of a world larger than the screen.
+type Minimap struct {
+	Width, Height           int
+	WorldWidth, WorldHeight float64
+	image                   *image.RGBA
+}
+
+// NewMinimap creates a minimap of the given pixel size for a world of the given size.
+func NewMinimap(width, height, worldWidth, worldHeight int) *Minimap {
+	return &Minimap{
+		Width:       width,
+		Height:      height,
+		WorldWidth:  float64(worldWidth),
+		WorldHeight: float64(worldHeight),
+		image:       image.NewRGBA(image.Rect(0, 0, width, height)),
+	}
+}
+
+// ToMap converts world coordinates to minimap pixel coordinates.
+func (m *Minimap) ToMap(x, y float64) (int, int) {
+	return int(x / m.WorldWidth * float64(m.Width)), int(y / m.WorldHeight * float64(m.Height))
+}
+
+// Render draws the blips and the outline of the visible viewport. The
+// returned image is reused between calls.
+func (m *Minimap) Render(blips []MinimapBlip, viewport *Viewport) *image.RGBA {
+	img := m.image
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i] = MinimapBackground.R
+		img.Pix[i+1] = MinimapBackground.G
+		img.Pix[i+2] = MinimapBackground.B
+		img.Pix[i+3] = MinimapBackground.A
+	}
+
+	if viewport != nil {
+		x0, y0 := m.ToMap(viewport.X, viewport.Y)
+		x1, y1 := m.ToMap(viewport.X+viewport.Width, viewport.Y+viewport.Height)
+		m.drawRect(x0, y0, x1-1, y1-1, MinimapFrame)
+	}
+	m.drawRect(0, 0, m.Width-1, m.Height-1, MinimapFrame)
+
+	for _, b := range blips {
+		x, y := m.ToMap(b.X, b.Y)
+		// 2x2 dots stay visible after downscaling
+		for dy := 0; dy < 2; dy++ {
+			for dx := 0; dx < 2; dx++ {
+				m.set(x+dx, y+dy, b.Color)
+			}
+		}
+	}
+	return img
+}
+
+// drawRect outlines a rectangle, clipping to the minimap.
+func (m *Minimap) drawRect(x0, y0, x1, y1 int, c color.RGBA) {
+	for x := x0; x <= x1; x++ {
+		m.set(x, y0, c)
+		m.set(x, y1, c)
+	}
+	for y := y0; y <= y1; y++ {
+		m.set(x0, y, c)
+		m.set(x1, y, c)
+	}
+}
+
+// set writes a pixel if it lies inside the minimap.
+func (m *Minimap) set(x, y int, c color.RGBA) {
+	if x < 0 || y < 0 || x >= m.Width || y >= m.Height {
+		return
+	}
+	m.image.SetRGBA(x, y, c)
+}
@@ -0,0 +1,38 @@
+package rendering
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestMinimap_ToMap(t *testing.T) {
+	m := NewMinimap(100, 75, 4000, 3000)
+	if x, y := m.ToMap(2000, 1500); x != 50 || y != 37 {
+		t.Errorf("ToMap(2000, 1500) = (%d, %d), want (50, 37)", x, y)
+	}
+}
+
+func TestMinimap_Render(t *testing.T) {
+	m := NewMinimap(100, 100, 1000, 1000)
+	red := color.RGBA{R: 255, A: 255}
+
+	vp := NewViewport(200, 200)
+	vp.X, vp.Y = 400, 400
+	img := m.Render([]MinimapBlip{{X: 100, Y: 900, Color: red}}, vp)
+
+	if got := img.RGBAAt(10, 90); got != red {
+		t.Errorf("expected blip at (10, 90), got %v", got)
+	}
+	if got := img.RGBAAt(40, 50); got != MinimapFrame {
+		t.Errorf("expected view outline at (40, 50), got %v", got)
+	}
+	if got := img.RGBAAt(50, 50); got != MinimapBackground {
+		t.Errorf("expected background inside the view outline, got %v", got)
+	}
+
+	// Blips from the previous frame are cleared
+	img = m.Render(nil, vp)
+	if got := img.RGBAAt(10, 90); got != MinimapBackground {
+		t.Errorf("expected stale blip cleared, got %v", got)
+	}
+}
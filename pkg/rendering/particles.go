@@ -0,0 +1,482 @@
+package rendering
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// EmitterShape is the region new particles start in.
+type EmitterShape int
+
+const (
+	// ShapePoint emits every particle from the emitter position.
+	ShapePoint EmitterShape = iota
+	// ShapeCircle emits from anywhere inside a disc of the emitter's radius.
+	ShapeCircle
+	// ShapeRing emits from the edge of a circle of the emitter's radius.
+	ShapeRing
+)
+
+// ParticleDef describes how an emitter's particles move and change over
+// their lifetime. Colors and Sizes are keyframes spread evenly from birth to
+// death; particles are interpolated between them.
+type ParticleDef struct {
+	Speed, SpeedRange float64
+	Life, LifeRange   float64
+	Colors            []color.RGBA // Alpha fades with the keyframes; one entry holds a fixed color
+	Sizes             []float64    // Pixel size keyframes
+	Gravity           float64      // Downward acceleration in pixels per second squared
+	Drag              float64      // Fraction of velocity lost per 1/60 s; 0 for none
+	Additive          bool         // Blend by adding light, for sparks and flames
+}
+
+// EmitterDef configures an emitter: where particles start, which way they
+// head and how many it produces.
+type EmitterDef struct {
+	Particle ParticleDef
+	Rate     float64 // Particles per second while a continuous emitter is active
+	Burst    int     // Particles per one-shot burst
+	Shape    EmitterShape
+	Radius   float64
+	Spread   float64 // Cone width around the emit angle in radians; 2π for all directions
+}
+
+// Emitter is a continuous particle source placed in the world.
+type Emitter struct {
+	Def    *EmitterDef
+	X, Y   float64
+	Angle  float64
+	Active bool
+	accum  float64
+}
+
+// Particle is a single pooled particle.
+type Particle struct {
+	X, Y    float64
+	VX, VY  float64
+	Life    float64
+	MaxLife float64
+	Color   color.RGBA
+	Size    float64
+	Gravity float64
+	Drag    float64
+	def     *ParticleDef
+}
+
+// Progress returns how far the particle is through its life, from 0 to 1.
+func (p *Particle) Progress() float64 {
+	if p.MaxLife <= 0 {
+		return 1
+	}
+	return math.Max(0, math.Min(1-p.Life/p.MaxLife, 1))
+}
+
+// Appearance returns the particle's current color and pixel size. The color
+// is not premultiplied; its alpha carries the fade.
+func (p *Particle) Appearance() (color.RGBA, float64) {
+	t := p.Progress()
+	if p.def == nil {
+		c := p.Color
+		c.A = uint8(float64(c.A) * (1 - t))
+		return c, p.Size
+	}
+	c := p.Color
+	if len(p.def.Colors) > 0 {
+		c = gradientAt(p.def.Colors, t)
+	}
+	size := p.Size
+	if len(p.def.Sizes) > 0 {
+		size = curveAt(p.def.Sizes, t)
+	}
+	return c, size
+}
+
+// Additive returns true if the particle should be drawn with additive blending.
+func (p *Particle) Additive() bool {
+	return p.def != nil && p.def.Additive
+}
+
+// ParticleSystem owns a fixed-capacity pool of particles and the emitters
+// that feed it. Live particles are packed at the front of the pool, so
+// emitting and expiring particles never allocates.
+type ParticleSystem struct {
+	pool     []Particle
+	count    int
+	dropped  int
+	emitters []*Emitter
+	genreID  string
+	rng      *rand.Rand
+	mu       sync.Mutex
+}
+
+// NewParticleSystem creates an empty particle system with the default pool size.
+func NewParticleSystem() *ParticleSystem {
+	return NewParticleSystemWithCapacity(DefaultParticleCapacity)
+}
+
+// NewParticleSystemWithCapacity creates an empty particle system holding at
+// most capacity particles.
+func NewParticleSystemWithCapacity(capacity int) *ParticleSystem {
+	return &ParticleSystem{
+		pool:    make([]Particle, capacity),
+		genreID: "scifi",
+		rng:     rand.New(rand.NewSource(0)),
+	}
+}
+
+// SetGenre switches particle colors to match the given genre.
+func (ps *ParticleSystem) SetGenre(genreID string) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.genreID = genreID
+}
+
+// SetSeed sets the RNG seed for particle randomization.
+func (ps *ParticleSystem) SetSeed(seed int64) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.rng = rand.New(rand.NewSource(seed))
+}
+
+// Emit spawns new particles at the given position.
+func (ps *ParticleSystem) Emit(x, y float64, count int) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	c := ps.getParticleColor()
+	for i := 0; i < count; i++ {
+		angle := ps.rng.Float64() * TwoPi
+		speed := ParticleMinSpeed + ps.rng.Float64()*ParticleSpeedRange
+		life := ParticleMinLife + ps.rng.Float64()*ParticleLifeRange
+		ps.spawn(x, y, angle, speed, life, c, ParticleMinSize+ps.rng.Float64()*ParticleSizeRange)
+	}
+}
+
+// EmitDirectional spawns particles moving in a specific direction.
+func (ps *ParticleSystem) EmitDirectional(x, y, angle, spread float64, count int) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.emitDirectional(x, y, angle, spread, count, ps.getParticleColor())
+}
+
+// EmitDirectionalColored spawns directional particles of a fixed color.
+func (ps *ParticleSystem) EmitDirectionalColored(x, y, angle, spread float64, count int, c color.RGBA) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.emitDirectional(x, y, angle, spread, count, c)
+}
+
+// emitDirectional spawns count particles in a cone around angle.
+func (ps *ParticleSystem) emitDirectional(x, y, angle, spread float64, count int, c color.RGBA) {
+	for i := 0; i < count; i++ {
+		particleAngle := angle + (ps.rng.Float64()-0.5)*spread
+		speed := DirectionalMinSpeed + ps.rng.Float64()*DirectionalSpeedRange
+		life := DirectionalMinLife + ps.rng.Float64()*DirectionalLifeRange
+		ps.spawn(x, y, particleAngle, speed, life, c, DirectionalMinSize+ps.rng.Float64()*DirectionalSizeRange)
+	}
+}
+
+// EmitColored spawns slow-moving particles of a fixed color, used for auras
+// and other effects that should not follow the genre palette.
+func (ps *ParticleSystem) EmitColored(x, y float64, count int, c color.RGBA) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	for i := 0; i < count; i++ {
+		angle := ps.rng.Float64() * TwoPi
+		speed := DirectionalMinSpeed * ps.rng.Float64()
+		life := DirectionalMinLife + ps.rng.Float64()*DirectionalLifeRange
+		ps.spawn(x, y, angle, speed, life, c, DirectionalMinSize+ps.rng.Float64()*DirectionalSizeRange)
+	}
+}
+
+// spawn claims a pool slot for a particle using the default look, returning
+// nil when the pool is full.
+func (ps *ParticleSystem) spawn(x, y, angle, speed, life float64, c color.RGBA, size float64) *Particle {
+	if ps.count == len(ps.pool) {
+		ps.dropped++
+		return nil
+	}
+	p := &ps.pool[ps.count]
+	ps.count++
+	*p = Particle{
+		X: x, Y: y,
+		VX:   speed * math.Cos(angle),
+		VY:   speed * math.Sin(angle),
+		Life: life, MaxLife: life,
+		Color: c, Size: size,
+		Drag: ParticleDragCoeff,
+	}
+	return p
+}
+
+// Burst emits def.Burst particles at once from (x, y), heading along angle.
+func (ps *ParticleSystem) Burst(def *EmitterDef, x, y, angle float64) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.emitFrom(def, x, y, angle, def.Burst)
+}
+
+// AddEmitter places a continuous emitter in the world. It starts active.
+func (ps *ParticleSystem) AddEmitter(def *EmitterDef, x, y float64) *Emitter {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	e := &Emitter{Def: def, X: x, Y: y, Active: true}
+	ps.emitters = append(ps.emitters, e)
+	return e
+}
+
+// RemoveEmitter stops and removes a continuous emitter. Its particles live on.
+func (ps *ParticleSystem) RemoveEmitter(e *Emitter) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	for i, other := range ps.emitters {
+		if other == e {
+			ps.emitters = append(ps.emitters[:i], ps.emitters[i+1:]...)
+			return
+		}
+	}
+}
+
+// emitFrom spawns count particles following an emitter definition.
+func (ps *ParticleSystem) emitFrom(def *EmitterDef, x, y, angle float64, count int) {
+	pd := &def.Particle
+	for i := 0; i < count; i++ {
+		ox, oy := ps.shapeOffset(def)
+		heading := angle + (ps.rng.Float64()-0.5)*def.Spread
+		speed := pd.Speed + ps.rng.Float64()*pd.SpeedRange
+		life := pd.Life + ps.rng.Float64()*pd.LifeRange
+		p := ps.spawn(x+ox, y+oy, heading, speed, life, color.RGBA{R: 255, G: 255, B: 255, A: 255}, 1)
+		if p == nil {
+			return
+		}
+		p.Gravity = pd.Gravity
+		p.Drag = pd.Drag
+		p.def = pd
+	}
+}
+
+// shapeOffset returns a random start offset inside the emitter's shape.
+func (ps *ParticleSystem) shapeOffset(def *EmitterDef) (float64, float64) {
+	r := def.Radius
+	switch def.Shape {
+	case ShapeCircle:
+		r *= math.Sqrt(ps.rng.Float64())
+	case ShapeRing:
+	default:
+		return 0, 0
+	}
+	a := ps.rng.Float64() * TwoPi
+	return r * math.Cos(a), r * math.Sin(a)
+}
+
+// Update runs active emitters, then advances all particles by dt seconds and
+// removes expired ones.
+func (ps *ParticleSystem) Update(dt float64) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	for _, e := range ps.emitters {
+		if !e.Active {
+			e.accum = 0
+			continue
+		}
+		e.accum += e.Def.Rate * dt
+		n := int(e.accum)
+		e.accum -= float64(n)
+		ps.emitFrom(e.Def, e.X, e.Y, e.Angle, n)
+	}
+
+	for i := 0; i < ps.count; {
+		p := &ps.pool[i]
+		p.Life -= dt
+		if p.Life <= 0 {
+			// Swap the last live particle into this slot
+			ps.count--
+			ps.pool[i] = ps.pool[ps.count]
+			continue
+		}
+		p.VY += p.Gravity * dt
+		p.X += p.VX * dt
+		p.Y += p.VY * dt
+		if p.Drag != 0 {
+			drag := math.Pow(1-p.Drag, dt*60)
+			p.VX *= drag
+			p.VY *= drag
+		}
+		i++
+	}
+}
+
+// Visit calls fn for each live particle without copying the pool. fn must
+// not emit particles.
+func (ps *ParticleSystem) Visit(fn func(p *Particle)) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	for i := 0; i < ps.count; i++ {
+		fn(&ps.pool[i])
+	}
+}
+
+// GetParticles returns a copy of the current particles.
+func (ps *ParticleSystem) GetParticles() []Particle {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	result := make([]Particle, ps.count)
+	copy(result, ps.pool[:ps.count])
+	return result
+}
+
+// Count returns the number of active particles.
+func (ps *ParticleSystem) Count() int {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.count
+}
+
+// Capacity returns the size of the particle pool.
+func (ps *ParticleSystem) Capacity() int {
+	return len(ps.pool)
+}
+
+// Dropped returns how many particles were not emitted because the pool was full.
+func (ps *ParticleSystem) Dropped() int {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.dropped
+}
+
+// Clear removes all particles and emitters.
+func (ps *ParticleSystem) Clear() {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.count = 0
+	ps.emitters = ps.emitters[:0]
+}
+
+// getParticleColor returns a color appropriate for the current genre.
+func (ps *ParticleSystem) getParticleColor() color.RGBA {
+	switch ps.genreID {
+	case "scifi":
+		return color.RGBA{R: 100, G: 180, B: 255, A: 255}
+	case "fantasy":
+		return color.RGBA{R: 255, G: 200, B: 100, A: 255}
+	case "horror":
+		return color.RGBA{R: 150, G: 80, B: 80, A: 255}
+	case "cyberpunk":
+		return color.RGBA{R: 255, G: 0, B: 200, A: 255}
+	case "postapoc":
+		return color.RGBA{R: 200, G: 150, B: 80, A: 255}
+	default:
+		return color.RGBA{R: 255, G: 200, B: 100, A: 255}
+	}
+}
+
+// gradientAt interpolates evenly spaced color keyframes at t (0-1),
+// including alpha.
+func gradientAt(keys []color.RGBA, t float64) color.RGBA {
+	if len(keys) == 1 {
+		return keys[0]
+	}
+	pos := t * float64(len(keys)-1)
+	i := int(math.Min(pos, float64(len(keys)-2)))
+	f := pos - float64(i)
+	c := blendColor(keys[i], keys[i+1], f)
+	c.A = uint8(float64(keys[i].A) + (float64(keys[i+1].A)-float64(keys[i].A))*f)
+	return c
+}
+
+// curveAt interpolates evenly spaced keyframes at t (0-1).
+func curveAt(keys []float64, t float64) float64 {
+	if len(keys) == 1 {
+		return keys[0]
+	}
+	pos := t * float64(len(keys)-1)
+	i := int(math.Min(pos, float64(len(keys)-2)))
+	return keys[i] + (keys[i+1]-keys[i])*(pos-float64(i))
+}
+
+// ExplosionEmitter returns a burst of fiery debris fading from c to smoke.
+func ExplosionEmitter(c color.RGBA) *EmitterDef {
+	return &EmitterDef{
+		Particle: ParticleDef{
+			Speed: ParticleMinSpeed, SpeedRange: ParticleSpeedRange * 1.5,
+			Life: ParticleMinLife, LifeRange: ParticleLifeRange,
+			Colors: []color.RGBA{
+				{R: 255, G: 255, B: 220, A: 255},
+				c,
+				{R: c.R / 3, G: c.G / 3, B: c.B / 3, A: 0},
+			},
+			Sizes: []float64{ParticleMinSize + ParticleSizeRange, ParticleMinSize, 1},
+			Drag:  ParticleDragCoeff,
+		},
+		Burst:  24,
+		Shape:  ShapeCircle,
+		Radius: 4,
+		Spread: TwoPi,
+	}
+}
+
+// SparkEmitter returns a burst of fast, bright sparks drawn additively.
+func SparkEmitter(c color.RGBA) *EmitterDef {
+	return &EmitterDef{
+		Particle: ParticleDef{
+			Speed: 120, SpeedRange: 160,
+			Life: 0.15, LifeRange: 0.25,
+			Colors:   []color.RGBA{{R: 255, G: 255, B: 255, A: 255}, {R: c.R, G: c.G, B: c.B, A: 0}},
+			Sizes:    []float64{2, 1},
+			Gravity:  60,
+			Drag:     0.05,
+			Additive: true,
+		},
+		Burst:  16,
+		Shape:  ShapePoint,
+		Spread: TwoPi,
+	}
+}
+
+// SmokeEmitter returns slow, growing puffs of smoke.
+func SmokeEmitter() *EmitterDef {
+	return &EmitterDef{
+		Particle: ParticleDef{
+			Speed: 5, SpeedRange: 15,
+			Life: 0.8, LifeRange: 0.6,
+			Colors: []color.RGBA{{R: 90, G: 90, B: 90, A: 160}, {R: 60, G: 60, B: 60, A: 0}},
+			Sizes:  []float64{2, 6},
+			Drag:   0.03,
+		},
+		Burst:  8,
+		Rate:   30,
+		Shape:  ShapeCircle,
+		Radius: 6,
+		Spread: TwoPi,
+	}
+}
+
+// EngineTrailEmitter returns a continuous exhaust stream in the color c,
+// meant to be pointed opposite the ship's heading.
+func EngineTrailEmitter(c color.RGBA) *EmitterDef {
+	return &EmitterDef{
+		Particle: ParticleDef{
+			Speed: 60, SpeedRange: 40,
+			Life: 0.2, LifeRange: 0.15,
+			Colors:   []color.RGBA{{R: 255, G: 255, B: 255, A: 220}, {R: c.R, G: c.G, B: c.B, A: 0}},
+			Sizes:    []float64{3, 1},
+			Drag:     0.1,
+			Additive: true,
+		},
+		Rate:   90,
+		Shape:  ShapePoint,
+		Spread: 0.4,
+	}
+}
+
+// GenreColor returns the particle color the system uses for its genre.
+func (ps *ParticleSystem) GenreColor() color.RGBA {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.getParticleColor()
+}
@@ -0,0 +1,231 @@
+package rendering
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestParticleSystem_PoolCapacity(t *testing.T) {
+	ps := NewParticleSystemWithCapacity(10)
+	ps.Emit(0, 0, 25)
+	if ps.Count() != 10 {
+		t.Errorf("expected pool to hold 10 particles, got %d", ps.Count())
+	}
+	if ps.Dropped() != 15 {
+		t.Errorf("expected 15 dropped particles, got %d", ps.Dropped())
+	}
+	if ps.Capacity() != 10 {
+		t.Errorf("expected capacity 10, got %d", ps.Capacity())
+	}
+}
+
+func TestParticleSystem_ExpiredSlotsReused(t *testing.T) {
+	ps := NewParticleSystemWithCapacity(4)
+	ps.Emit(0, 0, 4)
+	ps.Update(10)
+	if ps.Count() != 0 {
+		t.Fatalf("expected all particles to expire, got %d", ps.Count())
+	}
+	ps.Emit(0, 0, 4)
+	if ps.Count() != 4 {
+		t.Errorf("expected freed slots to be reused, got %d", ps.Count())
+	}
+}
+
+func TestParticleSystem_EmitDoesNotAllocate(t *testing.T) {
+	ps := NewParticleSystem()
+	def := SparkEmitter(color.RGBA{R: 255, A: 255})
+	allocs := testing.AllocsPerRun(100, func() {
+		ps.Burst(def, 0, 0, 0)
+		ps.Update(1.0 / 60)
+	})
+	if allocs != 0 {
+		t.Errorf("expected emit and update to be allocation-free, got %v allocs", allocs)
+	}
+}
+
+func TestParticleSystem_DirectionAtLargeAngles(t *testing.T) {
+	// A truncated series diverges far from zero, so check angles past 2π
+	for _, angle := range []float64{math.Pi, 1.5 * math.Pi, 5.5} {
+		ps := NewParticleSystem()
+		ps.EmitDirectionalColored(0, 0, angle, 0, 1, color.RGBA{A: 255})
+		p := ps.GetParticles()[0]
+		speed := math.Hypot(p.VX, p.VY)
+		if speed < DirectionalMinSpeed || speed > DirectionalMinSpeed+DirectionalSpeedRange {
+			t.Errorf("angle %v: speed %v out of range", angle, speed)
+		}
+		got := math.Atan2(p.VY, p.VX)
+		if d := math.Abs(math.Remainder(got-angle, TwoPi)); d > 1e-9 {
+			t.Errorf("angle %v: particle heads %v", angle, got)
+		}
+	}
+}
+
+func TestParticleSystem_EmitterRate(t *testing.T) {
+	ps := NewParticleSystem()
+	def := &EmitterDef{
+		Particle: ParticleDef{Life: 10},
+		Rate:     30,
+	}
+	e := ps.AddEmitter(def, 0, 0)
+	for i := 0; i < 60; i++ {
+		ps.Update(1.0 / 60)
+	}
+	if n := ps.Count(); n < 29 || n > 30 {
+		t.Errorf("expected about 30 particles after one second at 30/s, got %d", n)
+	}
+
+	e.Active = false
+	ps.Update(1)
+	if n := ps.Count(); n > 30 {
+		t.Errorf("expected inactive emitter to stop, got %d particles", n)
+	}
+
+	ps.RemoveEmitter(e)
+	e.Active = true
+	ps.Update(1)
+	if n := ps.Count(); n > 30 {
+		t.Errorf("expected removed emitter to stop, got %d particles", n)
+	}
+}
+
+func TestParticleSystem_BurstShape(t *testing.T) {
+	ps := NewParticleSystem()
+	def := &EmitterDef{
+		Particle: ParticleDef{Life: 1},
+		Burst:    50,
+		Shape:    ShapeRing,
+		Radius:   10,
+	}
+	ps.Burst(def, 100, 100, 0)
+	if ps.Count() != 50 {
+		t.Fatalf("expected 50 particles, got %d", ps.Count())
+	}
+	ps.Visit(func(p *Particle) {
+		if d := math.Hypot(p.X-100, p.Y-100); math.Abs(d-10) > 1e-9 {
+			t.Errorf("expected ring particle at radius 10, got %v", d)
+		}
+	})
+}
+
+func TestParticle_Appearance(t *testing.T) {
+	def := &ParticleDef{
+		Colors: []color.RGBA{{R: 255, A: 255}, {B: 255, A: 0}},
+		Sizes:  []float64{4, 2, 0},
+	}
+	p := Particle{Life: 1, MaxLife: 1, def: def}
+	c, size := p.Appearance()
+	if c.R != 255 || c.A != 255 || size != 4 {
+		t.Errorf("expected birth color red and size 4, got %v size %v", c, size)
+	}
+
+	p.Life = 0.5
+	c, size = p.Appearance()
+	if c.A < 120 || c.A > 135 || c.R == 0 || c.B == 0 {
+		t.Errorf("expected half-faded mix at midlife, got %v", c)
+	}
+	if size != 2 {
+		t.Errorf("expected size 2 at midlife, got %v", size)
+	}
+
+	p.Life = 0
+	c, size = p.Appearance()
+	if c.A != 0 || size != 0 {
+		t.Errorf("expected faded and shrunk at death, got %v size %v", c, size)
+	}
+}
+
+func TestParticle_AppearanceLegacyFade(t *testing.T) {
+	p := Particle{Life: 0.25, MaxLife: 1, Color: color.RGBA{G: 200, A: 200}, Size: 3}
+	c, size := p.Appearance()
+	if c.A != 50 || c.G != 200 || size != 3 {
+		t.Errorf("expected alpha fade only, got %v size %v", c, size)
+	}
+	if p.Additive() {
+		t.Error("expected legacy particles to blend normally")
+	}
+}
+
+func TestParticleSystem_Gravity(t *testing.T) {
+	ps := NewParticleSystem()
+	def := &EmitterDef{
+		Particle: ParticleDef{Life: 5, Gravity: 100},
+		Burst:    1,
+	}
+	ps.Burst(def, 0, 0, 0)
+	for i := 0; i < 60; i++ {
+		ps.Update(1.0 / 60)
+	}
+	p := ps.GetParticles()[0]
+	if math.Abs(p.VY-100) > 1e-6 {
+		t.Errorf("expected VY 100 after 1s of gravity, got %v", p.VY)
+	}
+	if p.Y < 45 || p.Y > 55 {
+		t.Errorf("expected fall of about 50 pixels, got %v", p.Y)
+	}
+}
+
+func TestParticleSystem_DragFrameRateIndependent(t *testing.T) {
+	run := func(steps int) float64 {
+		ps := NewParticleSystem()
+		ps.Burst(&EmitterDef{Particle: ParticleDef{Speed: 100, Life: 5, Drag: 0.02}, Burst: 1}, 0, 0, 0)
+		for i := 0; i < steps; i++ {
+			ps.Update(1.0 / float64(steps))
+		}
+		return ps.GetParticles()[0].VX
+	}
+	if a, b := run(30), run(120); math.Abs(a-b) > 1e-6 {
+		t.Errorf("expected same velocity at 30 and 120 fps, got %v and %v", a, b)
+	}
+}
+
+func TestParticleSystem_ZeroDragKeepsVelocity(t *testing.T) {
+	ps := NewParticleSystem()
+	ps.Burst(&EmitterDef{Particle: ParticleDef{Speed: 100, Life: 5}, Burst: 1}, 0, 0, 0)
+	ps.Update(0.5)
+	if vx := ps.GetParticles()[0].VX; math.Abs(vx-100) > 1e-9 {
+		t.Errorf("expected an unset drag to keep velocity 100, got %v", vx)
+	}
+}
+
+func TestEmitterPresets(t *testing.T) {
+	c := color.RGBA{R: 200, G: 100, B: 50, A: 255}
+	for name, def := range map[string]*EmitterDef{
+		"explosion": ExplosionEmitter(c),
+		"sparks":    SparkEmitter(c),
+		"smoke":     SmokeEmitter(),
+		"trail":     EngineTrailEmitter(c),
+	} {
+		if def.Burst == 0 && def.Rate == 0 {
+			t.Errorf("%s: emits nothing", name)
+		}
+		if len(def.Particle.Colors) == 0 || len(def.Particle.Sizes) == 0 {
+			t.Errorf("%s: expected color gradient and size curve", name)
+		}
+	}
+	if !SparkEmitter(c).Particle.Additive {
+		t.Error("expected sparks to blend additively")
+	}
+}
+
+func TestParticleSystem_Clear(t *testing.T) {
+	ps := NewParticleSystem()
+	ps.Emit(0, 0, 5)
+	ps.AddEmitter(SmokeEmitter(), 0, 0)
+	ps.Clear()
+	ps.Update(1)
+	if ps.Count() != 0 {
+		t.Errorf("expected Clear to remove particles and emitters, got %d", ps.Count())
+	}
+}
+
+func BenchmarkParticleSystem_UpdateFullPool(b *testing.B) {
+	ps := NewParticleSystem()
+	def := &EmitterDef{Particle: ParticleDef{Speed: 50, Life: 1e9, Gravity: 10, Drag: 0.02}, Burst: ps.Capacity(), Spread: TwoPi}
+	ps.Burst(def, 0, 0, 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ps.Update(1.0 / 60)
+	}
+}
@@ -0,0 +1,187 @@
+package rendering
+
+import (
+	_ "embed"
+	"image"
+	"math"
+
+	"github.com/opd-ai/velocity/pkg/procgen/genre"
+)
+
+// PostFXShaderSource is the Kage source of the post-processing pass. Its
+// uniforms are the fields of PostFX plus Seed; see PostFX.Uniforms.
+//
+//go:embed shaders/postfx.kage
+var PostFXShaderSource []byte
+
+// Post-processing constants, shared with shaders/postfx.kage.
+const (
+	// BloomThreshold is the channel brightness above which light blooms.
+	BloomThreshold = 0.6
+	// BloomSpread is the pixel spacing between bloom and glow samples.
+	BloomSpread = 2.0
+	// BloomTaps is the width of the square bloom and glow sample grid.
+	BloomTaps = 5
+	// ScanlineDepth is how far full-strength scanlines darken alternate rows.
+	ScanlineDepth = 0.5
+	// GrainAmplitude is the brightness range of full-strength film grain.
+	GrainAmplitude = 0.15
+	// GrainSeedPeriod bounds grain seeds so the shader's hash keeps precision.
+	GrainSeedPeriod = 1024
+)
+
+// PostFX holds the strengths of each post-processing effect. A zero strength
+// disables an effect, except Saturation where 1 leaves colors unchanged.
+type PostFX struct {
+	Saturation float64
+	Bloom      float64
+	NeonGlow   float64
+	Grain      float64
+	Scanlines  float64
+	Vignette   float64
+}
+
+// NewPostFX derives post-processing settings from a genre preset. Presets
+// that leave Saturation unset keep their colors unchanged.
+func NewPostFX(p genre.Preset) PostFX {
+	saturation := p.Saturation
+	if saturation == 0 {
+		saturation = 1
+	}
+	return PostFX{
+		Saturation: saturation,
+		Bloom:      p.BloomScale,
+		NeonGlow:   p.NeonGlow,
+		Grain:      p.GrainLevel,
+		Scanlines:  p.Scanlines,
+		Vignette:   p.Vignette,
+	}
+}
+
+// Enabled returns true if any effect would change the image.
+func (fx PostFX) Enabled() bool {
+	return fx.Saturation != 1 || fx.Bloom > 0 || fx.NeonGlow > 0 ||
+		fx.Grain > 0 || fx.Scanlines > 0 || fx.Vignette > 0
+}
+
+// Uniforms returns the shader uniforms for the given grain seed.
+func (fx PostFX) Uniforms(seed int64) map[string]any {
+	return map[string]any{
+		"Saturation": float32(fx.Saturation),
+		"Bloom":      float32(fx.Bloom),
+		"NeonGlow":   float32(fx.NeonGlow),
+		"Grain":      float32(fx.Grain),
+		"Scanlines":  float32(fx.Scanlines),
+		"Vignette":   float32(fx.Vignette),
+		"Seed":       float32(grainSeed(seed)),
+	}
+}
+
+// Apply runs the post-processing chain in software. It matches the shader
+// closely enough for headless visual regression tests.
+func (fx PostFX) Apply(src *image.RGBA, seed int64) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(b)
+	w, h := float64(b.Dx()), float64(b.Dy())
+	s := grainSeed(seed)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c, a := pixelAt(src, x, y)
+			c = mixVec(splat(luma(c)), c, fx.Saturation)
+
+			if fx.Bloom > 0 || fx.NeonGlow > 0 {
+				glow, neon := fx.gather(src, x, y)
+				for i := range c {
+					c[i] += glow[i]*fx.Bloom + neon[i]*fx.NeonGlow
+				}
+			}
+
+			grain := (grainNoise(float64(x), float64(y), s) - 0.5) * GrainAmplitude * fx.Grain
+			shade := 1.0
+			if (y-b.Min.Y)%2 == 1 {
+				shade -= fx.Scanlines * ScanlineDepth
+			}
+			u := (float64(x-b.Min.X)+0.5)/w - 0.5
+			v := (float64(y-b.Min.Y)+0.5)/h - 0.5
+			d := math.Hypot(u, v) * math.Sqrt2
+			shade *= 1 - fx.Vignette*d*d
+
+			i := dst.PixOffset(x, y)
+			for ch := range c {
+				dst.Pix[i+ch] = toByte(math.Max(0, math.Min((c[ch]+grain)*shade, a)))
+			}
+			dst.Pix[i+3] = src.Pix[src.PixOffset(x, y)+3]
+		}
+	}
+	return dst
+}
+
+// gather averages the bloom and neon glow contributions around a pixel.
+func (fx PostFX) gather(src *image.RGBA, x, y int) ([3]float64, [3]float64) {
+	var glow, neon [3]float64
+	half := BloomTaps / 2
+	for i := -half; i <= half; i++ {
+		for j := -half; j <= half; j++ {
+			s, _ := pixelAt(src, x+i*BloomSpread, y+j*BloomSpread)
+			k := chroma(s)
+			for ch := range s {
+				glow[ch] += math.Max(s[ch]-BloomThreshold, 0)
+				neon[ch] += s[ch] * k
+			}
+		}
+	}
+	n := float64(BloomTaps * BloomTaps)
+	for ch := range glow {
+		glow[ch] /= n
+		neon[ch] /= n
+	}
+	return glow, neon
+}
+
+// pixelAt returns a pixel's premultiplied color and alpha in 0-1, treating
+// pixels outside the image as transparent black like the shader does.
+func pixelAt(img *image.RGBA, x, y int) ([3]float64, float64) {
+	if !(image.Point{X: x, Y: y}).In(img.Bounds()) {
+		return [3]float64{}, 0
+	}
+	i := img.PixOffset(x, y)
+	p := img.Pix[i : i+4]
+	return [3]float64{float64(p[0]) / 255, float64(p[1]) / 255, float64(p[2]) / 255}, float64(p[3]) / 255
+}
+
+// luma returns the perceived brightness of a color.
+func luma(c [3]float64) float64 {
+	return 0.299*c[0] + 0.587*c[1] + 0.114*c[2]
+}
+
+// chroma returns how far a color is from gray.
+func chroma(c [3]float64) float64 {
+	return math.Max(c[0], math.Max(c[1], c[2])) - math.Min(c[0], math.Min(c[1], c[2]))
+}
+
+// splat returns a gray color with every channel set to v.
+func splat(v float64) [3]float64 {
+	return [3]float64{v, v, v}
+}
+
+// mixVec interpolates between two colors by t, extrapolating beyond 0-1.
+func mixVec(a, b [3]float64, t float64) [3]float64 {
+	return [3]float64{a[0] + (b[0]-a[0])*t, a[1] + (b[1]-a[1])*t, a[2] + (b[2]-a[2])*t}
+}
+
+// grainNoise is the shader's hash noise, returning a value in 0-1.
+func grainNoise(x, y, seed float64) float64 {
+	v := math.Sin((x+seed)*12.9898+(y+seed)*78.233) * 43758.5453
+	return v - math.Floor(v)
+}
+
+// grainSeed wraps a frame counter into the range the noise hash handles well.
+func grainSeed(seed int64) float64 {
+	return float64(((seed % GrainSeedPeriod) + GrainSeedPeriod) % GrainSeedPeriod)
+}
+
+// toByte converts a 0-1 channel value to a rounded byte.
+func toByte(v float64) uint8 {
+	return uint8(math.Round(v * 255))
+}
@@ -0,0 +1,123 @@
+package rendering
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/procgen/genre"
+)
+
+// postFXTestImage returns an opaque gradient with a bright spot in the middle.
+func postFXTestImage() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 32, 32))
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			img.SetRGBA(x, y, color.RGBA{R: uint8(x * 4), G: 80, B: uint8(y * 4), A: 255})
+		}
+	}
+	img.SetRGBA(16, 16, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+	return img
+}
+
+func TestNewPostFX_FromPresets(t *testing.T) {
+	for _, id := range genre.All() {
+		fx := NewPostFX(genre.GetPreset(id))
+		if !fx.Enabled() {
+			t.Errorf("expected post-processing for genre %s", id)
+		}
+		if fx.Saturation <= 0 {
+			t.Errorf("genre %s: expected positive saturation, got %f", id, fx.Saturation)
+		}
+	}
+	if fx := NewPostFX(genre.Preset{}); fx.Enabled() {
+		t.Errorf("expected empty preset to disable post-processing, got %+v", fx)
+	}
+}
+
+func TestPostFX_NeutralIsIdentity(t *testing.T) {
+	src := postFXTestImage()
+	out := PostFX{Saturation: 1}.Apply(src, 0)
+	if !bytes.Equal(src.Pix, out.Pix) {
+		t.Error("expected neutral post-processing to leave the image unchanged")
+	}
+}
+
+func TestPostFX_Desaturate(t *testing.T) {
+	out := PostFX{}.Apply(postFXTestImage(), 0)
+	c := out.RGBAAt(28, 4)
+	if c.R != c.G || c.G != c.B {
+		t.Errorf("expected gray at zero saturation, got %v", c)
+	}
+}
+
+func TestPostFX_BloomBrightensNeighbors(t *testing.T) {
+	src := postFXTestImage()
+	out := PostFX{Saturation: 1, Bloom: 1}.Apply(src, 0)
+	before, after := src.RGBAAt(18, 16), out.RGBAAt(18, 16)
+	if after.G <= before.G {
+		t.Errorf("expected bloom around bright pixel, got %v -> %v", before, after)
+	}
+	if far := out.RGBAAt(2, 2); far != src.RGBAAt(2, 2) {
+		t.Errorf("expected dim pixels far from the highlight to be unchanged, got %v", far)
+	}
+}
+
+func TestPostFX_ScanlinesDarkenOddRows(t *testing.T) {
+	src := postFXTestImage()
+	out := PostFX{Saturation: 1, Scanlines: 1}.Apply(src, 0)
+	if out.RGBAAt(4, 4) != src.RGBAAt(4, 4) {
+		t.Error("expected even rows to be unchanged")
+	}
+	if out.RGBAAt(4, 5).G >= src.RGBAAt(4, 5).G {
+		t.Error("expected odd rows to be darkened")
+	}
+}
+
+func TestPostFX_VignetteDarkensCorners(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 32, 32))
+	for i := range src.Pix {
+		src.Pix[i] = 200
+	}
+	out := PostFX{Saturation: 1, Vignette: 1}.Apply(src, 0)
+	if center, corner := out.RGBAAt(16, 16).R, out.RGBAAt(0, 0).R; corner >= center {
+		t.Errorf("expected corner (%d) darker than center (%d)", corner, center)
+	}
+}
+
+func TestPostFX_GrainDeterministic(t *testing.T) {
+	fx := PostFX{Saturation: 1, Grain: 1}
+	src := postFXTestImage()
+	a, b := fx.Apply(src, 7), fx.Apply(src, 7)
+	if !bytes.Equal(a.Pix, b.Pix) {
+		t.Error("expected identical grain for the same seed")
+	}
+	if c := fx.Apply(src, 8); bytes.Equal(a.Pix, c.Pix) {
+		t.Error("expected grain to change with the seed")
+	}
+}
+
+func TestPostFX_PreservesAlpha(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	src.SetRGBA(4, 4, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+	out := NewPostFX(genre.GetPreset(genre.SciFi)).Apply(src, 0)
+	if c := out.RGBAAt(0, 0); c.A != 0 || c.R != 0 {
+		t.Errorf("expected transparent pixels to stay transparent, got %v", c)
+	}
+}
+
+func TestPostFX_Uniforms(t *testing.T) {
+	u := NewPostFX(genre.GetPreset(genre.Cyberpunk)).Uniforms(GrainSeedPeriod + 3)
+	for _, name := range []string{"Saturation", "Bloom", "NeonGlow", "Grain", "Scanlines", "Vignette", "Seed"} {
+		if _, ok := u[name]; !ok {
+			t.Errorf("missing uniform %s", name)
+		}
+	}
+	if u["Seed"].(float32) != 3 {
+		t.Errorf("expected seed to wrap to 3, got %v", u["Seed"])
+	}
+	if len(PostFXShaderSource) == 0 {
+		t.Error("expected embedded shader source")
+	}
+}
@@ -0,0 +1,63 @@
+package rendering
+
+import (
+	"image/color"
+	"math"
+)
+
+// SpriteForwardAngle is the direction generated sprites face in their own
+// image: nose toward the top row. Rotation components use 0 = +X, so sprites
+// are turned by Rotation.Angle - SpriteForwardAngle when drawn.
+const SpriteForwardAngle = -math.Pi / 2
+
+// Faction and status tints. Tints multiply sprite colors, so white leaves a
+// sprite unchanged and one generated sprite can serve several factions.
+var (
+	// TintNone leaves a sprite's colors unchanged.
+	TintNone = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	// TintFriendly marks allied ships such as the escorted convoy.
+	TintFriendly = color.RGBA{R: 140, G: 220, B: 255, A: 255}
+	// TintElite marks elite enemies such as assassination targets.
+	TintElite = color.RGBA{R: 255, G: 200, B: 90, A: 255}
+	// TintSlowed marks enemies caught in a time slow.
+	TintSlowed = color.RGBA{R: 150, G: 190, B: 255, A: 255}
+)
+
+// Renderable is a component with per-entity draw modifiers. Entities without
+// one draw at native size, untinted and opaque.
+type Renderable struct {
+	Scale float64    // Size multiplier; 1 draws at the sprite's native size
+	Tint  color.RGBA // Base color modulation, usually a faction tint
+	Alpha float64    // Opacity from 0 (invisible) to 1
+
+	// FaceVelocity orients entities without a rotation component along
+	// their direction of travel.
+	FaceVelocity bool
+
+	Layer RenderLayer // Draw layer; LayerAuto uses the sprite type's layer
+	Z     int         // Order within the layer; higher draws on top
+}
+
+// NewRenderable creates a renderable that draws a sprite unmodified.
+func NewRenderable() *Renderable {
+	return &Renderable{Scale: 1, Tint: TintNone, Alpha: 1}
+}
+
+// DrawAngle converts a rotation angle to the angle to rotate a sprite image by.
+func DrawAngle(rotation float64) float64 {
+	return rotation - SpriteForwardAngle
+}
+
+// BlendTint mixes a tint toward another by t (0-1). Status effects use it to
+// layer over faction tints.
+func BlendTint(base, overlay color.RGBA, t float64) color.RGBA {
+	t = math.Max(0, math.Min(t, 1))
+	return blendColor(base, overlay, t)
+}
+
+// ColorScale returns the premultiplied color multipliers for a tint and
+// alpha, in the form ebiten.ColorScale.Scale expects.
+func ColorScale(tint color.RGBA, alpha float64) (float32, float32, float32, float32) {
+	a := float32(math.Max(0, math.Min(alpha, 1)))
+	return float32(tint.R) / 255 * a, float32(tint.G) / 255 * a, float32(tint.B) / 255 * a, a
+}
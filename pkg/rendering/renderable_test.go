@@ -0,0 +1,46 @@
+package rendering
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestNewRenderable_Neutral(t *testing.T) {
+	r := NewRenderable()
+	if rs, gs, bs, as := ColorScale(r.Tint, r.Alpha); rs != 1 || gs != 1 || bs != 1 || as != 1 {
+		t.Errorf("expected neutral color scale, got (%f, %f, %f, %f)", rs, gs, bs, as)
+	}
+	if r.Scale != 1 {
+		t.Errorf("expected scale 1, got %f", r.Scale)
+	}
+}
+
+func TestColorScale_Premultiplied(t *testing.T) {
+	rs, gs, bs, as := ColorScale(color.RGBA{R: 255, G: 0, B: 51, A: 255}, 0.5)
+	if rs != 0.5 || gs != 0 || bs != 0.1 || as != 0.5 {
+		t.Errorf("expected (0.5, 0, 0.1, 0.5), got (%f, %f, %f, %f)", rs, gs, bs, as)
+	}
+	if _, _, _, as := ColorScale(TintNone, 3); as != 1 {
+		t.Errorf("expected alpha clamped to 1, got %f", as)
+	}
+}
+
+func TestBlendTint(t *testing.T) {
+	if got := BlendTint(TintNone, TintElite, 0); got != TintNone {
+		t.Errorf("expected base tint at t=0, got %v", got)
+	}
+	if got := BlendTint(TintNone, TintElite, 2); got != TintElite {
+		t.Errorf("expected overlay tint at t>=1, got %v", got)
+	}
+}
+
+func TestDrawAngle(t *testing.T) {
+	// A ship facing up (rotation -pi/2) draws its nose-up sprite unrotated
+	if got := DrawAngle(-math.Pi / 2); got != 0 {
+		t.Errorf("DrawAngle(-pi/2) = %f, want 0", got)
+	}
+	if got := DrawAngle(0); got != math.Pi/2 {
+		t.Errorf("DrawAngle(0) = %f, want pi/2", got)
+	}
+}
@@ -5,7 +5,6 @@ package rendering
 import (
 	"fmt"
 	"image"
-	"image/color"
 	"math"
 	"math/rand"
 	"sync"
@@ -29,8 +28,8 @@ const (
 	ParticleMinSize = 2.0
 	// ParticleSizeRange is the random variation in particle size.
 	ParticleSizeRange = 3.0
-	// ParticleDragCoeff is the velocity dampening per frame.
-	ParticleDragCoeff = 0.98
+	// ParticleDragCoeff is the fraction of velocity lost per frame.
+	ParticleDragCoeff = 0.02
 )
 
 // Directional particle constants.
@@ -51,15 +50,21 @@ const (
 
 // Particle pool constants.
 const (
-	// DefaultParticleCapacity is the initial particle slice capacity.
-	DefaultParticleCapacity = 256
+	// DefaultParticleCapacity is the fixed size of the particle pool. Emits
+	// beyond it are dropped rather than growing the pool.
+	DefaultParticleCapacity = 32768
 )
 
 // Renderer handles all drawing operations.
 type Renderer struct {
 	genreID string
+	seed    int64
 	cache   *SpriteCache
 	rng     *rand.Rand
+
+	// Parallax layers for the current genre, seed and screen size
+	background    []BackgroundLayer
+	backgroundKey string
 }
 
 // NewRenderer creates a new renderer.
@@ -78,6 +83,7 @@ func (r *Renderer) SetGenre(genreID string) {
 
 // SetSeed sets the RNG seed for sprite generation.
 func (r *Renderer) SetSeed(seed int64) {
+	r.seed = seed
 	r.rng = rand.New(rand.NewSource(seed))
 }
 
@@ -87,18 +93,21 @@ func (r *Renderer) GetGenre() string {
 }
 
 // GetOrCreateShipSprite returns a cached ship sprite or generates a new one.
+// The variant selects the hull class, modulo HullClassCount.
 func (r *Renderer) GetOrCreateShipSprite(variant, size int) *image.RGBA {
 	key := SpriteKey{GenreID: r.genreID, Type: SpriteTypeShip, Variant: variant}
 	return r.cache.GetOrCreate(key, func() *image.RGBA {
-		return GenerateShipSprite(r.rng, r.genreID, size)
+		return GenerateHullSprite(r.rng, r.genreID, HullClass(abs(variant)%int(HullClassCount)), size)
 	})
 }
 
 // GetOrCreateEnemySprite returns a cached enemy sprite or generates a new one.
+// The variant selects the archetype, modulo EnemyArchetypeCount.
 func (r *Renderer) GetOrCreateEnemySprite(variant, size int) *image.RGBA {
 	key := SpriteKey{GenreID: r.genreID, Type: SpriteTypeEnemy, Variant: variant}
 	return r.cache.GetOrCreate(key, func() *image.RGBA {
-		return GenerateEnemySprite(r.rng, r.genreID, size)
+		archetype := EnemyArchetype(abs(variant) % int(EnemyArchetypeCount))
+		return GenerateEnemyArchetypeSprite(r.rng, r.genreID, archetype, size)
 	})
 }
 
@@ -110,9 +119,51 @@ func (r *Renderer) GetOrCreateProjectileSprite(variant, size int) *image.RGBA {
 	})
 }
 
+// GetOrCreatePickupSprite returns a cached pickup sprite or generates a new one.
+func (r *Renderer) GetOrCreatePickupSprite(variant, size int) *image.RGBA {
+	key := SpriteKey{GenreID: r.genreID, Type: SpriteTypePickup, Variant: variant}
+	return r.cache.GetOrCreate(key, func() *image.RGBA {
+		return GeneratePickupSprite(r.genreID, variant, size)
+	})
+}
+
+// GetOrCreateObstacleSprite returns a cached obstacle sprite or generates a new one.
+func (r *Renderer) GetOrCreateObstacleSprite(variant, size int) *image.RGBA {
+	key := SpriteKey{GenreID: r.genreID, Type: SpriteTypeObstacle, Variant: variant}
+	return r.cache.GetOrCreate(key, func() *image.RGBA {
+		return GenerateObstacleSprite(r.genreID, variant, size)
+	})
+}
+
+// GetOrCreateAnimationFrame returns a cached animation frame derived from the
+// static sprite base, or base itself for frame 0.
+func (r *Renderer) GetOrCreateAnimationFrame(base *image.RGBA, spriteType SpriteType, variant, frame int) *image.RGBA {
+	if frame == 0 || base == nil {
+		return base
+	}
+	key := SpriteKey{GenreID: r.genreID, Type: spriteType, Variant: variant, Frame: frame}
+	return r.cache.GetOrCreate(key, func() *image.RGBA {
+		state, n := AnimFrameFromIndex(frame)
+		return GenerateAnimationFrame(base, state, n)
+	})
+}
+
+// GetOrCreateBackground returns the cached parallax layers for a w by h
+// screen, regenerating them when the genre, seed or size changes.
+func (r *Renderer) GetOrCreateBackground(w, h int) []BackgroundLayer {
+	key := fmt.Sprintf("%s:%d:%dx%d", r.genreID, r.seed, w, h)
+	if key != r.backgroundKey {
+		r.background = GenerateBackground(r.genreID, r.seed, w, h)
+		r.backgroundKey = key
+	}
+	return r.background
+}
+
 // ClearCache clears the sprite cache (e.g., after genre change).
 func (r *Renderer) ClearCache() {
 	r.cache.Clear()
+	r.background = nil
+	r.backgroundKey = ""
 }
 
 // SpriteCache caches generated sprite bitmaps keyed by genre and variant.
@@ -128,7 +179,7 @@ func NewSpriteCache() *SpriteCache {
 
 // keyString converts a SpriteKey to a cache key string.
 func keyString(key SpriteKey) string {
-	return fmt.Sprintf("%s:%d:%d", key.GenreID, key.Type, key.Variant)
+	return fmt.Sprintf("%s:%d:%d:%d", key.GenreID, key.Type, key.Variant, key.Frame)
 }
 
 // Get retrieves a sprite from the cache.
@@ -177,171 +228,6 @@ type SpriteComponent struct {
 	Size    int
 }
 
-// Particle represents a single particle in the particle system.
-type Particle struct {
-	X, Y    float64
-	VX, VY  float64
-	Life    float64
-	MaxLife float64
-	Color   color.RGBA
-	Size    float64
-}
-
-// ParticleSystem manages a collection of particles.
-type ParticleSystem struct {
-	particles []Particle
-	genreID   string
-	rng       *rand.Rand
-	mu        sync.Mutex
-}
-
-// NewParticleSystem creates an empty particle system.
-func NewParticleSystem() *ParticleSystem {
-	return &ParticleSystem{
-		particles: make([]Particle, 0, DefaultParticleCapacity),
-		genreID:   "scifi",
-		rng:       rand.New(rand.NewSource(0)),
-	}
-}
-
-// SetGenre switches particle colors to match the given genre.
-func (ps *ParticleSystem) SetGenre(genreID string) {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-	ps.genreID = genreID
-}
-
-// SetSeed sets the RNG seed for particle randomization.
-func (ps *ParticleSystem) SetSeed(seed int64) {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-	ps.rng = rand.New(rand.NewSource(seed))
-}
-
-// Emit spawns new particles at the given position.
-func (ps *ParticleSystem) Emit(x, y float64, count int) {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-
-	// Pre-allocate new particles slice
-	newParticles := make([]Particle, count)
-	for i := 0; i < count; i++ {
-		angle := ps.rng.Float64() * TwoPi
-		speed := ParticleMinSpeed + ps.rng.Float64()*ParticleSpeedRange
-		life := ParticleMinLife + ps.rng.Float64()*ParticleLifeRange
-
-		newParticles[i] = Particle{
-			X:       x,
-			Y:       y,
-			VX:      speed * cosApprox(angle),
-			VY:      speed * sinApprox(angle),
-			Life:    life,
-			MaxLife: life,
-			Color:   ps.getParticleColor(),
-			Size:    ParticleMinSize + ps.rng.Float64()*ParticleSizeRange,
-		}
-	}
-	ps.particles = append(ps.particles, newParticles...)
-}
-
-// EmitDirectional spawns particles moving in a specific direction.
-func (ps *ParticleSystem) EmitDirectional(x, y, angle, spread float64, count int) {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-
-	// Pre-allocate new particles slice
-	newParticles := make([]Particle, count)
-	for i := 0; i < count; i++ {
-		particleAngle := angle + (ps.rng.Float64()-0.5)*spread
-		speed := DirectionalMinSpeed + ps.rng.Float64()*DirectionalSpeedRange
-		life := DirectionalMinLife + ps.rng.Float64()*DirectionalLifeRange
-
-		newParticles[i] = Particle{
-			X:       x,
-			Y:       y,
-			VX:      speed * cosApprox(particleAngle),
-			VY:      speed * sinApprox(particleAngle),
-			Life:    life,
-			MaxLife: life,
-			Color:   ps.getParticleColor(),
-			Size:    DirectionalMinSize + ps.rng.Float64()*DirectionalSizeRange,
-		}
-	}
-	ps.particles = append(ps.particles, newParticles...)
-}
-
-// Update advances all particles by dt seconds and removes expired ones.
-func (ps *ParticleSystem) Update(dt float64) {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-
-	alive := ps.particles[:0]
-	for i := range ps.particles {
-		p := &ps.particles[i]
-		p.X += p.VX * dt
-		p.Y += p.VY * dt
-		p.Life -= dt
-
-		// Apply drag
-		p.VX *= ParticleDragCoeff
-		p.VY *= ParticleDragCoeff
-
-		if p.Life > 0 {
-			alive = append(alive, *p)
-		}
-	}
-	ps.particles = alive
-}
-
-// GetParticles returns a copy of the current particles for rendering.
-func (ps *ParticleSystem) GetParticles() []Particle {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-
-	result := make([]Particle, len(ps.particles))
-	copy(result, ps.particles)
-	return result
-}
-
-// Count returns the number of active particles.
-func (ps *ParticleSystem) Count() int {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-	return len(ps.particles)
-}
-
-// getParticleColor returns a color appropriate for the current genre.
-func (ps *ParticleSystem) getParticleColor() color.RGBA {
-	switch ps.genreID {
-	case "scifi":
-		return color.RGBA{R: 100, G: 180, B: 255, A: 255}
-	case "fantasy":
-		return color.RGBA{R: 255, G: 200, B: 100, A: 255}
-	case "horror":
-		return color.RGBA{R: 150, G: 80, B: 80, A: 255}
-	case "cyberpunk":
-		return color.RGBA{R: 255, G: 0, B: 200, A: 255}
-	case "postapoc":
-		return color.RGBA{R: 200, G: 150, B: 80, A: 255}
-	default:
-		return color.RGBA{R: 255, G: 200, B: 100, A: 255}
-	}
-}
-
-// cosApprox is a fast cosine approximation.
-func cosApprox(x float64) float64 {
-	// Taylor series approximation
-	x2 := x * x
-	return 1 - x2/2 + x2*x2/24
-}
-
-// sinApprox is a fast sine approximation.
-func sinApprox(x float64) float64 {
-	// Taylor series approximation
-	x2 := x * x
-	return x - x*x2/6 + x*x2*x2/120
-}
-
 // DrawBatch represents a batch of entities to draw together.
 type DrawBatch struct {
 	Type     SpriteType
@@ -350,16 +236,25 @@ type DrawBatch struct {
 
 // CreateDrawBatches groups entities by sprite type for batched rendering.
 func CreateDrawBatches(world *engine.World) []DrawBatch {
-	batches := make(map[SpriteType][]engine.Entity)
-
+	var entities []engine.Entity
 	world.ForEachEntity(func(e engine.Entity) {
+		entities = append(entities, e)
+	})
+	return GroupDrawBatches(world, entities)
+}
+
+// GroupDrawBatches groups the given entities by sprite type, skipping those
+// without a sprite. Use it with culled entity lists.
+func GroupDrawBatches(world *engine.World, entities []engine.Entity) []DrawBatch {
+	batches := make(map[SpriteType][]engine.Entity)
+	for _, e := range entities {
 		spriteComp, hasSprite := world.GetComponent(e, "sprite")
 		if !hasSprite {
-			return
+			continue
 		}
 		sprite := spriteComp.(*SpriteComponent)
 		batches[sprite.Type] = append(batches[sprite.Type], e)
-	})
+	}
 
 	result := make([]DrawBatch, 0, len(batches))
 	for spriteType, entities := range batches {
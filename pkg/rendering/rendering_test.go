@@ -134,6 +134,43 @@ func TestParticleSystem_Emit(t *testing.T) {
 	}
 }
 
+func TestParticleSystem_EmitColored(t *testing.T) {
+	ps := NewParticleSystem()
+	ps.SetSeed(12345)
+
+	aura := color.RGBA{R: 10, G: 20, B: 30, A: 255}
+	ps.EmitColored(50, 50, 4, aura)
+
+	for _, p := range ps.GetParticles() {
+		if p.Color != aura {
+			t.Errorf("expected aura color %v, got %v", aura, p.Color)
+		}
+	}
+	if ps.Count() != 4 {
+		t.Errorf("expected 4 particles, got %d", ps.Count())
+	}
+}
+
+func TestParticleSystem_EmitDirectionalColored(t *testing.T) {
+	ps := NewParticleSystem()
+	ps.SetSeed(12345)
+
+	rain := color.RGBA{R: 200, G: 0, B: 0, A: 255}
+	ps.EmitDirectionalColored(50, 50, 0, 0, 3, rain)
+
+	if ps.Count() != 3 {
+		t.Fatalf("expected 3 particles, got %d", ps.Count())
+	}
+	for _, p := range ps.GetParticles() {
+		if p.Color != rain {
+			t.Errorf("expected color %v, got %v", rain, p.Color)
+		}
+		if p.VX <= 0 || p.VY != 0 {
+			t.Errorf("expected particle moving along +X, got (%f, %f)", p.VX, p.VY)
+		}
+	}
+}
+
 func TestParticleSystem_Update(t *testing.T) {
 	ps := NewParticleSystem()
 	ps.SetSeed(12345)
@@ -242,6 +279,20 @@ func TestCreateDrawBatches(t *testing.T) {
 	}
 }
 
+func TestGroupDrawBatches_OnlyGivenEntities(t *testing.T) {
+	world := engine.NewWorld()
+	ship := world.CreateEntity()
+	world.AddComponent(ship, "sprite", &SpriteComponent{Type: SpriteTypeShip, Size: 16})
+	enemy := world.CreateEntity()
+	world.AddComponent(enemy, "sprite", &SpriteComponent{Type: SpriteTypeEnemy, Size: 16})
+	bare := world.CreateEntity()
+
+	batches := GroupDrawBatches(world, []engine.Entity{enemy, bare})
+	if len(batches) != 1 || batches[0].Type != SpriteTypeEnemy || len(batches[0].Entities) != 1 {
+		t.Errorf("expected a single enemy batch, got %+v", batches)
+	}
+}
+
 func TestSpriteComponent(t *testing.T) {
 	sc := SpriteComponent{
 		Type:    SpriteTypeEnemy,
@@ -0,0 +1,428 @@
+package rendering
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand"
+)
+
+// HullClass selects the part rules for player and allied ship sprites. The
+// classes match the hull names in the class package.
+type HullClass int
+
+const (
+	// HullScout is a slim single-engine hull with a nose gun.
+	HullScout HullClass = iota
+	// HullInterceptor has wide swept wings carrying its guns.
+	HullInterceptor
+	// HullGunship is a broad hull armed at the nose and wings.
+	HullGunship
+	// HullCarrier is the bulkiest hull, with twin engines.
+	HullCarrier
+	// HullClassCount is the number of hull classes.
+	HullClassCount
+)
+
+// EnemyArchetype selects the part rules for enemy sprites.
+type EnemyArchetype int
+
+const (
+	// EnemyDrone is a stubby wingless pod.
+	EnemyDrone EnemyArchetype = iota
+	// EnemyFighter has forward-swept wings with guns.
+	EnemyFighter
+	// EnemyBomber has a heavy hull and broad wings.
+	EnemyBomber
+	// EnemySniper is a narrow hull with a long nose barrel.
+	EnemySniper
+	// EnemyClaw has sharply forward-swept, pincer-like wings.
+	EnemyClaw
+	// EnemyArchetypeCount is the number of enemy archetypes.
+	EnemyArchetypeCount
+)
+
+// Silhouette quality constants.
+const (
+	// MinSpriteMass is the smallest fraction of a sprite a ship must cover.
+	MinSpriteMass = 0.18
+	// MaxSpriteAttempts is how many blueprints are tried before keeping the
+	// best one and pruning any detached pieces.
+	MaxSpriteAttempts = 8
+	// SpriteMargin keeps parts off the sprite edge, leaving room for the outline.
+	SpriteMargin = 1
+)
+
+// Shading constants.
+const (
+	// ShadeTopLight brightens pixels with open space above them.
+	ShadeTopLight = 0.25
+	// ShadeBottomShadow darkens pixels with open space below them.
+	ShadeBottomShadow = 0.3
+	// ShadeCurvature darkens pixels toward the sides of a part.
+	ShadeCurvature = 0.35
+	// OutlineDarkness is the brightness of the outline relative to the hull.
+	OutlineDarkness = 0.3
+)
+
+// Part materials in a ship mask.
+const (
+	partNone uint8 = iota
+	partHull
+	partWing
+	partEngine
+	partCockpit
+	partWeapon
+)
+
+// shipRule is a shape grammar production for one hull class or archetype.
+// Lengths are fractions of the sprite size; ranges are [min, max].
+type shipRule struct {
+	hullLength  [2]float64
+	hullWidth   [2]float64 // Hull half-width at its widest
+	noseTaper   float64    // Fraction of the hull spent widening from the nose
+	tailTaper   float64    // How much the hull narrows toward the tail, 0-1
+	wingSpan    [2]float64 // Half-span from the centerline; 0 for no wings
+	wingChord   [2]float64
+	wingSweep   [2]float64 // Tip offset toward the tail; negative sweeps forward
+	wingRoot    float64    // Wing root along the hull, 0 at the nose, 1 at the tail
+	engines     []float64  // Engine offsets from the centerline
+	engineWidth float64
+	cockpit     float64 // Cockpit radius; 0 for none
+	noseGun     bool
+	wingGuns    bool
+	barrel      [2]float64
+}
+
+// hullRules are the part rules for each hull class.
+var hullRules = [HullClassCount]shipRule{
+	HullScout: {
+		hullLength: [2]float64{0.7, 0.85}, hullWidth: [2]float64{0.1, 0.14}, noseTaper: 0.45, tailTaper: 0.3,
+		wingSpan: [2]float64{0.26, 0.34}, wingChord: [2]float64{0.12, 0.2}, wingSweep: [2]float64{0.1, 0.2}, wingRoot: 0.55,
+		engines: []float64{0}, engineWidth: 0.12, cockpit: 0.08, noseGun: true, barrel: [2]float64{0.08, 0.12},
+	},
+	HullInterceptor: {
+		hullLength: [2]float64{0.65, 0.8}, hullWidth: [2]float64{0.12, 0.16}, noseTaper: 0.4, tailTaper: 0.2,
+		wingSpan: [2]float64{0.36, 0.44}, wingChord: [2]float64{0.15, 0.25}, wingSweep: [2]float64{0.15, 0.3}, wingRoot: 0.45,
+		engines: []float64{0.1}, engineWidth: 0.1, cockpit: 0.1, wingGuns: true, barrel: [2]float64{0.1, 0.18},
+	},
+	HullGunship: {
+		hullLength: [2]float64{0.7, 0.8}, hullWidth: [2]float64{0.2, 0.26}, noseTaper: 0.25, tailTaper: 0.1,
+		wingSpan: [2]float64{0.38, 0.44}, wingChord: [2]float64{0.2, 0.3}, wingSweep: [2]float64{0, 0.1}, wingRoot: 0.4,
+		engines: []float64{0.14}, engineWidth: 0.12, cockpit: 0.1, noseGun: true, wingGuns: true, barrel: [2]float64{0.12, 0.2},
+	},
+	HullCarrier: {
+		hullLength: [2]float64{0.8, 0.9}, hullWidth: [2]float64{0.3, 0.38}, noseTaper: 0.2, tailTaper: 0,
+		wingSpan: [2]float64{0.42, 0.46}, wingChord: [2]float64{0.1, 0.16}, wingSweep: [2]float64{0, 0.05}, wingRoot: 0.6,
+		engines: []float64{0, 0.24}, engineWidth: 0.12, cockpit: 0.12, wingGuns: true, barrel: [2]float64{0.06, 0.1},
+	},
+}
+
+// enemyRules are the part rules for each enemy archetype.
+var enemyRules = [EnemyArchetypeCount]shipRule{
+	EnemyDrone: {
+		hullLength: [2]float64{0.55, 0.7}, hullWidth: [2]float64{0.26, 0.32}, noseTaper: 0.5, tailTaper: 0.9,
+		engines: []float64{0}, engineWidth: 0.14, cockpit: 0.12,
+	},
+	EnemyFighter: {
+		hullLength: [2]float64{0.6, 0.75}, hullWidth: [2]float64{0.12, 0.16}, noseTaper: 0.35, tailTaper: 0.2,
+		wingSpan: [2]float64{0.38, 0.46}, wingChord: [2]float64{0.15, 0.22}, wingSweep: [2]float64{-0.25, -0.1}, wingRoot: 0.6,
+		engines: []float64{0}, engineWidth: 0.14, cockpit: 0.09, wingGuns: true, barrel: [2]float64{0.08, 0.14},
+	},
+	EnemyBomber: {
+		hullLength: [2]float64{0.6, 0.7}, hullWidth: [2]float64{0.22, 0.28}, noseTaper: 0.3, tailTaper: 0.1,
+		wingSpan: [2]float64{0.42, 0.46}, wingChord: [2]float64{0.25, 0.35}, wingSweep: [2]float64{0.05, 0.15}, wingRoot: 0.35,
+		engines: []float64{0.16}, engineWidth: 0.12, cockpit: 0.1,
+	},
+	EnemySniper: {
+		hullLength: [2]float64{0.6, 0.7}, hullWidth: [2]float64{0.1, 0.13}, noseTaper: 0.3, tailTaper: 0.2,
+		wingSpan: [2]float64{0.22, 0.3}, wingChord: [2]float64{0.15, 0.2}, wingSweep: [2]float64{0.1, 0.2}, wingRoot: 0.65,
+		engines: []float64{0}, engineWidth: 0.14, cockpit: 0.08, noseGun: true, barrel: [2]float64{0.22, 0.3},
+	},
+	EnemyClaw: {
+		hullLength: [2]float64{0.5, 0.6}, hullWidth: [2]float64{0.16, 0.2}, noseTaper: 0.3, tailTaper: 0.4,
+		wingSpan: [2]float64{0.36, 0.44}, wingChord: [2]float64{0.12, 0.18}, wingSweep: [2]float64{-0.4, -0.3}, wingRoot: 0.55,
+		engines: []float64{0.1}, engineWidth: 0.1, cockpit: 0.1,
+	},
+}
+
+// partColors maps part materials to sprite colors.
+type partColors [partWeapon + 1]color.RGBA
+
+// GenerateHullSprite creates a ship sprite for a hull class by assembling a
+// hull, cockpit, wings, engines and weapon hardpoints, then shading and
+// outlining the result.
+func GenerateHullSprite(rng *rand.Rand, genreID string, hull HullClass, size int) *image.RGBA {
+	palette := getPalette(genreID, []color.RGBA{{R: 200, G: 200, B: 200, A: 255}})
+	rule := hullRules[int(hull)%int(HullClassCount)]
+	colors := shipColors(palette, palette[0], false)
+	return generatePartSprite(rng, rule, colors, size)
+}
+
+// GenerateEnemyArchetypeSprite creates an enemy sprite for an archetype. Enemy
+// cockpits glow with a hostile accent.
+func GenerateEnemyArchetypeSprite(rng *rand.Rand, genreID string, archetype EnemyArchetype, size int) *image.RGBA {
+	palette := getPalette(genreID, []color.RGBA{{R: 255, G: 100, B: 100, A: 255}})
+	rule := enemyRules[int(archetype)%int(EnemyArchetypeCount)]
+	colors := shipColors(palette, palette[rng.Intn(len(palette))], true)
+	return generatePartSprite(rng, rule, colors, size)
+}
+
+// shipColors assigns palette colors to part materials.
+func shipColors(palette []color.RGBA, hull color.RGBA, hostile bool) partColors {
+	pick := func(i int) color.RGBA { return palette[i%len(palette)] }
+	colors := partColors{
+		partHull:    hull,
+		partWing:    blendColor(pick(3), hull, 0.5),
+		partEngine:  darkenColor(pick(3), 0.6),
+		partCockpit: blendColor(pick(1), color.RGBA{R: 255, G: 255, B: 255, A: 255}, 0.4),
+		partWeapon:  blendColor(pick(2), color.RGBA{R: 128, G: 128, B: 128, A: 255}, 0.5),
+	}
+	if hostile {
+		colors[partCockpit] = blendColor(pick(4), color.RGBA{R: 255, G: 40, B: 40, A: 255}, 0.6)
+	}
+	return colors
+}
+
+// generatePartSprite builds masks from the rule until one passes the
+// silhouette checks, then colors it.
+func generatePartSprite(rng *rand.Rand, rule shipRule, colors partColors, size int) *image.RGBA {
+	if size < 8 {
+		size = 8
+	}
+
+	var best []uint8
+	bestMass := -1.0
+	for attempt := 0; attempt < MaxSpriteAttempts; attempt++ {
+		mask := buildShipMask(rng, rule, size)
+		mass, connected := maskQuality(mask, size)
+		if connected && mass >= MinSpriteMass {
+			best = mask
+			break
+		}
+		if mass > bestMass {
+			best, bestMass = mask, mass
+		}
+	}
+	pruneIslands(best, size)
+	return paintShip(best, colors, size)
+}
+
+// buildShipMask applies a rule's productions to a size x size material mask.
+func buildShipMask(rng *rand.Rand, rule shipRule, size int) []uint8 {
+	mask := make([]uint8, size*size)
+	s := float64(size)
+	span := func(r [2]float64) float64 { return (r[0] + rng.Float64()*(r[1]-r[0])) * s }
+	maxHalf := s/2 - SpriteMargin - 0.5
+
+	length := math.Min(span(rule.hullLength), s-2*SpriteMargin)
+	width := math.Min(span(rule.hullWidth), maxHalf)
+	top := math.Round((s - length) / 2)
+	bottom := top + length
+
+	// Barrels first so the hull and wings draw over their roots
+	barrel := span(rule.barrel)
+	if rule.noseGun {
+		fillBand(mask, size, 0, 0.5, math.Max(SpriteMargin, top-barrel), top+length/3, partWeapon)
+	}
+
+	// Hull: widens from the nose, holds, then narrows toward the tail
+	for y := int(top); y < int(bottom); y++ {
+		t := (float64(y) + 0.5 - top) / length
+		profile := math.Min(1, t/rule.noseTaper)
+		if t > 0.6 {
+			profile *= 1 - rule.tailTaper*(t-0.6)/0.4
+		}
+		fillRow(mask, size, y, 0, math.Max(width*profile, 0.5), partHull)
+	}
+
+	// Wings: a quadrilateral from the hull side out to the tip, swept by wingSweep
+	if rule.wingSpan[1] > 0 {
+		wingSpan := math.Min(span(rule.wingSpan), maxHalf)
+		chord := span(rule.wingChord)
+		sweep := span(rule.wingSweep)
+		rootTop := top + length*rule.wingRoot - chord/2
+		tipChord := chord * 0.5
+		for x := 0; x < size; x++ {
+			dx := math.Abs(float64(x) + 0.5 - s/2)
+			if dx > wingSpan {
+				continue
+			}
+			u := dx / wingSpan
+			y0 := rootTop + sweep*u
+			y1 := y0 + chord + (tipChord-chord)*u
+			for y := int(math.Max(y0, SpriteMargin)); float64(y) < y1 && y < size-SpriteMargin; y++ {
+				setPart(mask, size, x, y, partWing)
+			}
+		}
+		if rule.wingGuns {
+			tip := math.Max(wingSpan-1, width)
+			tipTop := rootTop + sweep
+			fillBand(mask, size, tip, 0.5, math.Max(SpriteMargin, tipTop-barrel), tipTop+tipChord, partWeapon)
+		}
+	}
+
+	// Engines sit at the tail and stick out past it
+	engineHalf := math.Max(rule.engineWidth*s/2, 0.5)
+	for _, offset := range rule.engines {
+		fillBand(mask, size, offset*s, engineHalf, bottom-length*0.2, math.Min(bottom+1, s-SpriteMargin), partEngine)
+	}
+
+	// Cockpit: an ellipse on the centerline in the front third
+	if rule.cockpit > 0 {
+		r := math.Max(rule.cockpit*s, 1)
+		cy := top + length*0.35
+		for y := int(cy - r); y <= int(cy+r); y++ {
+			ry := (float64(y) + 0.5 - cy) / r
+			half := r * 0.7 * math.Sqrt(math.Max(0, 1-ry*ry))
+			if half > 0 {
+				fillRow(mask, size, y, 0, math.Max(half, 0.5), partCockpit)
+			}
+		}
+	}
+	return mask
+}
+
+// fillRow fills row y wherever the distance from the centerline lies within
+// [inner, outer]; both halves are filled, so masks are always symmetric.
+func fillRow(mask []uint8, size, y int, inner, outer float64, part uint8) {
+	for x := 0; x < size; x++ {
+		dx := math.Abs(float64(x) + 0.5 - float64(size)/2)
+		if dx >= inner-0.5 && dx <= outer+0.01 {
+			setPart(mask, size, x, y, part)
+		}
+	}
+}
+
+// fillBand fills a vertical strip of the given half-width centered offset
+// from the centerline on both sides, from y0 to y1.
+func fillBand(mask []uint8, size int, offset, half, y0, y1 float64, part uint8) {
+	for y := int(y0); float64(y) < y1; y++ {
+		fillRow(mask, size, y, offset-half+0.5, offset+half, part)
+	}
+}
+
+// setPart marks a mask cell, ignoring cells in the outline margin.
+func setPart(mask []uint8, size, x, y int, part uint8) {
+	if x < SpriteMargin || y < SpriteMargin || x >= size-SpriteMargin || y >= size-SpriteMargin {
+		return
+	}
+	mask[y*size+x] = part
+}
+
+// maskQuality returns the fraction of the sprite a mask covers and whether
+// all of its parts are 4-connected.
+func maskQuality(mask []uint8, size int) (float64, bool) {
+	filled := 0
+	for _, m := range mask {
+		if m != partNone {
+			filled++
+		}
+	}
+	if filled == 0 {
+		return 0, false
+	}
+	largest := largestComponent(mask, size)
+	return float64(filled) / float64(size*size), len(largest) == filled
+}
+
+// CheckSilhouette returns the fraction of a sprite covered by opaque pixels
+// and whether those pixels form a single 4-connected shape.
+func CheckSilhouette(img *image.RGBA) (float64, bool) {
+	size := img.Bounds().Dx()
+	mask := make([]uint8, size*size)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if img.RGBAAt(x, y).A > 0 {
+				mask[y*size+x] = partHull
+			}
+		}
+	}
+	return maskQuality(mask, size)
+}
+
+// largestComponent returns the cell indices of the largest 4-connected group
+// of filled cells.
+func largestComponent(mask []uint8, size int) []int {
+	seen := make([]bool, len(mask))
+	var largest []int
+	for start := range mask {
+		if mask[start] == partNone || seen[start] {
+			continue
+		}
+		component := []int{start}
+		seen[start] = true
+		for i := 0; i < len(component); i++ {
+			c := component[i]
+			x, y := c%size, c/size
+			for _, n := range [4][2]int{{x - 1, y}, {x + 1, y}, {x, y - 1}, {x, y + 1}} {
+				if n[0] < 0 || n[1] < 0 || n[0] >= size || n[1] >= size {
+					continue
+				}
+				j := n[1]*size + n[0]
+				if mask[j] != partNone && !seen[j] {
+					seen[j] = true
+					component = append(component, j)
+				}
+			}
+		}
+		if len(component) > len(largest) {
+			largest = component
+		}
+	}
+	return largest
+}
+
+// pruneIslands clears every filled cell outside the largest connected group.
+// Mirrored masks keep their symmetry, since mirrored islands are the same size.
+func pruneIslands(mask []uint8, size int) {
+	keep := make([]bool, len(mask))
+	for _, i := range largestComponent(mask, size) {
+		keep[i] = true
+	}
+	for i := range mask {
+		if !keep[i] {
+			mask[i] = partNone
+		}
+	}
+}
+
+// paintShip colors a mask with cylindrical shading, top highlights, bottom
+// shadows and a dark outline around the silhouette.
+func paintShip(mask []uint8, colors partColors, size int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	filled := func(x, y int) bool {
+		return x >= 0 && y >= 0 && x < size && y < size && mask[y*size+x] != partNone
+	}
+	outline := darkenColor(colors[partHull], OutlineDarkness)
+	half := float64(size) / 2
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			part := mask[y*size+x]
+			if part == partNone {
+				if filled(x-1, y) || filled(x+1, y) || filled(x, y-1) || filled(x, y+1) {
+					img.SetRGBA(x, y, outline)
+				}
+				continue
+			}
+			shade := 1 - ShadeCurvature*math.Abs(float64(x)+0.5-half)/half
+			if !filled(x, y-1) {
+				shade += ShadeTopLight
+			}
+			if !filled(x, y+1) {
+				shade -= ShadeBottomShadow
+			}
+			img.SetRGBA(x, y, shadeColor(colors[part], shade))
+		}
+	}
+	return img
+}
+
+// shadeColor scales a color's brightness, blending toward white above 1.
+func shadeColor(c color.RGBA, shade float64) color.RGBA {
+	if shade > 1 {
+		return blendColor(c, color.RGBA{R: 255, G: 255, B: 255, A: c.A}, shade-1)
+	}
+	return darkenColor(c, math.Max(shade, 0))
+}
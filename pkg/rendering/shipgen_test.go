@@ -0,0 +1,109 @@
+package rendering
+
+import (
+	"bytes"
+	"image/color"
+	"math/rand"
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/procgen/genre"
+)
+
+func TestGenerateHullSprite_Quality(t *testing.T) {
+	for _, id := range genre.All() {
+		for hull := HullScout; hull < HullClassCount; hull++ {
+			for seed := int64(0); seed < 10; seed++ {
+				img := GenerateHullSprite(rand.New(rand.NewSource(seed)), id, hull, 16)
+				mass, connected := CheckSilhouette(img)
+				if !connected {
+					t.Errorf("%s hull %d seed %d: expected a connected silhouette", id, hull, seed)
+				}
+				if mass < MinSpriteMass {
+					t.Errorf("%s hull %d seed %d: mass %f below minimum", id, hull, seed, mass)
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateEnemyArchetypeSprite_Quality(t *testing.T) {
+	for archetype := EnemyDrone; archetype < EnemyArchetypeCount; archetype++ {
+		for _, size := range []int{8, 16, 32} {
+			img := GenerateEnemyArchetypeSprite(rand.New(rand.NewSource(3)), genre.Horror, archetype, size)
+			if img.Bounds().Dx() != size {
+				t.Fatalf("archetype %d: expected %dpx sprite, got %v", archetype, size, img.Bounds())
+			}
+			if mass, connected := CheckSilhouette(img); !connected || mass < MinSpriteMass {
+				t.Errorf("archetype %d size %d: mass %f, connected %v", archetype, size, mass, connected)
+			}
+			assertSymmetric(t, img.Pix, size)
+		}
+	}
+}
+
+func TestGenerateHullSprite_ClassesDiffer(t *testing.T) {
+	scout := GenerateHullSprite(rand.New(rand.NewSource(1)), genre.SciFi, HullScout, 32)
+	carrier := GenerateHullSprite(rand.New(rand.NewSource(1)), genre.SciFi, HullCarrier, 32)
+	scoutMass, _ := CheckSilhouette(scout)
+	carrierMass, _ := CheckSilhouette(carrier)
+	if carrierMass <= scoutMass {
+		t.Errorf("expected carrier (%f) bulkier than scout (%f)", carrierMass, scoutMass)
+	}
+}
+
+func TestGenerateHullSprite_Outline(t *testing.T) {
+	img := GenerateHullSprite(rand.New(rand.NewSource(9)), genre.SciFi, HullGunship, 16)
+
+	// The first opaque pixel down the centerline is outline, darker than the hull below it
+	for y := 0; y < 14; y++ {
+		if edge := img.RGBAAt(8, y); edge.A != 0 {
+			if inner := img.RGBAAt(8, y+2); brightness(edge) >= brightness(inner) {
+				t.Errorf("expected outline %v darker than hull %v", edge, inner)
+			}
+			return
+		}
+	}
+	t.Error("expected an opaque centerline")
+}
+
+func TestGenerateHullSprite_Deterministic(t *testing.T) {
+	a := GenerateHullSprite(rand.New(rand.NewSource(4)), genre.Cyberpunk, HullInterceptor, 16)
+	b := GenerateHullSprite(rand.New(rand.NewSource(4)), genre.Cyberpunk, HullInterceptor, 16)
+	if !bytes.Equal(a.Pix, b.Pix) {
+		t.Error("expected identical sprites for the same seed")
+	}
+}
+
+func TestPruneIslands(t *testing.T) {
+	const size = 8
+	mask := make([]uint8, size*size)
+	for x := 2; x < 6; x++ {
+		mask[3*size+x] = partHull
+	}
+	mask[0] = partWing
+	pruneIslands(mask, size)
+	if mask[0] != partNone {
+		t.Error("expected detached cell to be pruned")
+	}
+	if _, connected := maskQuality(mask, size); !connected {
+		t.Error("expected remaining mask to be connected")
+	}
+}
+
+// assertSymmetric fails if an RGBA pixel buffer is not mirror symmetric.
+func assertSymmetric(t *testing.T, pix []byte, size int) {
+	t.Helper()
+	for y := 0; y < size; y++ {
+		for x := 0; x < size/2; x++ {
+			l, r := (y*size+x)*4, (y*size+size-1-x)*4
+			if !bytes.Equal(pix[l:l+4], pix[r:r+4]) {
+				t.Fatalf("symmetry broken at (%d, %d)", x, y)
+			}
+		}
+	}
+}
+
+// brightness returns the sum of a color's channels.
+func brightness(c color.RGBA) int {
+	return int(c.R) + int(c.G) + int(c.B)
+}
@@ -0,0 +1,199 @@
+package rendering
+
+import (
+	"math"
+
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+// DefaultGridCellSize is the side of a spatial grid cell in world pixels,
+// roughly a quarter of a screen.
+const DefaultGridCellSize = 256.0
+
+// gridCell identifies a spatial grid cell by its column and row.
+type gridCell struct {
+	col, row int
+}
+
+// gridEntry is an entity filed in the grid with its cull box.
+type gridEntry struct {
+	entity              engine.Entity
+	x, y, width, height float64
+}
+
+// SpatialGrid buckets positioned entities into fixed-size cells so the
+// entities near a region can be found without scanning the whole world.
+// Each entity is filed once, under the cell holding its center; queries
+// widen their search by the largest entity extent seen to catch overlaps.
+//
+// The grid is kept current incrementally: as an engine.EntityObserver it
+// collects the entities the world reports as changed or removed, and Sync
+// refiles only those, so a frame costs time in the entities that moved
+// rather than in the size of the world.
+type SpatialGrid struct {
+	cellSize  float64
+	cells     map[gridCell][]gridEntry
+	index     map[engine.Entity]gridCell
+	dirty     map[engine.Entity]struct{}
+	maxExtent float64
+}
+
+// NewSpatialGrid creates an empty grid with the given cell size.
+func NewSpatialGrid(cellSize float64) *SpatialGrid {
+	return &SpatialGrid{
+		cellSize: cellSize,
+		cells:    make(map[gridCell][]gridEntry),
+		index:    make(map[engine.Entity]gridCell),
+		dirty:    make(map[engine.Entity]struct{}),
+	}
+}
+
+// EntityChanged marks an entity to be refiled by the next Sync.
+func (g *SpatialGrid) EntityChanged(e engine.Entity) {
+	g.dirty[e] = struct{}{}
+}
+
+// EntityRemoved drops an entity from the grid.
+func (g *SpatialGrid) EntityRemoved(e engine.Entity) {
+	delete(g.dirty, e)
+	g.Remove(e)
+}
+
+// Sync refiles the entities changed since the last Sync. Entities that lost
+// their position are dropped.
+func (g *SpatialGrid) Sync(world *engine.World) {
+	for e := range g.dirty {
+		delete(g.dirty, e)
+		posComp, ok := world.GetComponent(e, "position")
+		if !ok {
+			g.Remove(e)
+			continue
+		}
+		x, y, w, h := EntityCullBox(world, e, posComp.(*engine.Position))
+		g.Insert(e, x, y, w, h)
+	}
+}
+
+// Rebuild empties the grid and files every positioned entity in the world.
+// It is for attaching a grid to a world that already has entities; after
+// that, Sync keeps it current.
+func (g *SpatialGrid) Rebuild(world *engine.World) {
+	clear(g.cells)
+	clear(g.index)
+	clear(g.dirty)
+	g.maxExtent = 0
+
+	world.ForEachEntity(func(e engine.Entity) {
+		posComp, ok := world.GetComponent(e, "position")
+		if !ok {
+			return
+		}
+		x, y, w, h := EntityCullBox(world, e, posComp.(*engine.Position))
+		g.Insert(e, x, y, w, h)
+	})
+}
+
+// Insert files an entity whose cull box is centered on (x, y), moving it
+// if it is already filed. An entity that stays in its cell is updated in
+// place.
+func (g *SpatialGrid) Insert(e engine.Entity, x, y, width, height float64) {
+	entry := gridEntry{entity: e, x: x, y: y, width: width, height: height}
+	g.maxExtent = math.Max(g.maxExtent, math.Max(width, height)/2)
+
+	k := g.cellAt(x, y)
+	if old, ok := g.index[e]; ok {
+		if i := g.find(old, e); old == k && i >= 0 {
+			g.cells[k][i] = entry
+			return
+		}
+		g.unfile(old, e)
+	}
+	g.cells[k] = append(g.cells[k], entry)
+	g.index[e] = k
+}
+
+// Remove drops an entity from the grid. Removing an entity that is not
+// filed does nothing.
+func (g *SpatialGrid) Remove(e engine.Entity) {
+	k, ok := g.index[e]
+	if !ok {
+		return
+	}
+	g.unfile(k, e)
+	delete(g.index, e)
+	if len(g.index) == 0 {
+		g.maxExtent = 0
+	}
+}
+
+// unfile takes an entity out of a cell's bucket, deleting the cell once it
+// is empty so the map only holds occupied cells.
+func (g *SpatialGrid) unfile(k gridCell, e engine.Entity) {
+	i := g.find(k, e)
+	if i < 0 {
+		return
+	}
+	bucket := g.cells[k]
+	last := len(bucket) - 1
+	bucket[i] = bucket[last]
+	if last == 0 {
+		delete(g.cells, k)
+		return
+	}
+	g.cells[k] = bucket[:last]
+}
+
+// find returns the index of an entity in a cell's bucket, or -1.
+func (g *SpatialGrid) find(k gridCell, e engine.Entity) int {
+	for i, en := range g.cells[k] {
+		if en.entity == e {
+			return i
+		}
+	}
+	return -1
+}
+
+// Len returns the number of filed entities.
+func (g *SpatialGrid) Len() int {
+	return len(g.index)
+}
+
+// Cells returns the number of occupied cells.
+func (g *SpatialGrid) Cells() int {
+	return len(g.cells)
+}
+
+// cellAt returns the cell containing a world point.
+func (g *SpatialGrid) cellAt(x, y float64) gridCell {
+	return gridCell{col: int(math.Floor(x / g.cellSize)), row: int(math.Floor(y / g.cellSize))}
+}
+
+// Query calls fn for every entity whose cull box overlaps the rectangle.
+// Only the cells around the rectangle are visited.
+func (g *SpatialGrid) Query(minX, minY, maxX, maxY float64, fn func(e engine.Entity)) {
+	lo := g.cellAt(minX-g.maxExtent, minY-g.maxExtent)
+	hi := g.cellAt(maxX+g.maxExtent, maxY+g.maxExtent)
+	for row := lo.row; row <= hi.row; row++ {
+		for col := lo.col; col <= hi.col; col++ {
+			for _, en := range g.cells[gridCell{col: col, row: row}] {
+				if en.x+en.width/2 >= minX && en.x-en.width/2 < maxX &&
+					en.y+en.height/2 >= minY && en.y-en.height/2 < maxY {
+					fn(en.entity)
+				}
+			}
+		}
+	}
+}
+
+// Visible appends the entities inside the cull context's viewport to dst
+// and returns it, updating the context's rendered count.
+func (g *SpatialGrid) Visible(cc *CullContext, dst []engine.Entity) []engine.Entity {
+	start := len(dst)
+	minX, minY, maxX, maxY := cc.Bounds()
+	g.Query(minX, minY, maxX, maxY, func(e engine.Entity) {
+		dst = append(dst, e)
+	})
+	cc.renderedCount += len(dst) - start
+	cc.culledCount += len(g.index) - (len(dst) - start)
+	return dst
+}
@@ -0,0 +1,184 @@
+package rendering
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+func TestSpatialGrid_QueryMatchesLinearScan(t *testing.T) {
+	world := engine.NewWorld()
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		e := world.CreateEntity()
+		world.AddComponent(e, "position", &engine.Position{X: rng.Float64()*4000 - 1000, Y: rng.Float64()*4000 - 1000})
+		world.AddComponent(e, "sprite", &SpriteComponent{Size: 8 + rng.Intn(120)})
+	}
+
+	grid := NewSpatialGrid(DefaultGridCellSize)
+	grid.Rebuild(world)
+	if grid.Len() != 500 {
+		t.Fatalf("expected 500 filed entities, got %d", grid.Len())
+	}
+
+	vp := NewViewport(800, 600)
+	vp.X, vp.Y = 700, 900
+	fromGrid := grid.Visible(NewCullContext(vp, 32), nil)
+	linear := FilterVisibleEntities(world, vp, 32)
+
+	sort.Slice(fromGrid, func(i, j int) bool { return fromGrid[i] < fromGrid[j] })
+	sort.Slice(linear, func(i, j int) bool { return linear[i] < linear[j] })
+	if len(fromGrid) != len(linear) {
+		t.Fatalf("grid found %d entities, linear scan %d", len(fromGrid), len(linear))
+	}
+	for i := range linear {
+		if fromGrid[i] != linear[i] {
+			t.Fatalf("mismatch at %d: %d vs %d", i, fromGrid[i], linear[i])
+		}
+	}
+}
+
+func TestSpatialGrid_VisibleCounts(t *testing.T) {
+	world := engine.NewWorld()
+	in := world.CreateEntity()
+	world.AddComponent(in, "position", &engine.Position{X: 100, Y: 100})
+	out := world.CreateEntity()
+	world.AddComponent(out, "position", &engine.Position{X: 5000, Y: 5000})
+
+	grid := NewSpatialGrid(DefaultGridCellSize)
+	grid.Rebuild(world)
+	cc := NewCullContext(NewViewport(800, 600), 0)
+	visible := grid.Visible(cc, []engine.Entity{99})
+	if len(visible) != 2 || visible[1] != in {
+		t.Errorf("expected the in-view entity appended, got %v", visible)
+	}
+	if cc.GetRenderedCount() != 1 || cc.GetCulledCount() != 1 {
+		t.Errorf("expected 1 rendered and 1 culled, got %d and %d", cc.GetRenderedCount(), cc.GetCulledCount())
+	}
+}
+
+func TestSpatialGrid_RebuildForgetsRemoved(t *testing.T) {
+	world := engine.NewWorld()
+	e := world.CreateEntity()
+	world.AddComponent(e, "position", &engine.Position{X: 10, Y: 10})
+	grid := NewSpatialGrid(DefaultGridCellSize)
+	grid.Rebuild(world)
+	world.RemoveEntity(e)
+	grid.Rebuild(world)
+
+	found := 0
+	grid.Query(-100, -100, 100, 100, func(engine.Entity) { found++ })
+	if found != 0 || grid.Len() != 0 {
+		t.Errorf("expected removed entity to be gone, found %d", found)
+	}
+}
+
+func TestSpatialGrid_NegativeCoordinates(t *testing.T) {
+	grid := NewSpatialGrid(100)
+	grid.Insert(1, -50, -50, 10, 10)
+	found := 0
+	grid.Query(-60, -60, -40, -40, func(engine.Entity) { found++ })
+	if found != 1 {
+		t.Errorf("expected entity at negative coordinates, found %d", found)
+	}
+}
+
+func TestSpatialGrid_SyncFollowsWorld(t *testing.T) {
+	world := engine.NewWorld()
+	grid := NewSpatialGrid(100)
+	world.AddObserver(grid)
+
+	e := world.CreateEntity()
+	pos := &engine.Position{X: 10, Y: 10}
+	world.AddComponent(e, "position", pos)
+	other := world.CreateEntity()
+	world.AddComponent(other, "position", &engine.Position{X: 20, Y: 20})
+	grid.Sync(world)
+	if grid.Len() != 2 || grid.Cells() != 1 {
+		t.Fatalf("expected 2 entities in 1 cell, got %d in %d", grid.Len(), grid.Cells())
+	}
+
+	pos.X, pos.Y = 1050, 1050
+	grid.Sync(world)
+	if found := countIn(grid, 1000, 1000, 1100, 1100); found != 0 {
+		t.Error("an unmarked move should not be picked up")
+	}
+	world.MarkChanged(e)
+	grid.Sync(world)
+	if found := countIn(grid, 1000, 1000, 1100, 1100); found != 1 {
+		t.Errorf("expected the moved entity at its new position, found %d", found)
+	}
+	if found := countIn(grid, 0, 0, 50, 50); found != 1 || grid.Len() != 2 || grid.Cells() != 2 {
+		t.Errorf("expected the entity filed once, found %d in the old cell, %d filed in %d cells", found, grid.Len(), grid.Cells())
+	}
+
+	world.RemoveEntity(e)
+	world.RemoveEntity(other)
+	if grid.Len() != 0 || grid.Cells() != 0 {
+		t.Errorf("expected removed entities and their cells gone, got %d in %d cells", grid.Len(), grid.Cells())
+	}
+}
+
+func TestSpatialGrid_RemoveDropsEmptyCells(t *testing.T) {
+	grid := NewSpatialGrid(100)
+	for i := 0; i < 50; i++ {
+		grid.Insert(engine.Entity(i), float64(i)*150, 0, 10, 10)
+	}
+	for i := 0; i < 50; i++ {
+		grid.Insert(engine.Entity(i), float64(i)*150, 500, 10, 10)
+	}
+	if grid.Cells() != 50 {
+		t.Fatalf("expected 50 occupied cells after moving, got %d", grid.Cells())
+	}
+	for i := 0; i < 50; i++ {
+		grid.Remove(engine.Entity(i))
+	}
+	grid.Remove(7)
+	if grid.Len() != 0 || grid.Cells() != 0 {
+		t.Errorf("expected an empty grid, got %d entities in %d cells", grid.Len(), grid.Cells())
+	}
+}
+
+// countIn returns the number of entities the grid finds in a rectangle.
+func countIn(grid *SpatialGrid, minX, minY, maxX, maxY float64) int {
+	found := 0
+	grid.Query(minX, minY, maxX, maxY, func(engine.Entity) { found++ })
+	return found
+}
+
+// BenchmarkSpatialGrid_Visible keeps the entities around the viewport fixed
+// and grows the number elsewhere; the time per query should stay flat.
+func BenchmarkSpatialGrid_Visible(b *testing.B) {
+	for _, offscreen := range []int{1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("offscreen=%d", offscreen), func(b *testing.B) {
+			world := engine.NewWorld()
+			grid := NewSpatialGrid(DefaultGridCellSize)
+			world.AddObserver(grid)
+			rng := rand.New(rand.NewSource(1))
+			for i := 0; i < 200; i++ {
+				e := world.CreateEntity()
+				world.AddComponent(e, "position", &engine.Position{X: rng.Float64() * 1000, Y: rng.Float64() * 800})
+				world.AddComponent(e, "sprite", &SpriteComponent{Size: 16})
+			}
+			for i := 0; i < offscreen; i++ {
+				e := world.CreateEntity()
+				world.AddComponent(e, "position", &engine.Position{X: 2000 + rng.Float64()*20000, Y: 2000 + rng.Float64()*20000})
+				world.AddComponent(e, "sprite", &SpriteComponent{Size: 16})
+			}
+			grid.Sync(world)
+			vp := NewViewport(800, 600)
+			cc := NewCullContext(vp, 32)
+			var visible []engine.Entity
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				cc.SetViewport(vp)
+				grid.Sync(world)
+				visible = grid.Visible(cc, visible[:0])
+			}
+		})
+	}
+}
@@ -17,94 +17,224 @@ const (
 	SpriteTypeShip SpriteType = iota
 	SpriteTypeEnemy
 	SpriteTypeProjectile
+	SpriteTypePickup
+	SpriteTypeObstacle
 )
 
-// SpriteKey uniquely identifies a cached sprite.
+// Pickup sprite shapes, selected by variant % PickupShapeCount. Genre
+// palettes repeat colors across power-up types, so the shape is what tells
+// the pickups apart. The first shapes follow the power-up types; the rings
+// are kept for objective collectibles.
+const (
+	PickupShapeDiamond = iota
+	PickupShapeCircle
+	PickupShapeSquare
+	PickupShapeTriangle
+	PickupShapeOctagon
+	PickupShapeCross
+	PickupShapeRings
+	PickupShapeCount
+)
+
+// Obstacle sprite shapes, selected by variant % ObstacleShapeCount.
+const (
+	ObstacleShapeAsteroid = iota
+	ObstacleShapeCover
+	ObstacleShapeWall
+	ObstacleShapeCount
+)
+
+// SpriteKey uniquely identifies a cached sprite. Frame 0 is the static
+// sprite; animation frames use AnimFrameIndex.
 type SpriteKey struct {
 	GenreID string
 	Type    SpriteType
 	Variant int
+	Frame   int
 }
 
-// GenerateShipSprite creates a procedurally generated ship sprite.
+// GenerateShipSprite creates a procedurally generated interceptor ship sprite.
 func GenerateShipSprite(rng *rand.Rand, genreID string, size int) *image.RGBA {
-	const defaultFillChance = 0.45
-	defaultPalette := []color.RGBA{{R: 200, G: 200, B: 200, A: 255}}
+	return GenerateHullSprite(rng, genreID, HullInterceptor, size)
+}
+
+// GenerateEnemySprite creates a procedurally generated enemy sprite of a
+// random archetype.
+func GenerateEnemySprite(rng *rand.Rand, genreID string, size int) *image.RGBA {
+	archetype := EnemyArchetype(rng.Intn(int(EnemyArchetypeCount)))
+	return GenerateEnemyArchetypeSprite(rng, genreID, archetype, size)
+}
 
-	img := generateSymmetricBody(rng, genreID, size, defaultFillChance, defaultPalette)
-	addCenterDetail(img, rng, size, getPalette(genreID, defaultPalette))
+// getPalette returns the genre palette or the provided default.
+func getPalette(genreID string, defaultPalette []color.RGBA) []color.RGBA {
+	preset := genre.GetPreset(genreID)
+	if len(preset.Colors) > 0 {
+		return preset.Colors
+	}
+	return defaultPalette
+}
+
+// GenerateProjectileSprite creates a procedurally generated projectile sprite.
+func GenerateProjectileSprite(rng *rand.Rand, genreID string, size int) *image.RGBA {
+	size = clampProjectileSize(size)
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	c := selectProjectileColor(rng, genreID)
+	drawDiamondPattern(img, size, c)
 	return img
 }
 
-// GenerateEnemySprite creates a procedurally generated enemy sprite.
-func GenerateEnemySprite(rng *rand.Rand, genreID string, size int) *image.RGBA {
-	const enemyFillChance = 0.55
-	defaultPalette := []color.RGBA{{R: 255, G: 100, B: 100, A: 255}}
+// GeneratePickupSprite creates a power-up pickup sprite: a hollow outline
+// around a bright core. The outline's shape is chosen by variant %
+// PickupShapeCount and its color by variant, so each power-up type is
+// recognizable.
+func GeneratePickupSprite(genreID string, variant, size int) *image.RGBA {
+	size = clampProjectileSize(size)
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	palette := getPalette(genreID, []color.RGBA{{R: 255, G: 255, B: 100, A: 255}})
+	ring := palette[abs(variant)%len(palette)]
+	core := brightenColor(ring, 80)
+	shape := abs(variant) % PickupShapeCount
 
-	img := generateSymmetricBody(rng, genreID, size, enemyFillChance, defaultPalette)
-	addHostileAccent(img, rng, size, getPalette(genreID, defaultPalette))
+	center := size / 2
+	radius := size/2 - 1
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dx, dy := x-center, y-center
+			switch {
+			case insidePickupShape(shape, dx, dy, radius) && !insidePickupShape(shape, dx, dy, radius-2):
+				setPixel(img, x, y, ring)
+			case shape == PickupShapeRings:
+				// A second, inner ring in place of the core
+				if insidePickupShape(shape, dx, dy, radius-3) && !insidePickupShape(shape, dx, dy, radius-4) {
+					setPixel(img, x, y, core)
+				}
+			case insidePickupShape(PickupShapeDiamond, dx, dy, radius/3):
+				setPixel(img, x, y, core)
+			}
+		}
+	}
 	return img
 }
 
-// generateSymmetricBody creates the common symmetric pixel pattern for sprites.
-func generateSymmetricBody(rng *rand.Rand, genreID string, size int, fillChance float64, defaultPalette []color.RGBA) *image.RGBA {
+// insidePickupShape reports whether the offset (dx, dy) from a pickup's
+// center lies inside a shape of the given radius.
+func insidePickupShape(shape, dx, dy, radius int) bool {
+	ax, ay := abs(dx), abs(dy)
+	switch shape {
+	case PickupShapeCircle, PickupShapeRings:
+		return ax*ax+ay*ay <= radius*radius
+	case PickupShapeSquare:
+		return ax <= radius && ay <= radius
+	case PickupShapeTriangle:
+		// Point up, base along the bottom
+		return dy >= -radius && dy <= radius && 2*ax <= dy+radius
+	case PickupShapeOctagon:
+		return ax <= radius && ay <= radius && 2*(ax+ay) <= 3*radius
+	case PickupShapeCross:
+		arm := radius / 2
+		return (ax <= arm && ay <= radius) || (ay <= arm && ax <= radius)
+	default:
+		return ax+ay <= radius
+	}
+}
+
+// GenerateObstacleSprite creates an arena obstacle sprite. The shape is chosen by
+// variant % ObstacleShapeCount (asteroid, cover or wall) and the whole variant
+// seeds the surface detail, so callers can encode size into the variant.
+func GenerateObstacleSprite(genreID string, variant, size int) *image.RGBA {
 	if size < 8 {
 		size = 8
 	}
-
 	img := image.NewRGBA(image.Rect(0, 0, size, size))
-	palette := getPalette(genreID, defaultPalette)
-
-	fillSymmetricHalf(img, rng, size, fillChance, palette)
-	fillCenterColumn(img, rng, size, fillChance, palette)
-
+	rng := rand.New(rand.NewSource(int64(variant)))
+	palette := getPalette(genreID, []color.RGBA{{R: 140, G: 130, B: 120, A: 255}})
+	base := darkenColor(palette[abs(variant)%len(palette)], 0.45)
+	edge := darkenColor(base, 0.6)
+
+	switch abs(variant) % ObstacleShapeCount {
+	case ObstacleShapeAsteroid:
+		drawAsteroid(img, rng, size, base, edge)
+	case ObstacleShapeCover:
+		drawCover(img, rng, size, base, edge)
+	default:
+		drawWall(img, size, base, edge)
+	}
 	return img
 }
 
-// getPalette returns the genre palette or the provided default.
-func getPalette(genreID string, defaultPalette []color.RGBA) []color.RGBA {
-	preset := genre.GetPreset(genreID)
-	if len(preset.Colors) > 0 {
-		return preset.Colors
+// drawAsteroid draws a lumpy disc with a darker rim and scattered craters.
+func drawAsteroid(img *image.RGBA, rng *rand.Rand, size int, base, edge color.RGBA) {
+	center := float64(size-1) / 2
+	radius := float64(size) / 2
+	lumps := [4]float64{}
+	for i := range lumps {
+		lumps[i] = 0.8 + rng.Float64()*0.2
+	}
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dx := float64(x) - center
+			dy := float64(y) - center
+			quadrant := 0
+			if dx >= 0 {
+				quadrant++
+			}
+			if dy >= 0 {
+				quadrant += 2
+			}
+			r := radius * lumps[quadrant]
+			d2 := dx*dx + dy*dy
+			switch {
+			case d2 > r*r:
+				continue
+			case d2 > (r-1.5)*(r-1.5) || rng.Float64() < 0.08:
+				setPixel(img, x, y, edge)
+			default:
+				setPixel(img, x, y, base)
+			}
+		}
 	}
-	return defaultPalette
 }
 
-// fillSymmetricHalf generates the left half with symmetric mirroring.
-func fillSymmetricHalf(img *image.RGBA, rng *rand.Rand, size int, fillChance float64, palette []color.RGBA) {
-	halfWidth := size / 2
+// drawCover draws a bordered block with random cracks.
+func drawCover(img *image.RGBA, rng *rand.Rand, size int, base, edge color.RGBA) {
 	for y := 0; y < size; y++ {
-		for x := 0; x < halfWidth; x++ {
-			if shouldFillPixel(rng, x, y, size, fillChance) {
-				c := palette[rng.Intn(len(palette))]
-				setPixel(img, x, y, c)
-				setPixel(img, size-1-x, y, c)
+		for x := 0; x < size; x++ {
+			if x == 0 || y == 0 || x == size-1 || y == size-1 || rng.Float64() < 0.1 {
+				setPixel(img, x, y, edge)
+			} else {
+				setPixel(img, x, y, base)
 			}
 		}
 	}
 }
 
-// fillCenterColumn fills the center column for odd-width sprites.
-func fillCenterColumn(img *image.RGBA, rng *rand.Rand, size int, fillChance float64, palette []color.RGBA) {
-	if size%2 == 0 {
-		return
-	}
-	centerX := size / 2
+// drawWall draws a solid brick pattern.
+func drawWall(img *image.RGBA, size int, base, edge color.RGBA) {
+	const brickHeight = 4
+	const brickWidth = 8
 	for y := 0; y < size; y++ {
-		if rng.Float64() < fillChance {
-			c := palette[rng.Intn(len(palette))]
-			setPixel(img, centerX, y, c)
+		offset := 0
+		if (y/brickHeight)%2 == 1 {
+			offset = brickWidth / 2
+		}
+		for x := 0; x < size; x++ {
+			if y%brickHeight == 0 || (x+offset)%brickWidth == 0 {
+				setPixel(img, x, y, edge)
+			} else {
+				setPixel(img, x, y, base)
+			}
 		}
 	}
 }
 
-// GenerateProjectileSprite creates a procedurally generated projectile sprite.
-func GenerateProjectileSprite(rng *rand.Rand, genreID string, size int) *image.RGBA {
-	size = clampProjectileSize(size)
-	img := image.NewRGBA(image.Rect(0, 0, size, size))
-	c := selectProjectileColor(rng, genreID)
-	drawDiamondPattern(img, size, c)
-	return img
+// darkenColor scales RGB values by factor, keeping alpha.
+func darkenColor(c color.RGBA, factor float64) color.RGBA {
+	return color.RGBA{
+		R: uint8(float64(c.R) * factor),
+		G: uint8(float64(c.G) * factor),
+		B: uint8(float64(c.B) * factor),
+		A: c.A,
+	}
 }
 
 // clampProjectileSize ensures minimum projectile size of 4 pixels.
@@ -146,31 +276,6 @@ func isDiamondPixel(x, y, centerX, centerY, halfSize int) bool {
 	return dx+dy <= halfSize
 }
 
-// shouldFillPixel determines if a pixel should be filled based on position.
-func shouldFillPixel(rng *rand.Rand, x, y, size int, baseChance float64) bool {
-	// Higher fill probability toward center
-	centerDist := float64(abs(x-size/4)+abs(y-size/2)) / float64(size)
-	adjustedChance := baseChance * (1.2 - centerDist*0.4)
-
-	return rng.Float64() < adjustedChance
-}
-
-// addCenterDetail adds a cockpit or engine detail to the sprite center.
-func addCenterDetail(img *image.RGBA, rng *rand.Rand, size int, palette []color.RGBA) {
-	centerY := size / 3
-	halfWidth := size / 2
-	c := brightenColor(palette[0], 50)
-
-	// Draw a 3x3 symmetric cockpit area centered at (halfWidth, centerY)
-	for dy := -1; dy <= 1; dy++ {
-		y := centerY + dy
-		if y < 0 || y >= size {
-			continue
-		}
-		drawSymmetricRow(img, y, halfWidth, size, c)
-	}
-}
-
 // brightenColor increases RGB values by the given amount, clamping at 255.
 func brightenColor(c color.RGBA, amount uint8) color.RGBA {
 	return color.RGBA{
@@ -181,45 +286,6 @@ func brightenColor(c color.RGBA, amount uint8) color.RGBA {
 	}
 }
 
-// drawSymmetricRow draws a symmetric row of pixels centered at halfWidth.
-func drawSymmetricRow(img *image.RGBA, y, halfWidth, size int, c color.RGBA) {
-	// Draw center pixel for odd-sized sprites
-	if size%2 == 1 {
-		setPixel(img, halfWidth, y, c)
-	}
-
-	// Draw symmetric pairs at offsets ±1
-	for offset := 1; offset <= 1; offset++ {
-		leftX := halfWidth - offset
-		rightX := halfWidth - 1 + offset
-		if size%2 == 1 {
-			rightX = halfWidth + offset
-		}
-		if leftX >= 0 && rightX < size {
-			setPixel(img, leftX, y, c)
-			setPixel(img, rightX, y, c)
-		}
-	}
-}
-
-// addHostileAccent adds menacing details to enemy sprites.
-func addHostileAccent(img *image.RGBA, rng *rand.Rand, size int, palette []color.RGBA) {
-	// Red accent for enemies
-	accent := color.RGBA{R: 255, G: 50, B: 50, A: 255}
-	if len(palette) > 0 {
-		accent = palette[0]
-		accent.R = min(accent.R+100, 255)
-	}
-
-	// Add eye-like details
-	eyeY := size / 3
-	eyeX1 := size / 4
-	eyeX2 := size - 1 - size/4
-
-	setPixel(img, eyeX1, eyeY, accent)
-	setPixel(img, eyeX2, eyeY, accent)
-}
-
 // setPixel safely sets a pixel in the image.
 func setPixel(img *image.RGBA, x, y int, c color.RGBA) {
 	bounds := img.Bounds()
@@ -1,6 +1,7 @@
 package rendering
 
 import (
+	"bytes"
 	"math/rand"
 	"testing"
 
@@ -182,3 +183,64 @@ func BenchmarkGenerateEnemySprite(b *testing.B) {
 		GenerateEnemySprite(rng, genre.SciFi, 16)
 	}
 }
+
+func TestGeneratePickupSprite(t *testing.T) {
+	img := GeneratePickupSprite(genre.SciFi, 2, 12)
+	if img.Bounds().Dx() != 12 || img.Bounds().Dy() != 12 {
+		t.Fatalf("expected 12x12 pickup, got %v", img.Bounds())
+	}
+	if img.RGBAAt(6, 6).A == 0 {
+		t.Error("expected pickup core to be filled")
+	}
+	if img.RGBAAt(0, 0).A != 0 {
+		t.Error("expected pickup corner to be transparent")
+	}
+}
+
+func TestGeneratePickupSprite_ShapesDiffer(t *testing.T) {
+	// Variants sharing a palette color must still differ in shape
+	seen := make(map[string]int)
+	for variant := 0; variant < PickupShapeCount; variant++ {
+		img := GeneratePickupSprite(genre.SciFi, variant, 16)
+		mask := make([]byte, 0, 16*16)
+		for y := 0; y < 16; y++ {
+			for x := 0; x < 16; x++ {
+				if img.RGBAAt(x, y).A != 0 {
+					mask = append(mask, '#')
+				} else {
+					mask = append(mask, '.')
+				}
+			}
+		}
+		if other, ok := seen[string(mask)]; ok {
+			t.Errorf("variants %d and %d have the same shape", other, variant)
+		}
+		seen[string(mask)] = variant
+	}
+}
+
+func TestGenerateObstacleSprite(t *testing.T) {
+	asteroid := GenerateObstacleSprite(genre.SciFi, ObstacleShapeAsteroid, 24)
+	if asteroid.Bounds().Dx() != 24 {
+		t.Fatalf("expected 24px asteroid, got %v", asteroid.Bounds())
+	}
+	if asteroid.RGBAAt(12, 12).A == 0 {
+		t.Error("expected asteroid center to be filled")
+	}
+	if asteroid.RGBAAt(0, 0).A != 0 {
+		t.Error("expected asteroid corner to be transparent")
+	}
+
+	wall := GenerateObstacleSprite(genre.Horror, ObstacleShapeWall, 16)
+	for _, p := range [][2]int{{0, 0}, {15, 15}, {8, 8}} {
+		if wall.RGBAAt(p[0], p[1]).A == 0 {
+			t.Errorf("expected solid wall pixel at %v", p)
+		}
+	}
+
+	a := GenerateObstacleSprite(genre.Fantasy, ObstacleShapeCover+ObstacleShapeCount, 20)
+	b := GenerateObstacleSprite(genre.Fantasy, ObstacleShapeCover+ObstacleShapeCount, 20)
+	if !bytes.Equal(a.Pix, b.Pix) {
+		t.Error("expected obstacle sprites to be deterministic per variant")
+	}
+}
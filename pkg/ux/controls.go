@@ -0,0 +1,149 @@
+package ux
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opd-ai/velocity/pkg/config"
+	"github.com/opd-ai/velocity/pkg/engine"
+	"github.com/opd-ai/velocity/pkg/validation"
+)
+
+// Controls screen text.
+const (
+	// CaptureHint is shown in place of the bindings of the action waiting
+	// for a key.
+	CaptureHint = "press a key or button..."
+	// ControlsHint explains the controls screen while nothing else is shown.
+	ControlsHint = "Enter adds a binding, Left removes the last"
+	// CaptureNote is shown while waiting for a key; Escape stays free to
+	// cancel, so it can only come back through a reset.
+	CaptureNote = "Escape cancels"
+)
+
+// actionLabels names each bindable action on the controls screen.
+var actionLabels = map[string]string{
+	engine.ActionThrust:      "Thrust",
+	engine.ActionRotateLeft:  "Rotate Left",
+	engine.ActionRotateRight: "Rotate Right",
+	engine.ActionFire:        "Fire",
+	engine.ActionSecondary:   "Secondary",
+	engine.ActionPause:       "Pause",
+}
+
+// ActionLabel returns the display name of an action.
+func ActionLabel(action string) string {
+	if label, ok := actionLabels[action]; ok {
+		return label
+	}
+	return action
+}
+
+// Controls is the rebinding screen's state: the bindings being edited, the
+// action waiting for a key, and the last conflict or error to show.
+type Controls struct {
+	bindings  engine.KeyBindings
+	capturing string
+	message   string
+	dirty     bool
+}
+
+// NewControls creates a rebinding screen editing a copy of bindings.
+func NewControls(bindings engine.KeyBindings) *Controls {
+	return &Controls{bindings: bindings.Clone()}
+}
+
+// Bindings returns a copy of the edited bindings.
+func (c *Controls) Bindings() engine.KeyBindings {
+	return c.bindings.Clone()
+}
+
+// Line renders an action and its bindings as a menu line.
+func (c *Controls) Line(action string) string {
+	keys := strings.Join(c.bindings.Get(action), ", ")
+	if c.capturing == action {
+		keys = CaptureHint
+	}
+	return fmt.Sprintf("%-*s %s", SettingsLabelWidth, ActionLabel(action), keys)
+}
+
+// BeginCapture makes action wait for the next key or button.
+func (c *Controls) BeginCapture(action string) {
+	c.capturing = action
+	c.message = ""
+}
+
+// Capturing returns the action waiting for a key, or "".
+func (c *Controls) Capturing() string {
+	return c.capturing
+}
+
+// CancelCapture stops waiting for a key.
+func (c *Controls) CancelCapture() {
+	c.capturing = ""
+	c.message = ""
+}
+
+// Capture binds key to the action waiting for one and reports whether it
+// was bound. Keys already bound elsewhere, unknown keys and actions at
+// their binding limit are refused with a message, and the action keeps
+// waiting so another key can be tried.
+func (c *Controls) Capture(key string) bool {
+	action := c.capturing
+	if action == "" {
+		return false
+	}
+	keys := c.bindings.Get(action)
+
+	switch other := c.bindings.Action(key); {
+	case validation.ValidateKey(key) != nil:
+		c.message = fmt.Sprintf("%s cannot be bound", key)
+	case other != "":
+		c.message = fmt.Sprintf("%s is already bound to %s", key, ActionLabel(other))
+	case len(keys) >= validation.MaxBindingsPerAction:
+		c.message = fmt.Sprintf("%s already has %d bindings", ActionLabel(action), validation.MaxBindingsPerAction)
+	default:
+		c.bindings.Set(action, append(keys, key))
+		c.capturing, c.message, c.dirty = "", "", true
+		return true
+	}
+	return false
+}
+
+// RemoveLast unbinds the last key of action and reports whether it did. An
+// action's only binding is kept.
+func (c *Controls) RemoveLast(action string) bool {
+	keys := c.bindings.Get(action)
+	if len(keys) <= 1 {
+		c.message = fmt.Sprintf("%s needs at least one binding", ActionLabel(action))
+		return false
+	}
+	c.bindings.Set(action, keys[:len(keys)-1])
+	c.message, c.dirty = "", true
+	return true
+}
+
+// Reset restores the default bindings.
+func (c *Controls) Reset() {
+	c.bindings = engine.DefaultKeyBindings()
+	c.capturing, c.dirty = "", true
+	c.message = "Controls reset to defaults"
+}
+
+// Message returns the last conflict or error, or "".
+func (c *Controls) Message() string {
+	return c.message
+}
+
+// Apply writes the bindings into cfg.
+func (c *Controls) Apply(cfg *config.Config) {
+	b := c.Bindings()
+	cfg.Controls = config.ControlsConfig{
+		Thrust:      b.Thrust,
+		RotateLeft:  b.RotateLeft,
+		RotateRight: b.RotateRight,
+		Fire:        b.Fire,
+		Secondary:   b.Secondary,
+		Pause:       b.Pause,
+	}
+}
@@ -0,0 +1,195 @@
+package ux
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/engine"
+	"github.com/opd-ai/velocity/pkg/validation"
+)
+
+func TestControls_CaptureAddsBinding(t *testing.T) {
+	c := NewControls(engine.DefaultKeyBindings())
+
+	if c.Capture("Up") {
+		t.Fatal("Capture without a waiting action should do nothing")
+	}
+	c.BeginCapture(engine.ActionThrust)
+	if !strings.Contains(c.Line(engine.ActionThrust), CaptureHint) {
+		t.Errorf("waiting line = %q, want the capture hint", c.Line(engine.ActionThrust))
+	}
+	if !c.Capture("Up") {
+		t.Fatalf("Capture(Up) refused: %s", c.Message())
+	}
+	if c.Capturing() != "" {
+		t.Error("a successful capture should stop waiting")
+	}
+	if got := c.Bindings().Thrust; len(got) != 3 || got[2] != "Up" {
+		t.Errorf("thrust = %v, want Up appended", got)
+	}
+	if line := c.Line(engine.ActionThrust); !strings.HasPrefix(line, "Thrust ") || !strings.HasSuffix(line, "W, PadUp, Up") {
+		t.Errorf("Line() = %q", line)
+	}
+}
+
+func TestControls_CaptureConflict(t *testing.T) {
+	c := NewControls(engine.DefaultKeyBindings())
+	c.BeginCapture(engine.ActionThrust)
+
+	if c.Capture("Space") {
+		t.Fatal("a key bound to another action should be refused")
+	}
+	if got := c.Message(); got != "Space is already bound to Fire" {
+		t.Errorf("Message() = %q", got)
+	}
+	if c.Capturing() != engine.ActionThrust {
+		t.Error("a refused key should leave the action waiting")
+	}
+	if c.Capture("W") {
+		t.Error("a key already bound to the action should be refused")
+	}
+	if c.Capture("F13") {
+		t.Error("an unknown key should be refused")
+	}
+
+	c.CancelCapture()
+	if c.Capturing() != "" || c.Message() != "" {
+		t.Error("CancelCapture should stop waiting and clear the message")
+	}
+}
+
+func TestControls_BindingLimit(t *testing.T) {
+	c := NewControls(engine.DefaultKeyBindings())
+	for _, key := range []string{"Up", "I", "PadRT"} {
+		c.BeginCapture(engine.ActionThrust)
+		c.Capture(key)
+	}
+	if got := len(c.Bindings().Thrust); got != validation.MaxBindingsPerAction {
+		t.Fatalf("thrust has %d bindings, want the limit %d", got, validation.MaxBindingsPerAction)
+	}
+	if !strings.Contains(c.Message(), "already has 4 bindings") {
+		t.Errorf("Message() = %q, want the limit explained", c.Message())
+	}
+}
+
+func TestControls_RemoveLastAndReset(t *testing.T) {
+	c := NewControls(engine.DefaultKeyBindings())
+
+	if !c.RemoveLast(engine.ActionFire) {
+		t.Fatal("RemoveLast should remove the gamepad binding")
+	}
+	if c.RemoveLast(engine.ActionFire) {
+		t.Error("RemoveLast should keep an action's only binding")
+	}
+	if got := c.Bindings().Fire; len(got) != 1 || got[0] != "Space" {
+		t.Errorf("fire = %v, want [Space]", got)
+	}
+
+	// PadA is free again
+	c.BeginCapture(engine.ActionSecondary)
+	if !c.Capture("PadA") {
+		t.Errorf("Capture(PadA) refused: %s", c.Message())
+	}
+
+	c.Reset()
+	if c.Bindings().Action("PadA") != engine.ActionFire {
+		t.Error("Reset should restore the default bindings")
+	}
+	if err := validation.ValidateControls(c.Bindings().Map()); err != nil {
+		t.Errorf("reset bindings should validate: %v", err)
+	}
+}
+
+func TestControls_ApplyAndDirty(t *testing.T) {
+	cfg := testConfig()
+	s := NewSettings(cfg)
+
+	s.Controls.BeginCapture(engine.ActionRotateLeft)
+	s.Controls.Capture("Left")
+	if !s.Dirty() {
+		t.Fatal("a new binding should make settings dirty")
+	}
+	s.Apply(cfg)
+	if got := cfg.Controls.RotateLeft; len(got) != 2 || got[1] != "Left" {
+		t.Errorf("rotate_left = %v, want [A Left]", got)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("applied config should validate: %v", err)
+	}
+
+	s.MarkSaved()
+	if s.Dirty() {
+		t.Error("MarkSaved should clear binding changes too")
+	}
+}
+
+func TestMenuController_Controls(t *testing.T) {
+	gsm := NewGameStateManager()
+	mc := NewMenuController(gsm)
+	mc.SetSettings(NewSettings(testConfig()))
+
+	var changes int
+	var last engine.KeyBindings
+	mc.SetControlsChangeCallback(func(b engine.KeyBindings) {
+		changes++
+		last = b
+	})
+
+	// Main menu > Settings > Controls, the item after the last setting
+	mc.MoveDown()
+	mc.Select()
+	for range mc.Settings().Items {
+		mc.MoveDown()
+	}
+	mc.Select()
+	if !mc.InControls() || !mc.InSettings() {
+		t.Fatal("expected the controls screen to open")
+	}
+	items := mc.GetCurrentItems()
+	if len(items) != len(engine.Actions())+2 || items[0].Action != ActionBind {
+		t.Fatalf("expected a line per action plus Reset and Back, got %v", items)
+	}
+	if mc.SelectedSetting() != nil {
+		t.Error("no setting should be selected on the controls screen")
+	}
+
+	// Bind Up to thrust
+	mc.Select()
+	if !mc.Capturing() {
+		t.Fatal("selecting an action should wait for a key")
+	}
+	if mc.CaptureBinding("Space") {
+		t.Error("a conflicting key should be refused")
+	}
+	if !mc.CaptureBinding("Up") || changes != 1 || last.Action("Up") != engine.ActionThrust {
+		t.Errorf("expected Up bound to thrust and reported, got %d changes", changes)
+	}
+
+	// Back cancels a capture before it leaves the screen
+	mc.Select()
+	if !mc.Back() || mc.Capturing() || !mc.InControls() {
+		t.Error("Back should cancel the capture and stay on the controls screen")
+	}
+
+	// Left removes the last binding
+	mc.MoveLeft()
+	if changes != 2 || last.Action("Up") != "" {
+		t.Error("MoveLeft should remove the last binding")
+	}
+
+	// Reset to Defaults is the row after the actions
+	for range engine.Actions() {
+		mc.MoveDown()
+	}
+	mc.Select()
+	if changes != 3 || last.Action("W") != engine.ActionThrust {
+		t.Error("Reset to Defaults should restore and report the defaults")
+	}
+
+	if !mc.Back() || mc.InControls() || !mc.InSettings() {
+		t.Fatal("Back should return to the settings screen")
+	}
+	if mc.GetCurrentItems()[mc.SelectionIndex()].Action != ActionControls {
+		t.Error("the Controls item should stay selected after returning")
+	}
+}
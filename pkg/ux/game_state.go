@@ -1,6 +1,8 @@
 // Package ux provides the menu framework, HUD components, and tutorial scaffolding.
 package ux
 
+import "github.com/opd-ai/velocity/pkg/engine"
+
 // GameState represents the overall game state.
 type GameState int
 
@@ -158,12 +160,36 @@ func DefaultMenuItems() MenuItems {
 	}
 }
 
+// Menu actions on the settings and controls screens.
+const (
+	// ActionSetting adjusts the selected setting.
+	ActionSetting = "setting"
+	// ActionControls opens the controls screen from settings.
+	ActionControls = "controls"
+	// ActionBind waits for a new key for the selected action.
+	ActionBind = "bind"
+	// ActionResetControls restores the default bindings.
+	ActionResetControls = "reset_controls"
+	// ActionBack leaves the settings or controls screen.
+	ActionBack = "back"
+)
+
 // MenuController handles menu navigation and selection.
 type MenuController struct {
 	items        MenuItems
 	selectedIdx  int
 	stateManager *GameStateManager
 	onAction     func(action string)
+
+	// Settings screen, opened from the "settings" item, and the controls
+	// screen within it
+	settings         *Settings
+	inSettings       bool
+	inControls       bool
+	returnIdx        int
+	controlsIdx      int
+	onSettingChange  func(s Setting)
+	onControlsChange func(b engine.KeyBindings)
 }
 
 // NewMenuController creates a new menu controller.
@@ -180,8 +206,55 @@ func (mc *MenuController) SetActionCallback(fn func(action string)) {
 	mc.onAction = fn
 }
 
+// SetSettings attaches the settings the "settings" item opens. Without
+// settings the item does nothing.
+func (mc *MenuController) SetSettings(s *Settings) {
+	mc.settings = s
+}
+
+// Settings returns the attached settings, or nil.
+func (mc *MenuController) Settings() *Settings {
+	return mc.settings
+}
+
+// SetSettingChangeCallback sets the callback run after a setting changes.
+func (mc *MenuController) SetSettingChangeCallback(fn func(s Setting)) {
+	mc.onSettingChange = fn
+}
+
+// SetControlsChangeCallback sets the callback run after a binding changes.
+func (mc *MenuController) SetControlsChangeCallback(fn func(b engine.KeyBindings)) {
+	mc.onControlsChange = fn
+}
+
+// InSettings reports whether the settings screen, or the controls screen
+// within it, is showing.
+func (mc *MenuController) InSettings() bool {
+	return mc.inSettings
+}
+
+// InControls reports whether the controls screen is showing.
+func (mc *MenuController) InControls() bool {
+	return mc.inControls
+}
+
+// SelectedSetting returns the setting under the cursor, or nil when the
+// settings screen is closed or no setting is selected.
+func (mc *MenuController) SelectedSetting() *Setting {
+	if !mc.inSettings || mc.inControls || mc.selectedIdx >= len(mc.settings.Items) {
+		return nil
+	}
+	return &mc.settings.Items[mc.selectedIdx]
+}
+
 // GetCurrentItems returns the menu items for the current state.
 func (mc *MenuController) GetCurrentItems() []MenuItem {
+	if mc.inControls {
+		return mc.controlsItems()
+	}
+	if mc.inSettings {
+		return mc.settingsItems()
+	}
 	switch mc.stateManager.State() {
 	case StateMainMenu:
 		return mc.items.MainMenu
@@ -223,6 +296,104 @@ func (mc *MenuController) MoveDown() {
 	}
 }
 
+// MoveLeft adjusts the selected setting down, or on the controls screen
+// removes the selected action's last binding.
+func (mc *MenuController) MoveLeft() {
+	if action := mc.selectedAction(); action != "" {
+		if mc.settings.Controls.RemoveLast(action) {
+			mc.controlsChanged()
+		}
+		return
+	}
+	mc.adjustSetting(-1)
+}
+
+// MoveRight adjusts the selected setting up.
+func (mc *MenuController) MoveRight() {
+	mc.adjustSetting(1)
+}
+
+// Back cancels a pending key capture or leaves the controls or settings
+// screen, and reports whether there was anything to leave.
+func (mc *MenuController) Back() bool {
+	switch {
+	case mc.Capturing():
+		mc.settings.Controls.CancelCapture()
+	case mc.inSettings:
+		mc.handleAction(ActionBack)
+	default:
+		return false
+	}
+	return true
+}
+
+// Capturing reports whether the controls screen is waiting for a key.
+func (mc *MenuController) Capturing() bool {
+	return mc.inControls && mc.settings.Controls.Capturing() != ""
+}
+
+// CaptureBinding offers a pressed key or button to the action waiting for
+// one and reports whether it was bound.
+func (mc *MenuController) CaptureBinding(key string) bool {
+	if !mc.Capturing() || !mc.settings.Controls.Capture(key) {
+		return false
+	}
+	mc.controlsChanged()
+	return true
+}
+
+// settingsItems lists each setting with its value, then Controls and Back.
+func (mc *MenuController) settingsItems() []MenuItem {
+	items := make([]MenuItem, 0, len(mc.settings.Items)+2)
+	for _, s := range mc.settings.Items {
+		items = append(items, MenuItem{Label: s.Line(), Action: ActionSetting})
+	}
+	return append(items,
+		MenuItem{Label: "Controls", Action: ActionControls},
+		MenuItem{Label: "Back", Action: ActionBack})
+}
+
+// controlsItems lists each action with its bindings, then Reset to
+// Defaults and Back.
+func (mc *MenuController) controlsItems() []MenuItem {
+	actions := engine.Actions()
+	items := make([]MenuItem, 0, len(actions)+2)
+	for _, action := range actions {
+		items = append(items, MenuItem{Label: mc.settings.Controls.Line(action), Action: ActionBind})
+	}
+	return append(items,
+		MenuItem{Label: "Reset to Defaults", Action: ActionResetControls},
+		MenuItem{Label: "Back", Action: ActionBack})
+}
+
+// selectedAction returns the action under the cursor on the controls
+// screen, or "".
+func (mc *MenuController) selectedAction() string {
+	actions := engine.Actions()
+	if !mc.inControls || mc.selectedIdx >= len(actions) {
+		return ""
+	}
+	return actions[mc.selectedIdx]
+}
+
+// controlsChanged reports the edited bindings.
+func (mc *MenuController) controlsChanged() {
+	if mc.onControlsChange != nil {
+		mc.onControlsChange(mc.settings.Controls.Bindings())
+	}
+}
+
+// adjustSetting moves the selected setting in direction dir and reports
+// the change.
+func (mc *MenuController) adjustSetting(dir int) {
+	if !mc.inSettings || mc.inControls || !mc.settings.Adjust(mc.selectedIdx, dir) {
+		return
+	}
+	if mc.onSettingChange != nil {
+		mc.onSettingChange(mc.settings.Items[mc.selectedIdx])
+	}
+}
+
 // Select executes the currently selected menu item.
 func (mc *MenuController) Select() {
 	items := mc.GetCurrentItems()
@@ -248,6 +419,32 @@ func (mc *MenuController) handleAction(action string) {
 	case "main_menu", "quit_menu":
 		mc.stateManager.ReturnToMainMenu()
 		mc.selectedIdx = 0
+	case "settings":
+		if mc.settings != nil {
+			mc.inSettings = true
+			mc.returnIdx = mc.selectedIdx
+			mc.selectedIdx = 0
+		}
+	case ActionSetting:
+		// Toggles and choices also step forward on select
+		mc.adjustSetting(1)
+	case ActionControls:
+		mc.inControls = true
+		mc.controlsIdx = mc.selectedIdx
+		mc.selectedIdx = 0
+	case ActionBind:
+		mc.settings.Controls.BeginCapture(mc.selectedAction())
+	case ActionResetControls:
+		mc.settings.Controls.Reset()
+		mc.controlsChanged()
+	case ActionBack:
+		if mc.inControls {
+			mc.inControls = false
+			mc.selectedIdx = mc.controlsIdx
+			break
+		}
+		mc.inSettings = false
+		mc.selectedIdx = mc.returnIdx
 	}
 
 	if mc.onAction != nil {
@@ -0,0 +1,611 @@
+package ux
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/opd-ai/velocity/pkg/procgen/genre"
+)
+
+// HUD layout and animation constants.
+const (
+	// HUDBaseWidth and HUDBaseHeight are the resolution the layout is
+	// designed at; other resolutions scale from it.
+	HUDBaseWidth  = 800
+	HUDBaseHeight = 600
+	// HUDMinScale and HUDMaxScale bound the layout scale.
+	HUDMinScale = 0.75
+	HUDMaxScale = 3.0
+	// HUDCharWidth and HUDCharHeight are the debug font cell size in pixels.
+	HUDCharWidth  = 6
+	HUDCharHeight = 16
+
+	// ScoreRollRate is the fraction of the gap to the real score the
+	// displayed score closes per second.
+	ScoreRollRate = 6.0
+	// ScoreRollMinSpeed is the slowest the displayed score counts, in
+	// points per second, so small gaps still finish promptly.
+	ScoreRollMinSpeed = 40.0
+	// BarTrailDelay is how long the damage trail on a bar holds before
+	// draining, and BarTrailRate how fast it drains (fraction per second).
+	BarTrailDelay = 0.4
+	BarTrailRate  = 0.8
+	// WaveBannerDuration is how long the wave banner stays up in seconds,
+	// and WaveBannerFade how long it takes to fade in and out.
+	WaveBannerDuration = 2.5
+	WaveBannerFade     = 0.4
+	// DamageFlashDuration is how long the health bar flashes after a hit.
+	DamageFlashDuration = 0.3
+	// LowHealthFraction is the health fraction below which the bar pulses.
+	LowHealthFraction = 0.25
+	// LowHealthPulseRate is the low-health pulse frequency in Hz.
+	LowHealthPulseRate = 2.0
+
+	// MaxIndicators caps how many off-screen indicators are shown; the
+	// nearest targets win.
+	MaxIndicators = 12
+	// IndicatorFadeDistance is the distance beyond the view edge, in world
+	// pixels, at which an indicator reaches its faintest.
+	IndicatorFadeDistance = 1200.0
+)
+
+// HUD holds the state for the heads-up display.
+type HUD struct {
+	genreID   string
+	Health    float64
+	Shield    float64
+	Score     int64
+	Wave      int
+	Combo     int
+	PowerUps  []PowerUpTimer
+	Objective ObjectiveStatus
+
+	MaxHealth      float64
+	MaxShield      float64
+	ComboRemaining float64 // Seconds until the combo decays
+	ComboDuration  float64 // Full combo window in seconds
+	Weapons        []WeaponStatus
+	Boss           BossStatus
+	Indicators     []Indicator
+	Status         string // Secondary info such as weather and arena mode
+
+	theme  HUDTheme
+	layout HUDLayout
+
+	displayedScore float64
+	healthTrail    trail
+	bossTrail      trail
+	banner         string
+	bannerTime     float64
+	flash          float64
+	clock          float64
+}
+
+// trail is a bar's damage trail: it holds at the previous value for a
+// moment after a drop, then drains to the current one.
+type trail struct {
+	value float64
+	hold  float64
+}
+
+// update moves the trail toward the bar's current fraction.
+func (t *trail) update(current, dt float64) {
+	if current >= t.value {
+		t.value, t.hold = current, 0
+		return
+	}
+	if t.hold < BarTrailDelay {
+		t.hold += dt
+		return
+	}
+	t.value = math.Max(current, t.value-BarTrailRate*dt)
+}
+
+// ObjectiveStatus describes the wave objective shown on the HUD.
+type ObjectiveStatus struct {
+	Description string
+	Progress    float64 // Progress toward the goal, 0-1
+	Remaining   float64 // Seconds left on a timed objective, 0 if untimed
+	Completed   bool
+	Failed      bool
+}
+
+// Label returns the objective text with its progress or outcome.
+func (o ObjectiveStatus) Label() string {
+	switch {
+	case o.Description == "":
+		return ""
+	case o.Completed:
+		return o.Description + " - COMPLETE"
+	case o.Failed:
+		return o.Description + " - FAILED"
+	case o.Remaining > 0:
+		return fmt.Sprintf("%s (%.0fs)", o.Description, o.Remaining)
+	case o.Progress > 0:
+		return fmt.Sprintf("%s (%.0f%%)", o.Description, o.Progress*100)
+	}
+	return o.Description
+}
+
+// PowerUpTimer describes an active power-up shown on the HUD. Duration is
+// the longest the power-up can last, including extensions.
+type PowerUpTimer struct {
+	Name      string
+	Remaining float64
+	Duration  float64
+	Stacks    int
+}
+
+// Fraction returns the remaining time as a fraction of the full duration,
+// clamped to 0-1.
+func (p PowerUpTimer) Fraction() float64 {
+	if p.Duration <= 0 {
+		return 0
+	}
+	return math.Max(0, math.Min(p.Remaining/p.Duration, 1))
+}
+
+// Label returns the power-up name with its stack count and time left.
+func (p PowerUpTimer) Label() string {
+	if p.Stacks > 1 {
+		return fmt.Sprintf("%s x%d %.1fs", p.Name, p.Stacks, p.Remaining)
+	}
+	return fmt.Sprintf("%s %.1fs", p.Name, p.Remaining)
+}
+
+// WeaponStatus describes a weapon slot shown in the weapon panel.
+type WeaponStatus struct {
+	Name     string
+	Cooldown float64 // Fraction of the cooldown left, 0 when ready
+	Ammo     int     // Rounds left; negative for unlimited
+	MaxAmmo  int
+}
+
+// Ready reports whether the weapon can fire now.
+func (w WeaponStatus) Ready() bool {
+	return w.Cooldown <= 0 && w.Ammo != 0
+}
+
+// AmmoLabel returns the ammo count, or "INF" for unlimited weapons.
+func (w WeaponStatus) AmmoLabel() string {
+	switch {
+	case w.Ammo < 0:
+		return "INF"
+	case w.MaxAmmo > 0:
+		return fmt.Sprintf("%d/%d", w.Ammo, w.MaxAmmo)
+	}
+	return fmt.Sprintf("%d", w.Ammo)
+}
+
+// BossStatus describes the boss shown in the boss bar.
+type BossStatus struct {
+	Active    bool
+	Name      string
+	Health    float64
+	MaxHealth float64
+}
+
+// Fraction returns the boss's remaining health as a fraction (0-1).
+func (b BossStatus) Fraction() float64 {
+	return fraction(b.Health, b.MaxHealth)
+}
+
+// NewHUD creates a new HUD instance.
+func NewHUD() *HUD {
+	h := &HUD{}
+	h.SetGenre(genre.SciFi)
+	h.SetResolution(HUDBaseWidth, HUDBaseHeight)
+	return h
+}
+
+// SetGenre switches the HUD visual style to match the given genre.
+func (h *HUD) SetGenre(genreID string) {
+	h.genreID = genreID
+	h.theme = ThemeForGenre(genreID)
+}
+
+// Theme returns the colors the HUD is drawn with.
+func (h *HUD) Theme() HUDTheme {
+	return h.theme
+}
+
+// SetResolution lays the HUD out for a screen of the given size.
+func (h *HUD) SetResolution(width, height int) {
+	h.layout = NewHUDLayout(width, height)
+}
+
+// Layout returns where each HUD element is drawn.
+func (h *HUD) Layout() HUDLayout {
+	return h.layout
+}
+
+// Update refreshes HUD values. A new wave raises the wave banner and lost
+// health flashes the health bar.
+func (h *HUD) Update(health, shield float64, score int64, wave, combo int) {
+	if wave != h.Wave && wave > 0 {
+		h.ShowBanner(fmt.Sprintf("WAVE %d", wave))
+	}
+	if health < h.Health {
+		h.flash = DamageFlashDuration
+	}
+	h.Health = health
+	h.Shield = shield
+	h.Score = score
+	h.Wave = wave
+	h.Combo = combo
+}
+
+// SetLimits sets the full health and shield the bars are measured against.
+func (h *HUD) SetLimits(maxHealth, maxShield float64) {
+	h.MaxHealth = maxHealth
+	h.MaxShield = maxShield
+}
+
+// SetComboTimer sets the time left before the combo decays and the full
+// combo window.
+func (h *HUD) SetComboTimer(remaining, duration float64) {
+	h.ComboRemaining = remaining
+	h.ComboDuration = duration
+}
+
+// SetPowerUps replaces the list of active power-up timers.
+func (h *HUD) SetPowerUps(timers []PowerUpTimer) {
+	h.PowerUps = timers
+}
+
+// SetObjective replaces the displayed wave objective.
+func (h *HUD) SetObjective(status ObjectiveStatus) {
+	h.Objective = status
+}
+
+// SetWeapons replaces the weapon panel slots.
+func (h *HUD) SetWeapons(weapons []WeaponStatus) {
+	h.Weapons = weapons
+}
+
+// SetBoss replaces the boss bar status; an inactive boss hides the bar.
+func (h *HUD) SetBoss(boss BossStatus) {
+	if boss.Active && !h.Boss.Active {
+		h.bossTrail = trail{value: boss.Fraction()}
+	}
+	h.Boss = boss
+}
+
+// SetStatus replaces the secondary status line.
+func (h *HUD) SetStatus(status string) {
+	h.Status = status
+}
+
+// TrackTargets places edge indicators for targets outside the view. The
+// view and targets are in world coordinates.
+func (h *HUD) TrackTargets(view image.Rectangle, targets []IndicatorTarget) {
+	h.Indicators = EdgeIndicators(view, targets, float64(h.layout.IndicatorInset))
+}
+
+// ShowBanner displays text in the center of the screen for
+// WaveBannerDuration seconds.
+func (h *HUD) ShowBanner(text string) {
+	h.banner = text
+	h.bannerTime = WaveBannerDuration
+}
+
+// Banner returns the banner text and its opacity (0-1); the text is empty
+// when no banner is showing.
+func (h *HUD) Banner() (string, float64) {
+	if h.bannerTime <= 0 {
+		return "", 0
+	}
+	elapsed := WaveBannerDuration - h.bannerTime
+	alpha := math.Min(1, math.Min(elapsed, h.bannerTime)/WaveBannerFade)
+	return h.banner, alpha
+}
+
+// Tick advances HUD animations by dt seconds: the rolling score, bar damage
+// trails, the wave banner and the damage flash.
+func (h *HUD) Tick(dt float64) {
+	h.clock += dt
+
+	target := float64(h.Score)
+	gap := target - h.displayedScore
+	if gap <= 0 {
+		h.displayedScore = target
+	} else {
+		step := math.Max(gap*math.Min(1, ScoreRollRate*dt), ScoreRollMinSpeed*dt)
+		h.displayedScore = math.Min(target, h.displayedScore+step)
+	}
+
+	h.healthTrail.update(h.HealthFraction(), dt)
+	h.bossTrail.update(h.Boss.Fraction(), dt)
+	h.bannerTime = math.Max(0, h.bannerTime-dt)
+	h.flash = math.Max(0, h.flash-dt)
+}
+
+// DisplayedScore returns the score as the rolling counter currently shows it.
+func (h *HUD) DisplayedScore() int64 {
+	return int64(h.displayedScore)
+}
+
+// HealthFraction returns health as a fraction of MaxHealth (0-1).
+func (h *HUD) HealthFraction() float64 {
+	return fraction(h.Health, h.MaxHealth)
+}
+
+// ShieldFraction returns the shield as a fraction of MaxShield (0-1).
+func (h *HUD) ShieldFraction() float64 {
+	return fraction(h.Shield, h.MaxShield)
+}
+
+// HealthTrail returns the fraction the health bar's damage trail shows.
+func (h *HUD) HealthTrail() float64 {
+	return h.healthTrail.value
+}
+
+// BossTrail returns the fraction the boss bar's damage trail shows.
+func (h *HUD) BossTrail() float64 {
+	return h.bossTrail.value
+}
+
+// ComboFraction returns the time left on the combo as a fraction of the
+// combo window, or 0 when there is no combo.
+func (h *HUD) ComboFraction() float64 {
+	if h.Combo <= 0 {
+		return 0
+	}
+	return fraction(h.ComboRemaining, h.ComboDuration)
+}
+
+// Flash returns the damage flash strength (0-1).
+func (h *HUD) Flash() float64 {
+	return h.flash / DamageFlashDuration
+}
+
+// LowHealthPulse returns how strongly the health bar pulses its warning
+// color (0-1); it is 0 above LowHealthFraction.
+func (h *HUD) LowHealthPulse() float64 {
+	f := h.HealthFraction()
+	if h.MaxHealth <= 0 || f <= 0 || f >= LowHealthFraction {
+		return 0
+	}
+	return 0.5 + 0.5*math.Sin(2*math.Pi*LowHealthPulseRate*h.clock)
+}
+
+// fraction returns v/max clamped to 0-1, or 0 when max is not positive.
+func fraction(v, max float64) float64 {
+	if max <= 0 {
+		return 0
+	}
+	return math.Max(0, math.Min(1, v/max))
+}
+
+// HUDTheme holds the colors and bar style of a genre's HUD.
+type HUDTheme struct {
+	Text       color.RGBA
+	Dim        color.RGBA // Secondary text and empty bar backgrounds
+	Panel      color.RGBA // Translucent panel backgrounds
+	Frame      color.RGBA // Bar outlines
+	Health     color.RGBA
+	Shield     color.RGBA
+	Combo      color.RGBA
+	Boss       color.RGBA
+	Trail      color.RGBA // Damage trail behind draining bars
+	Warning    color.RGBA // Low health pulse and damage flash
+	Indicator  color.RGBA
+	Segments   int // Bar tick marks; 0 draws solid bars
+	FrameWidth float64
+}
+
+// hudStyles gives each genre's bar segmentation and frame weight.
+var hudStyles = map[string]struct {
+	segments   int
+	frameWidth float64
+}{
+	genre.SciFi:     {segments: 10, frameWidth: 1},
+	genre.Horror:    {segments: 0, frameWidth: 1},
+	genre.Cyberpunk: {segments: 20, frameWidth: 2},
+	genre.Fantasy:   {segments: 0, frameWidth: 2},
+	genre.PostApoc:  {segments: 8, frameWidth: 2},
+}
+
+// ThemeForGenre derives the HUD colors from a genre's palette. Text is
+// lifted toward white so it stays readable on the darker palettes.
+func ThemeForGenre(genreID string) HUDTheme {
+	p := genre.GetPreset(genreID).Colors
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	t := HUDTheme{
+		Text:       mixRGBA(p[2], white, 0.6),
+		Dim:        mixRGBA(p[3], white, 0.15),
+		Panel:      withAlpha(p[3], 150),
+		Frame:      mixRGBA(p[1], white, 0.3),
+		Health:     mixRGBA(p[0], white, 0.2),
+		Shield:     mixRGBA(p[1], white, 0.3),
+		Combo:      mixRGBA(p[2], white, 0.2),
+		Boss:       mixRGBA(p[4], white, 0.1),
+		Trail:      mixRGBA(p[4], white, 0.5),
+		Warning:    mixRGBA(p[4], color.RGBA{R: 255, A: 255}, 0.5),
+		Indicator:  mixRGBA(p[4], white, 0.3),
+		Segments:   10,
+		FrameWidth: 1,
+	}
+	if s, ok := hudStyles[genreID]; ok {
+		t.Segments, t.FrameWidth = s.segments, s.frameWidth
+	}
+	return t
+}
+
+// mixRGBA blends a toward b by t (0-1), keeping full opacity.
+func mixRGBA(a, b color.RGBA, t float64) color.RGBA {
+	mix := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + (float64(y)-float64(x))*t))
+	}
+	return color.RGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: 255}
+}
+
+// withAlpha returns c with premultiplied alpha a.
+func withAlpha(c color.RGBA, a uint8) color.RGBA {
+	scale := float64(a) / 255
+	return color.RGBA{
+		R: uint8(float64(c.R) * scale), G: uint8(float64(c.G) * scale),
+		B: uint8(float64(c.B) * scale), A: a,
+	}
+}
+
+// HUDLayout places HUD elements on a screen. Rectangles are in screen
+// pixels; text drawn in the layout is scaled by TextScale.
+type HUDLayout struct {
+	Width, Height  int
+	Scale          float64 // Size relative to the HUDBase resolution
+	TextScale      float64 // Whole-number scale for debug font text
+	Margin         int
+	Health         image.Rectangle
+	Shield         image.Rectangle
+	PowerUps       image.Point // Bottom-left corner of the power-up stack
+	Score          image.Point // Top center of the score
+	Combo          image.Rectangle
+	Boss           image.Rectangle
+	Weapons        image.Rectangle // Grows upward when there are more than two slots
+	WeaponSlot     int             // Height of one weapon slot
+	Objective      image.Point
+	Status         image.Point // Bottom center of the status line
+	Banner         image.Point // Center of the wave banner
+	BannerScale    float64
+	IndicatorInset int // Distance of edge indicators from the screen edge
+	IndicatorSize  float64
+}
+
+// NewHUDLayout lays the HUD out for a screen. Element sizes scale with the
+// smaller of the width and height ratios to the HUDBase resolution, and
+// positions anchor to the screen edges so wide screens stay usable.
+func NewHUDLayout(width, height int) HUDLayout {
+	s := math.Min(float64(width)/HUDBaseWidth, float64(height)/HUDBaseHeight)
+	s = math.Max(HUDMinScale, math.Min(HUDMaxScale, s))
+	px := func(v float64) int { return int(math.Round(v * s)) }
+
+	l := HUDLayout{
+		Width:          width,
+		Height:         height,
+		Scale:          s,
+		TextScale:      math.Max(1, math.Floor(s)),
+		Margin:         px(10),
+		BannerScale:    3 * math.Max(1, math.Floor(s)),
+		IndicatorInset: px(18),
+		IndicatorSize:  8 * s,
+	}
+	lineH := int(HUDCharHeight * l.TextScale)
+	barW, barH := px(180), px(10)
+
+	// Health and shield bars in the bottom-left corner, labels above each
+	l.Health = image.Rect(l.Margin, height-l.Margin-barH, l.Margin+barW, height-l.Margin)
+	l.Shield = l.Health.Sub(image.Pt(0, barH+lineH))
+	l.PowerUps = image.Pt(l.Margin, l.Shield.Min.Y-lineH-px(4))
+
+	// Score, combo meter and boss bar stacked at the top center; the top
+	// line is left to the FPS readout and the objective
+	l.Score = image.Pt(width/2, l.Margin)
+	comboW := px(120)
+	comboTop := l.Score.Y + lineH + px(4)
+	l.Combo = image.Rect(width/2-comboW/2, comboTop+lineH, width/2+comboW/2, comboTop+lineH+px(4))
+	bossW := width / 2
+	bossTop := l.Combo.Max.Y + px(8) + lineH
+	l.Boss = image.Rect(width/2-bossW/2, bossTop, width/2+bossW/2, bossTop+px(12))
+	l.Objective = image.Pt(l.Margin, l.Margin+HUDCharHeight)
+
+	// Weapon panel in the bottom-right corner
+	weaponW := px(150)
+	l.WeaponSlot = lineH + px(8)
+	l.Weapons = image.Rect(width-l.Margin-weaponW, height-l.Margin-2*l.WeaponSlot, width-l.Margin, height-l.Margin)
+
+	l.Status = image.Pt(width/2, height-l.Margin)
+	l.Banner = image.Pt(width/2, height/3)
+	return l
+}
+
+// TextWidth returns the width in pixels of text drawn at the given scale.
+func TextWidth(text string, scale float64) int {
+	return int(float64(len(text)*HUDCharWidth) * scale)
+}
+
+// IndicatorKind identifies what an off-screen indicator points at.
+type IndicatorKind int
+
+// Indicator kinds.
+const (
+	IndicatorEnemy IndicatorKind = iota
+	IndicatorBoss
+)
+
+// IndicatorTarget is something the HUD points at when it is off screen.
+type IndicatorTarget struct {
+	X, Y float64
+	Kind IndicatorKind
+}
+
+// Indicator is an arrow on the screen edge pointing at an off-screen target.
+type Indicator struct {
+	X, Y     float64 // Screen position of the arrow
+	Angle    float64 // Direction to the target in radians
+	Distance float64 // World distance from the view edge to the target
+	Kind     IndicatorKind
+}
+
+// Alpha returns the indicator opacity: near targets are solid and far ones
+// fade toward a third.
+func (i Indicator) Alpha() float64 {
+	return 1 - 2.0/3*math.Min(1, i.Distance/IndicatorFadeDistance)
+}
+
+// EdgeIndicators returns edge arrows for the targets outside view, inset
+// from the screen edge by inset pixels. Each arrow sits where the line from
+// the view center to its target crosses the inset border. Bosses are always
+// kept; other targets are limited to the MaxIndicators nearest.
+func EdgeIndicators(view image.Rectangle, targets []IndicatorTarget, inset float64) []Indicator {
+	if view.Empty() {
+		return nil
+	}
+	w, h := float64(view.Dx()), float64(view.Dy())
+	cx, cy := float64(view.Min.X)+w/2, float64(view.Min.Y)+h/2
+	halfW, halfH := math.Max(1, w/2-inset), math.Max(1, h/2-inset)
+
+	var out []Indicator
+	for _, t := range targets {
+		dx, dy := t.X-cx, t.Y-cy
+		if math.Abs(dx) <= w/2 && math.Abs(dy) <= h/2 {
+			continue
+		}
+		// Scale the direction so it just touches the inset border
+		k := rayToBox(dx, dy, halfW, halfH)
+		out = append(out, Indicator{
+			X:        w/2 + dx*k,
+			Y:        h/2 + dy*k,
+			Angle:    math.Atan2(dy, dx),
+			Distance: (1 - rayToBox(dx, dy, w/2, h/2)) * math.Hypot(dx, dy),
+			Kind:     t.Kind,
+		})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if (out[i].Kind == IndicatorBoss) != (out[j].Kind == IndicatorBoss) {
+			return out[i].Kind == IndicatorBoss
+		}
+		return out[i].Distance < out[j].Distance
+	})
+	if len(out) > MaxIndicators {
+		out = out[:MaxIndicators]
+	}
+	return out
+}
+
+// rayToBox returns the factor that scales (dx, dy) onto the border of a box
+// with the given half extents centered on the ray's origin.
+func rayToBox(dx, dy, halfW, halfH float64) float64 {
+	k := math.Inf(1)
+	if dx != 0 {
+		k = halfW / math.Abs(dx)
+	}
+	if dy != 0 {
+		k = math.Min(k, halfH/math.Abs(dy))
+	}
+	return k
+}
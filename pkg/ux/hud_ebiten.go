@@ -0,0 +1,250 @@
+//go:build !noebiten
+
+package ux
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// hudTextBufferWidth is the widest line of HUD text, in unscaled pixels.
+const hudTextBufferWidth = 512
+
+// HUDRenderer draws a HUD onto the screen with the HUD's genre theme and
+// layout. Text is drawn with the debug font through a scratch image so it
+// can be tinted and scaled.
+type HUDRenderer struct {
+	text  *ebiten.Image
+	arrow vector.Path
+	draws int
+}
+
+// NewHUDRenderer creates a HUD renderer.
+func NewHUDRenderer() *HUDRenderer {
+	return &HUDRenderer{text: ebiten.NewImage(hudTextBufferWidth, HUDCharHeight)}
+}
+
+// Draw renders every HUD element and returns the number of draw calls issued.
+func (r *HUDRenderer) Draw(screen *ebiten.Image, h *HUD) int {
+	r.draws = 0
+	t, l := h.theme, h.layout
+
+	r.drawIndicators(screen, h)
+	r.drawVitals(screen, h)
+	r.drawPowerUps(screen, h)
+	r.drawScore(screen, h)
+	r.drawBoss(screen, h)
+	r.drawWeapons(screen, h)
+
+	if label := h.Objective.Label(); label != "" {
+		r.print(screen, label, float64(l.Objective.X), float64(l.Objective.Y), l.TextScale, t.Text, 1)
+	}
+	if h.Status != "" {
+		w := TextWidth(h.Status, l.TextScale)
+		y := float64(l.Status.Y) - HUDCharHeight*l.TextScale
+		r.print(screen, h.Status, float64(l.Status.X-w/2), y, l.TextScale, t.Dim, 1)
+	}
+	if text, alpha := h.Banner(); text != "" {
+		w := TextWidth(text, l.BannerScale)
+		y := float64(l.Banner.Y) - HUDCharHeight*l.BannerScale/2
+		r.print(screen, text, float64(l.Banner.X-w/2), y, l.BannerScale, t.Text, alpha)
+	}
+	return r.draws
+}
+
+// drawVitals draws the health and shield bars. The health bar flashes when
+// hit and pulses its warning color when low.
+func (r *HUDRenderer) drawVitals(screen *ebiten.Image, h *HUD) {
+	t, l := h.theme, h.layout
+
+	fill := t.Health
+	if pulse := math.Max(h.Flash(), h.LowHealthPulse()); pulse > 0 {
+		fill = mixRGBA(fill, t.Warning, pulse)
+	}
+	r.label(screen, h, fmt.Sprintf("HULL %.0f", math.Max(0, h.Health)), l.Health)
+	r.bar(screen, t, l.Health, h.HealthFraction(), h.HealthTrail(), fill, true)
+
+	if h.MaxShield > 0 {
+		r.label(screen, h, fmt.Sprintf("SHIELD %.0f", h.Shield), l.Shield)
+		r.bar(screen, t, l.Shield, h.ShieldFraction(), 0, t.Shield, true)
+	}
+}
+
+// drawPowerUps stacks the active power-up timers upward above the vitals,
+// each with a thin bar showing its remaining time.
+func (r *HUDRenderer) drawPowerUps(screen *ebiten.Image, h *HUD) {
+	t, l := h.theme, h.layout
+	lineH := HUDCharHeight * l.TextScale
+	x, y := float64(l.PowerUps.X), float64(l.PowerUps.Y)
+	for _, p := range h.PowerUps {
+		y -= lineH + 3*l.Scale
+		r.print(screen, p.Label(), x, y, l.TextScale, t.Text, 1)
+		w := float32(TextWidth(p.Label(), l.TextScale)) * float32(p.Fraction())
+		r.fillRect(screen, float32(x), float32(y+lineH), w, float32(2*l.Scale), t.Combo)
+	}
+}
+
+// drawScore draws the rolling score and, during a combo, the combo meter
+// draining toward decay.
+func (r *HUDRenderer) drawScore(screen *ebiten.Image, h *HUD) {
+	t, l := h.theme, h.layout
+
+	score := fmt.Sprintf("SCORE %08d", h.DisplayedScore())
+	r.print(screen, score, float64(l.Score.X-TextWidth(score, l.TextScale)/2), float64(l.Score.Y), l.TextScale, t.Text, 1)
+
+	if f := h.ComboFraction(); f > 0 {
+		combo := fmt.Sprintf("COMBO x%d", h.Combo+1)
+		y := float64(l.Combo.Min.Y) - HUDCharHeight*l.TextScale
+		r.print(screen, combo, float64(l.Combo.Min.X+l.Combo.Dx()/2-TextWidth(combo, l.TextScale)/2), y, l.TextScale, t.Combo, 1)
+		r.bar(screen, t, l.Combo, f, 0, t.Combo, false)
+	}
+}
+
+// drawBoss draws the boss name and health bar with its damage trail.
+func (r *HUDRenderer) drawBoss(screen *ebiten.Image, h *HUD) {
+	if !h.Boss.Active {
+		return
+	}
+	t, l := h.theme, h.layout
+	name := h.Boss.Name
+	if name == "" {
+		name = "BOSS"
+	}
+	y := float64(l.Boss.Min.Y) - HUDCharHeight*l.TextScale
+	r.print(screen, name, float64(l.Boss.Min.X+l.Boss.Dx()/2-TextWidth(name, l.TextScale)/2), y, l.TextScale, t.Boss, 1)
+	r.bar(screen, t, l.Boss, h.Boss.Fraction(), h.BossTrail(), t.Boss, true)
+}
+
+// drawWeapons draws one slot per weapon in the bottom-right panel: the name
+// and ammo, and a cooldown bar that fills as the weapon recharges.
+func (r *HUDRenderer) drawWeapons(screen *ebiten.Image, h *HUD) {
+	if len(h.Weapons) == 0 {
+		return
+	}
+	t, l := h.theme, h.layout
+	panel := l.Weapons
+	panel.Min.Y = panel.Max.Y - len(h.Weapons)*l.WeaponSlot
+	pad := int(4 * l.Scale)
+	r.fillRect(screen, float32(panel.Min.X-pad), float32(panel.Min.Y-pad),
+		float32(panel.Dx()+2*pad), float32(panel.Dy()+pad), t.Panel)
+
+	barH := int(math.Max(2, 3*l.Scale))
+	for i, w := range h.Weapons {
+		y := panel.Min.Y + i*l.WeaponSlot
+		clr := t.Dim
+		if w.Ready() {
+			clr = t.Text
+		}
+		r.print(screen, w.Name, float64(panel.Min.X), float64(y), l.TextScale, clr, 1)
+		ammo := w.AmmoLabel()
+		r.print(screen, ammo, float64(panel.Max.X-TextWidth(ammo, l.TextScale)), float64(y), l.TextScale, clr, 1)
+
+		barY := y + int(HUDCharHeight*l.TextScale) + barH/2
+		charge := image.Rect(panel.Min.X, barY, panel.Max.X, barY+barH)
+		r.bar(screen, t, charge, 1-w.Cooldown, 0, t.Shield, false)
+	}
+}
+
+// drawIndicators draws an arrow at the screen edge for each off-screen
+// target, fading with distance.
+func (r *HUDRenderer) drawIndicators(screen *ebiten.Image, h *HUD) {
+	t, l := h.theme, h.layout
+	for _, ind := range h.Indicators {
+		size := l.IndicatorSize
+		clr := t.Indicator
+		if ind.Kind == IndicatorBoss {
+			size *= 1.5
+			clr = t.Boss
+		}
+		sin, cos := math.Sincos(ind.Angle)
+		tipX, tipY := ind.X+cos*size, ind.Y+sin*size
+		// Back corners sit behind the tip, spread perpendicular to it
+		bx, by := ind.X-cos*size*0.6, ind.Y-sin*size*0.6
+		px, py := -sin*size*0.7, cos*size*0.7
+
+		r.arrow.Reset()
+		r.arrow.MoveTo(float32(tipX), float32(tipY))
+		r.arrow.LineTo(float32(bx+px), float32(by+py))
+		r.arrow.LineTo(float32(bx-px), float32(by-py))
+		r.arrow.Close()
+
+		op := &vector.DrawPathOptions{AntiAlias: true}
+		op.ColorScale.ScaleWithColor(clr)
+		op.ColorScale.ScaleAlpha(float32(ind.Alpha()))
+		vector.FillPath(screen, &r.arrow, nil, op)
+		r.draws++
+	}
+}
+
+// label prints a bar's caption just above it.
+func (r *HUDRenderer) label(screen *ebiten.Image, h *HUD, text string, bar image.Rectangle) {
+	l := h.layout
+	y := float64(bar.Min.Y) - HUDCharHeight*l.TextScale
+	r.print(screen, text, float64(bar.Min.X), y, l.TextScale, h.theme.Text, 1)
+}
+
+// bar draws a meter: the empty track, a damage trail and the filled part.
+// Framed bars add the theme's segment ticks and outline; thin meters skip
+// them.
+func (r *HUDRenderer) bar(screen *ebiten.Image, t HUDTheme, rect image.Rectangle, value, trailValue float64, fill color.RGBA, framed bool) {
+	x, y := float32(rect.Min.X), float32(rect.Min.Y)
+	w, h := float32(rect.Dx()), float32(rect.Dy())
+
+	r.fillRect(screen, x, y, w, h, withAlpha(t.Dim, 200))
+	if trailValue > value {
+		r.fillRect(screen, x, y, w*float32(trailValue), h, t.Trail)
+	}
+	if value > 0 {
+		r.fillRect(screen, x, y, w*float32(math.Min(1, value)), h, fill)
+	}
+	if !framed {
+		return
+	}
+	if t.Segments > 1 {
+		for i := 1; i < t.Segments; i++ {
+			sx := x + w*float32(i)/float32(t.Segments)
+			vector.StrokeLine(screen, sx, y, sx, y+h, 1, t.Dim, false)
+			r.draws++
+		}
+	}
+	if t.FrameWidth > 0 {
+		vector.StrokeRect(screen, x, y, w, h, float32(t.FrameWidth), t.Frame, false)
+		r.draws++
+	}
+}
+
+// fillRect fills a rectangle, skipping empty ones.
+func (r *HUDRenderer) fillRect(screen *ebiten.Image, x, y, w, h float32, clr color.RGBA) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	vector.DrawFilledRect(screen, x, y, w, h, clr, false)
+	r.draws++
+}
+
+// print draws text at (x, y) scaled, tinted and faded.
+func (r *HUDRenderer) print(screen *ebiten.Image, text string, x, y, scale float64, clr color.RGBA, alpha float64) {
+	width := len(text) * HUDCharWidth
+	if width == 0 || alpha <= 0 {
+		return
+	}
+	if width > hudTextBufferWidth {
+		width = hudTextBufferWidth
+	}
+	r.text.Clear()
+	ebitenutil.DebugPrintAt(r.text, text, 0, 0)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(math.Round(x), math.Round(y))
+	op.ColorScale.ScaleWithColor(clr)
+	op.ColorScale.ScaleAlpha(float32(alpha))
+	screen.DrawImage(r.text.SubImage(image.Rect(0, 0, width, HUDCharHeight)).(*ebiten.Image), op)
+	r.draws++
+}
@@ -0,0 +1,340 @@
+package ux
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/procgen/genre"
+)
+
+func TestHUD_RollingScore(t *testing.T) {
+	hud := NewHUD()
+	hud.Update(100, 0, 1000, 1, 0)
+
+	hud.Tick(1.0 / 60)
+	first := hud.DisplayedScore()
+	if first <= 0 || first >= 1000 {
+		t.Fatalf("score should start rolling toward 1000, shows %d", first)
+	}
+
+	for i := 0; i < 120; i++ {
+		hud.Tick(1.0 / 60)
+	}
+	if got := hud.DisplayedScore(); got != 1000 {
+		t.Errorf("score should settle at 1000 within two seconds, shows %d", got)
+	}
+
+	// A lower score (a new game) snaps instead of counting down
+	hud.Update(100, 0, 0, 1, 0)
+	hud.Tick(1.0 / 60)
+	if got := hud.DisplayedScore(); got != 0 {
+		t.Errorf("score should snap down to 0, shows %d", got)
+	}
+}
+
+func TestHUD_RollingScoreSmallGap(t *testing.T) {
+	hud := NewHUD()
+	hud.Update(100, 0, 10, 1, 0)
+
+	for i := 0; i < 30; i++ {
+		hud.Tick(1.0 / 60)
+	}
+	if got := hud.DisplayedScore(); got != 10 {
+		t.Errorf("small score gap should finish within half a second, shows %d", got)
+	}
+}
+
+func TestHUD_WaveBanner(t *testing.T) {
+	hud := NewHUD()
+	if text, _ := hud.Banner(); text != "" {
+		t.Fatalf("no banner expected before the first wave, got %q", text)
+	}
+
+	hud.Update(100, 0, 0, 2, 0)
+	text, alpha := hud.Banner()
+	if text != "WAVE 2" {
+		t.Fatalf("banner = %q, want %q", text, "WAVE 2")
+	}
+	if alpha != 0 {
+		t.Errorf("banner should fade in from 0, got %f", alpha)
+	}
+
+	hud.Tick(WaveBannerDuration / 2)
+	if _, alpha := hud.Banner(); alpha != 1 {
+		t.Errorf("banner should be opaque mid-way, got %f", alpha)
+	}
+
+	// The same wave does not raise the banner again
+	hud.Tick(WaveBannerDuration)
+	hud.Update(100, 0, 0, 2, 0)
+	if text, _ := hud.Banner(); text != "" {
+		t.Errorf("banner should be gone, got %q", text)
+	}
+}
+
+func TestHUD_HealthTrailAndFlash(t *testing.T) {
+	hud := NewHUD()
+	hud.SetLimits(100, 50)
+	hud.Update(100, 0, 0, 1, 0)
+	hud.Tick(0.01)
+
+	hud.Update(60, 0, 0, 1, 0)
+	if hud.Flash() != 1 {
+		t.Errorf("damage should flash the health bar, flash = %f", hud.Flash())
+	}
+	hud.Tick(0.01)
+	if f := hud.HealthFraction(); f != 0.6 {
+		t.Errorf("HealthFraction() = %f, want 0.6", f)
+	}
+	if tr := hud.HealthTrail(); tr != 1 {
+		t.Errorf("trail should hold at 1 right after the hit, got %f", tr)
+	}
+
+	for i := 0; i < 120; i++ {
+		hud.Tick(1.0 / 60)
+	}
+	if tr := hud.HealthTrail(); math.Abs(tr-0.6) > 1e-9 {
+		t.Errorf("trail should drain to 0.6, got %f", tr)
+	}
+	if hud.Flash() != 0 {
+		t.Errorf("flash should fade, got %f", hud.Flash())
+	}
+
+	// Healing moves the trail up with the bar
+	hud.Update(90, 0, 0, 1, 0)
+	hud.Tick(0.01)
+	if tr := hud.HealthTrail(); tr != 0.9 {
+		t.Errorf("trail should follow healing to 0.9, got %f", tr)
+	}
+}
+
+func TestHUD_LowHealthPulse(t *testing.T) {
+	hud := NewHUD()
+	hud.SetLimits(100, 0)
+
+	hud.Update(80, 0, 0, 1, 0)
+	if p := hud.LowHealthPulse(); p != 0 {
+		t.Errorf("no pulse expected at 80%% health, got %f", p)
+	}
+
+	hud.Update(10, 0, 0, 1, 0)
+	seen := false
+	for i := 0; i < 60; i++ {
+		hud.Tick(1.0 / 60)
+		if hud.LowHealthPulse() > 0.5 {
+			seen = true
+		}
+	}
+	if !seen {
+		t.Error("health bar should pulse below LowHealthFraction")
+	}
+}
+
+func TestHUD_ComboFraction(t *testing.T) {
+	hud := NewHUD()
+	hud.SetComboTimer(1, 2)
+	if f := hud.ComboFraction(); f != 0 {
+		t.Errorf("no combo should show an empty meter, got %f", f)
+	}
+
+	hud.Update(100, 0, 0, 1, 3)
+	if f := hud.ComboFraction(); f != 0.5 {
+		t.Errorf("ComboFraction() = %f, want 0.5", f)
+	}
+}
+
+func TestHUD_Boss(t *testing.T) {
+	hud := NewHUD()
+	hud.SetBoss(BossStatus{Active: true, Name: "TARGET", Health: 300, MaxHealth: 300})
+	hud.Tick(0.01)
+	if tr := hud.BossTrail(); tr != 1 {
+		t.Errorf("boss trail should start full, got %f", tr)
+	}
+
+	hud.SetBoss(BossStatus{Active: true, Name: "TARGET", Health: 150, MaxHealth: 300})
+	hud.Tick(0.01)
+	if f := hud.Boss.Fraction(); f != 0.5 {
+		t.Errorf("boss fraction = %f, want 0.5", f)
+	}
+	if tr := hud.BossTrail(); tr != 1 {
+		t.Errorf("boss trail should hold after damage, got %f", tr)
+	}
+	if f := (BossStatus{}).Fraction(); f != 0 {
+		t.Errorf("empty boss fraction = %f, want 0", f)
+	}
+}
+
+func TestWeaponStatus(t *testing.T) {
+	tests := []struct {
+		status WeaponStatus
+		ready  bool
+		ammo   string
+	}{
+		{WeaponStatus{Name: "Blaster", Ammo: -1}, true, "INF"},
+		{WeaponStatus{Name: "Blaster", Cooldown: 0.5, Ammo: -1}, false, "INF"},
+		{WeaponStatus{Name: "Missiles", Ammo: 3, MaxAmmo: 8}, true, "3/8"},
+		{WeaponStatus{Name: "Bombs", Ammo: 0}, false, "0"},
+	}
+	for _, tt := range tests {
+		if got := tt.status.Ready(); got != tt.ready {
+			t.Errorf("%+v Ready() = %v, want %v", tt.status, got, tt.ready)
+		}
+		if got := tt.status.AmmoLabel(); got != tt.ammo {
+			t.Errorf("%+v AmmoLabel() = %q, want %q", tt.status, got, tt.ammo)
+		}
+	}
+}
+
+func TestPowerUpTimer_Label(t *testing.T) {
+	if got := (PowerUpTimer{Name: "Rapid Fire", Remaining: 4.25, Stacks: 1}).Label(); got != "Rapid Fire 4.2s" {
+		t.Errorf("Label() = %q", got)
+	}
+	if got := (PowerUpTimer{Name: "Spread", Remaining: 2, Stacks: 3}).Label(); got != "Spread x3 2.0s" {
+		t.Errorf("stacked Label() = %q", got)
+	}
+}
+
+func TestThemeForGenre(t *testing.T) {
+	seen := make(map[HUDTheme]string)
+	for _, id := range genre.All() {
+		theme := ThemeForGenre(id)
+		if other, ok := seen[theme]; ok {
+			t.Errorf("genres %s and %s share a HUD theme", id, other)
+		}
+		seen[theme] = id
+
+		// Text must stand out from the panel it sits on
+		if luminance(theme.Text) < luminance(theme.Panel)+60 {
+			t.Errorf("%s: text %v is too close to panel %v", id, theme.Text, theme.Panel)
+		}
+		if theme.Panel.A == 0 || theme.Panel.A == 255 {
+			t.Errorf("%s: panel should be translucent, alpha %d", id, theme.Panel.A)
+		}
+	}
+
+	hud := NewHUD()
+	hud.SetGenre(genre.Cyberpunk)
+	if hud.Theme() != ThemeForGenre(genre.Cyberpunk) {
+		t.Error("SetGenre should apply the genre's theme")
+	}
+	if unknown := ThemeForGenre("unknown"); unknown.Segments == 0 || unknown.FrameWidth == 0 {
+		t.Error("unknown genres should fall back to a framed, segmented theme")
+	}
+}
+
+func luminance(c interface{ RGBA() (r, g, b, a uint32) }) float64 {
+	r, g, b, _ := c.RGBA()
+	return (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 257
+}
+
+func TestNewHUDLayout(t *testing.T) {
+	for _, size := range []image.Point{{800, 600}, {1280, 720}, {1920, 1080}, {640, 480}, {2560, 1440}} {
+		l := NewHUDLayout(size.X, size.Y)
+		screen := image.Rect(0, 0, size.X, size.Y)
+
+		rects := map[string]image.Rectangle{
+			"health": l.Health, "shield": l.Shield, "combo": l.Combo,
+			"boss": l.Boss, "weapons": l.Weapons,
+		}
+		for name, r := range rects {
+			if r.Empty() || !r.In(screen) {
+				t.Errorf("%v: %s %v is not on screen", size, name, r)
+			}
+		}
+		if l.Health.Overlaps(l.Weapons) || l.Shield.Overlaps(l.Health) || l.Combo.Overlaps(l.Boss) {
+			t.Errorf("%v: HUD elements overlap", size)
+		}
+		if l.Health.Max.Y != size.Y-l.Margin || l.Weapons.Max.X != size.X-l.Margin {
+			t.Errorf("%v: corner panels should anchor to the screen edges", size)
+		}
+		if l.TextScale != math.Floor(l.TextScale) || l.TextScale < 1 {
+			t.Errorf("%v: text scale %f should be a whole number", size, l.TextScale)
+		}
+	}
+
+	small, large := NewHUDLayout(800, 600), NewHUDLayout(1600, 1200)
+	if large.Scale != 2*small.Scale || large.Health.Dx() != 2*small.Health.Dx() {
+		t.Errorf("layout should scale with resolution: %v vs %v", small.Health, large.Health)
+	}
+	if l := NewHUDLayout(100, 100); l.Scale != HUDMinScale {
+		t.Errorf("tiny screens should clamp to HUDMinScale, got %f", l.Scale)
+	}
+
+	hud := NewHUD()
+	hud.SetResolution(1920, 1080)
+	if hud.Layout() != NewHUDLayout(1920, 1080) {
+		t.Error("SetResolution should lay the HUD out for the new size")
+	}
+}
+
+func TestEdgeIndicators(t *testing.T) {
+	view := image.Rect(1000, 1000, 1800, 1600)
+	targets := []IndicatorTarget{
+		{X: 1400, Y: 1300},                       // On screen
+		{X: 2400, Y: 1300},                       // Right
+		{X: 1400, Y: 500},                        // Above
+		{X: 100, Y: 100, Kind: IndicatorBoss},    // Far up-left
+		{X: 1790, Y: 1590, Kind: IndicatorEnemy}, // Just on screen
+	}
+	got := EdgeIndicators(view, targets, 20)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 indicators, got %d: %+v", len(got), got)
+	}
+
+	if got[0].Kind != IndicatorBoss {
+		t.Errorf("boss should come first, got %+v", got[0])
+	}
+	for _, ind := range got {
+		if ind.X < 20-1e-9 || ind.X > 780+1e-9 || ind.Y < 20-1e-9 || ind.Y > 580+1e-9 {
+			t.Errorf("indicator %+v is outside the inset border", ind)
+		}
+	}
+
+	// Nearest first: the target above is 500 past the edge, the one to the
+	// right 600
+	above, right := got[1], got[2]
+	if math.Abs(right.X-780) > 1e-9 || math.Abs(right.Y-300) > 1e-9 || math.Abs(right.Angle) > 1e-9 {
+		t.Errorf("right indicator = %+v, want (780, 300) pointing right", right)
+	}
+	if math.Abs(right.Distance-600) > 1e-9 {
+		t.Errorf("right indicator distance = %f, want 600 past the edge", right.Distance)
+	}
+	if math.Abs(above.Y-20) > 1e-9 || math.Abs(above.Angle+math.Pi/2) > 1e-9 {
+		t.Errorf("above indicator = %+v, want y 20 pointing up", above)
+	}
+	if above.Alpha() <= right.Alpha() {
+		t.Errorf("nearer indicator should be more opaque: %f vs %f", above.Alpha(), right.Alpha())
+	}
+}
+
+func TestEdgeIndicatorsLimit(t *testing.T) {
+	view := image.Rect(0, 0, 800, 600)
+	var targets []IndicatorTarget
+	for i := 0; i < MaxIndicators*2; i++ {
+		targets = append(targets, IndicatorTarget{X: 1000 + float64(i)*100, Y: 300})
+	}
+	got := EdgeIndicators(view, targets, 10)
+	if len(got) != MaxIndicators {
+		t.Fatalf("expected %d indicators, got %d", MaxIndicators, len(got))
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].Distance < got[i-1].Distance {
+			t.Fatal("indicators should keep the nearest targets in order")
+		}
+	}
+	if EdgeIndicators(image.Rectangle{}, targets, 10) != nil {
+		t.Error("an empty view should have no indicators")
+	}
+}
+
+func TestHUD_TrackTargets(t *testing.T) {
+	hud := NewHUD()
+	hud.TrackTargets(image.Rect(0, 0, 800, 600), []IndicatorTarget{{X: 900, Y: 300}})
+	if len(hud.Indicators) != 1 {
+		t.Fatalf("expected 1 indicator, got %d", len(hud.Indicators))
+	}
+	if x := hud.Indicators[0].X; x != float64(800-hud.Layout().IndicatorInset) {
+		t.Errorf("indicator x = %f, want the layout inset from the edge", x)
+	}
+}
@@ -0,0 +1,255 @@
+package ux
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/opd-ai/velocity/pkg/config"
+	"github.com/opd-ai/velocity/pkg/engine"
+	"github.com/opd-ai/velocity/pkg/procgen/genre"
+	"github.com/opd-ai/velocity/pkg/validation"
+)
+
+// Settings screen constants.
+const (
+	// SettingsLabelWidth is the column, in characters, setting values start at.
+	SettingsLabelWidth = 16
+	// SliderCells is the number of cells in a slider's text bar.
+	SliderCells = 10
+	// VolumeStep is how far one press moves a volume slider.
+	VolumeStep = 0.05
+
+	// NoteRestart and NoteNextGame tell players when a change takes effect.
+	NoteRestart  = "Applies after restart"
+	NoteNextGame = "Applies from the next game"
+)
+
+// ResolutionChoices are the window sizes offered on the settings screen.
+var ResolutionChoices = []string{"800x600", "1024x768", "1280x720", "1366x768", "1600x900", "1920x1080"}
+
+// SettingKind identifies how a setting is edited.
+type SettingKind int
+
+// Setting kinds.
+const (
+	// SettingSlider steps a number between Min and Max.
+	SettingSlider SettingKind = iota
+	// SettingToggle flips between on and off.
+	SettingToggle
+	// SettingChoice cycles through a list of values.
+	SettingChoice
+)
+
+// Setting is one adjustable option on the settings screen. Key names the
+// config value it edits, such as "audio.music_volume".
+type Setting struct {
+	Key   string
+	Label string
+	Kind  SettingKind
+	Note  string // Shown while the setting is selected
+
+	Value, Min, Max, Step float64 // For sliders
+	On                    bool    // For toggles
+	Choices               []string
+	Index                 int // Selected choice
+}
+
+// Adjust moves the setting one step in direction dir (negative for left or
+// down, positive for right or up) and reports whether it changed. Toggles
+// flip in either direction and choices wrap around.
+func (s *Setting) Adjust(dir int) bool {
+	if dir == 0 {
+		return false
+	}
+	switch s.Kind {
+	case SettingSlider:
+		// Snap to the step grid, then drop floating-point dust so saved
+		// values read cleanly
+		v := math.Round((s.Value+float64(dir)*s.Step)/s.Step) * s.Step
+		v = math.Max(s.Min, math.Min(s.Max, math.Round(v*1e9)/1e9))
+		if v == s.Value {
+			return false
+		}
+		s.Value = v
+	case SettingToggle:
+		s.On = !s.On
+	case SettingChoice:
+		if len(s.Choices) < 2 {
+			return false
+		}
+		n := len(s.Choices)
+		s.Index = ((s.Index+dir)%n + n) % n
+	}
+	return true
+}
+
+// Choice returns the selected choice, or "" for other kinds.
+func (s Setting) Choice() string {
+	if s.Kind != SettingChoice || s.Index < 0 || s.Index >= len(s.Choices) {
+		return ""
+	}
+	return s.Choices[s.Index]
+}
+
+// ValueText renders the setting's current value: a cell bar and percentage
+// for sliders, On or Off for toggles, and the choice between arrows.
+func (s Setting) ValueText() string {
+	switch s.Kind {
+	case SettingSlider:
+		filled := 0
+		if s.Max > s.Min {
+			filled = int(math.Round((s.Value - s.Min) / (s.Max - s.Min) * SliderCells))
+		}
+		return fmt.Sprintf("[%s%s] %3.0f%%", strings.Repeat("#", filled), strings.Repeat("-", SliderCells-filled), s.Value*100)
+	case SettingToggle:
+		if s.On {
+			return "On"
+		}
+		return "Off"
+	default:
+		return "< " + s.Choice() + " >"
+	}
+}
+
+// Line renders the setting as a menu line with its value in a column.
+func (s Setting) Line() string {
+	return fmt.Sprintf("%-*s %s", SettingsLabelWidth, s.Label, s.ValueText())
+}
+
+// Settings is the list of options on the settings screen, built from and
+// written back to a config. Key bindings are edited on their own screen.
+type Settings struct {
+	Items    []Setting
+	Controls *Controls
+	dirty    bool
+}
+
+// NewSettings builds the settings screen for display, audio and gameplay
+// options and the controls screen from cfg.
+func NewSettings(cfg *config.Config) *Settings {
+	resolution := fmt.Sprintf("%dx%d", cfg.Display.Width, cfg.Display.Height)
+
+	return &Settings{Controls: NewControls(engine.KeyBindingsFromMap(cfg.Controls.Map())), Items: []Setting{
+		choice("display.resolution", "Resolution", ResolutionChoices, resolution, NoteRestart),
+		{Key: "display.fullscreen", Label: "Fullscreen", Kind: SettingToggle, On: cfg.Display.Fullscreen},
+		{Key: "display.vsync", Label: "VSync", Kind: SettingToggle, On: cfg.Display.VSync},
+		volume("audio.master_volume", "Master Volume", cfg.Audio.MasterVolume),
+		volume("audio.music_volume", "Music Volume", cfg.Audio.MusicVolume),
+		volume("audio.sfx_volume", "SFX Volume", cfg.Audio.SFXVolume),
+		choice("gameplay.genre", "Genre", genre.All(), cfg.Gameplay.Genre, NoteRestart),
+		choice("gameplay.arena_mode", "Arena Mode", arenaModeChoices(), cfg.Gameplay.ArenaMode, NoteNextGame),
+	}}
+}
+
+// volume builds a 0-100% volume slider.
+func volume(key, label string, value float64) Setting {
+	return Setting{Key: key, Label: label, Kind: SettingSlider, Value: value, Min: 0, Max: 1, Step: VolumeStep}
+}
+
+// choice builds a choice list selecting current. A current value missing
+// from the list, such as a hand-edited resolution, is offered first.
+func choice(key, label string, choices []string, current, note string) Setting {
+	s := Setting{Key: key, Label: label, Kind: SettingChoice, Choices: choices, Note: note}
+	for i, c := range choices {
+		if c == current {
+			s.Index = i
+			return s
+		}
+	}
+	s.Choices = append([]string{current}, choices...)
+	return s
+}
+
+// arenaModeChoices returns the valid arena modes in alphabetical order.
+func arenaModeChoices() []string {
+	modes := make([]string, 0, len(validation.ValidArenaModes))
+	for m := range validation.ValidArenaModes {
+		modes = append(modes, m)
+	}
+	sort.Strings(modes)
+	return modes
+}
+
+// Get returns the setting for a config key, or nil if there is none.
+func (s *Settings) Get(key string) *Setting {
+	for i := range s.Items {
+		if s.Items[i].Key == key {
+			return &s.Items[i]
+		}
+	}
+	return nil
+}
+
+// Adjust moves setting i one step in direction dir and reports whether it
+// changed.
+func (s *Settings) Adjust(i, dir int) bool {
+	if i < 0 || i >= len(s.Items) || !s.Items[i].Adjust(dir) {
+		return false
+	}
+	s.dirty = true
+	return true
+}
+
+// Dirty reports whether any setting or binding changed since the last
+// MarkSaved.
+func (s *Settings) Dirty() bool {
+	return s.dirty || s.Controls.dirty
+}
+
+// MarkSaved records that the current settings have been written out.
+func (s *Settings) MarkSaved() {
+	s.dirty = false
+	s.Controls.dirty = false
+}
+
+// Apply writes every setting and binding into cfg, the config to save.
+func (s *Settings) Apply(cfg *config.Config) {
+	s.Controls.Apply(cfg)
+	s.apply(cfg, func(Setting) bool { return true })
+}
+
+// ApplyLive writes the bindings and the settings that take effect at once
+// into cfg, the config the running game reads. Settings noted as applying
+// later are left alone.
+func (s *Settings) ApplyLive(cfg *config.Config) {
+	s.Controls.Apply(cfg)
+	s.apply(cfg, func(item Setting) bool { return item.Note == "" })
+}
+
+// ApplyNextGame writes the settings that apply from the next game into cfg.
+func (s *Settings) ApplyNextGame(cfg *config.Config) {
+	s.apply(cfg, func(item Setting) bool { return item.Note == NoteNextGame })
+}
+
+// apply writes the settings selected by keep into cfg.
+func (s *Settings) apply(cfg *config.Config, keep func(Setting) bool) {
+	for _, item := range s.Items {
+		if !keep(item) {
+			continue
+		}
+		switch item.Key {
+		case "display.resolution":
+			// A malformed choice keeps the previous resolution
+			var w, h int
+			if n, err := fmt.Sscanf(item.Choice(), "%dx%d", &w, &h); err == nil && n == 2 && w > 0 && h > 0 {
+				cfg.Display.Width, cfg.Display.Height = w, h
+			}
+		case "display.fullscreen":
+			cfg.Display.Fullscreen = item.On
+		case "display.vsync":
+			cfg.Display.VSync = item.On
+		case "audio.master_volume":
+			cfg.Audio.MasterVolume = item.Value
+		case "audio.music_volume":
+			cfg.Audio.MusicVolume = item.Value
+		case "audio.sfx_volume":
+			cfg.Audio.SFXVolume = item.Value
+		case "gameplay.genre":
+			cfg.Gameplay.Genre = item.Choice()
+		case "gameplay.arena_mode":
+			cfg.Gameplay.ArenaMode = item.Choice()
+		}
+	}
+}
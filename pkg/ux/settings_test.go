@@ -0,0 +1,272 @@
+package ux
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/config"
+)
+
+func testConfig() *config.Config {
+	return &config.Config{
+		Display:  config.DisplayConfig{Width: 1280, Height: 720, Fullscreen: false, VSync: true},
+		Audio:    config.AudioConfig{MasterVolume: 0.8, MusicVolume: 0.6, SFXVolume: 1, Attenuation: "linear", HearingRange: 700},
+		Gameplay: config.GameplayConfig{Genre: "scifi", ArenaMode: "wrap"},
+		Controls: config.ControlsConfig{
+			Thrust: []string{"W"}, RotateLeft: []string{"A"}, RotateRight: []string{"D"},
+			Fire: []string{"Space", "PadA"}, Secondary: []string{"Shift"}, Pause: []string{"Escape"},
+		},
+	}
+}
+
+func TestSetting_AdjustSlider(t *testing.T) {
+	s := volume("audio.music_volume", "Music", 0.8)
+
+	if !s.Adjust(1) || s.Value != 0.85 {
+		t.Fatalf("Adjust(1) = %v, want 0.85", s.Value)
+	}
+	for i := 0; i < 10; i++ {
+		s.Adjust(1)
+	}
+	if s.Value != 1 {
+		t.Errorf("slider should clamp at 1, got %v", s.Value)
+	}
+	if s.Adjust(1) {
+		t.Error("Adjust at the maximum should report no change")
+	}
+	if s.Adjust(0) {
+		t.Error("Adjust(0) should report no change")
+	}
+}
+
+func TestSetting_AdjustChoiceWraps(t *testing.T) {
+	s := choice("k", "K", []string{"a", "b", "c"}, "a", "")
+
+	s.Adjust(-1)
+	if got := s.Choice(); got != "c" {
+		t.Errorf("Adjust(-1) from first = %q, want c", got)
+	}
+	s.Adjust(1)
+	if got := s.Choice(); got != "a" {
+		t.Errorf("Adjust(1) from last = %q, want a", got)
+	}
+}
+
+func TestSetting_ChoiceKeepsUnknownValue(t *testing.T) {
+	s := choice("display.resolution", "Resolution", ResolutionChoices, "1000x700", "")
+	if got := s.Choice(); got != "1000x700" {
+		t.Errorf("Choice() = %q, want the current value kept", got)
+	}
+	if len(s.Choices) != len(ResolutionChoices)+1 {
+		t.Errorf("expected the unknown value prepended, got %v", s.Choices)
+	}
+	if len(ResolutionChoices) != 6 {
+		t.Error("ResolutionChoices must not be modified")
+	}
+}
+
+func TestSetting_ValueText(t *testing.T) {
+	tests := []struct {
+		s    Setting
+		want string
+	}{
+		{volume("v", "V", 0.5), "[#####-----]  50%"},
+		{Setting{Kind: SettingToggle, On: true}, "On"},
+		{Setting{Kind: SettingToggle}, "Off"},
+		{choice("k", "K", []string{"x", "y"}, "y", ""), "< y >"},
+	}
+	for _, tt := range tests {
+		if got := tt.s.ValueText(); got != tt.want {
+			t.Errorf("ValueText() = %q, want %q", got, tt.want)
+		}
+	}
+
+	line := Setting{Label: "VSync", Kind: SettingToggle}.Line()
+	if !strings.HasPrefix(line, "VSync ") || !strings.HasSuffix(line, " Off") || len(line) != SettingsLabelWidth+4 {
+		t.Errorf("Line() = %q, want label padded to a column", line)
+	}
+}
+
+func TestNewSettings_ReflectsConfig(t *testing.T) {
+	s := NewSettings(testConfig())
+
+	if got := s.Get("display.resolution").Choice(); got != "1280x720" {
+		t.Errorf("resolution = %q, want 1280x720", got)
+	}
+	if !s.Get("display.vsync").On || s.Get("display.fullscreen").On {
+		t.Error("toggles should match the config")
+	}
+	if got := s.Get("audio.music_volume").Value; got != 0.6 {
+		t.Errorf("music volume = %v, want 0.6", got)
+	}
+	if got := s.Get("gameplay.arena_mode").Choice(); got != "wrap" {
+		t.Errorf("arena mode = %q, want wrap", got)
+	}
+	if got := s.Controls.Bindings().Fire; len(got) != 2 || got[1] != "PadA" {
+		t.Errorf("fire = %v, want [Space PadA]", got)
+	}
+	if s.Get("gameplay.genre").Note != NoteRestart {
+		t.Error("genre should note that it applies after restart")
+	}
+	if s.Get("missing") != nil {
+		t.Error("Get of an unknown key should return nil")
+	}
+}
+
+func TestSettings_ApplyAndDirty(t *testing.T) {
+	cfg := testConfig()
+	s := NewSettings(cfg)
+	if s.Dirty() {
+		t.Fatal("new settings should not be dirty")
+	}
+
+	for i, item := range s.Items {
+		switch item.Key {
+		case "display.resolution", "display.fullscreen", "audio.sfx_volume":
+			s.Adjust(i, -1)
+		}
+	}
+	s.Controls.BeginCapture("pause")
+	s.Controls.Capture("P")
+	if !s.Dirty() {
+		t.Fatal("settings should be dirty after a change")
+	}
+
+	s.Apply(cfg)
+	if cfg.Display.Width != 1024 || cfg.Display.Height != 768 {
+		t.Errorf("resolution = %dx%d, want 1024x768", cfg.Display.Width, cfg.Display.Height)
+	}
+	if !cfg.Display.Fullscreen {
+		t.Error("fullscreen should be on")
+	}
+	if cfg.Audio.SFXVolume != 0.95 {
+		t.Errorf("SFX volume = %v, want 0.95", cfg.Audio.SFXVolume)
+	}
+	if got := cfg.Controls.Pause; len(got) != 2 || got[1] != "P" {
+		t.Errorf("pause = %v, want [Escape P]", got)
+	}
+	if cfg.Gameplay.Genre != "scifi" || len(cfg.Controls.Fire) != 2 {
+		t.Error("untouched settings should be written back unchanged")
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("applied config should validate: %v", err)
+	}
+
+	s.MarkSaved()
+	if s.Dirty() {
+		t.Error("MarkSaved should clear dirty")
+	}
+}
+
+func TestSettings_ApplyLiveAndNextGame(t *testing.T) {
+	cfg := testConfig()
+	s := NewSettings(cfg)
+	for i, item := range s.Items {
+		switch item.Key {
+		case "display.resolution", "display.fullscreen", "gameplay.genre", "gameplay.arena_mode", "audio.music_volume":
+			s.Adjust(i, 1)
+		}
+	}
+	s.Controls.BeginCapture("pause")
+	s.Controls.Capture("P")
+
+	s.ApplyLive(cfg)
+	if cfg.Display.Width != 1280 || cfg.Gameplay.Genre != "scifi" || cfg.Gameplay.ArenaMode != "wrap" {
+		t.Errorf("settings applying later should be left alone, got %dx%d %s %s",
+			cfg.Display.Width, cfg.Display.Height, cfg.Gameplay.Genre, cfg.Gameplay.ArenaMode)
+	}
+	if !cfg.Display.Fullscreen || cfg.Audio.MusicVolume != 0.65 || len(cfg.Controls.Pause) != 2 {
+		t.Error("toggles, volumes and bindings should apply at once")
+	}
+
+	s.ApplyNextGame(cfg)
+	if cfg.Gameplay.ArenaMode != s.Get("gameplay.arena_mode").Choice() {
+		t.Errorf("arena mode = %q, want the new choice", cfg.Gameplay.ArenaMode)
+	}
+	if cfg.Display.Width != 1280 || cfg.Gameplay.Genre != "scifi" {
+		t.Error("settings applying after restart should still be left alone")
+	}
+}
+
+func TestSettings_ApplyKeepsResolutionOnBadChoice(t *testing.T) {
+	cfg := testConfig()
+	s := NewSettings(cfg)
+	res := s.Get("display.resolution")
+	for _, bad := range []string{"1920xabc", "wide", "0x0"} {
+		res.Choices[res.Index] = bad
+		s.Apply(cfg)
+		if cfg.Display.Width != 1280 || cfg.Display.Height != 720 {
+			t.Errorf("%q: resolution = %dx%d, want 1280x720 kept", bad, cfg.Display.Width, cfg.Display.Height)
+		}
+	}
+}
+
+func TestMenuController_Settings(t *testing.T) {
+	gsm := NewGameStateManager()
+	mc := NewMenuController(gsm)
+	mc.SetSettings(NewSettings(testConfig()))
+
+	var changed []string
+	mc.SetSettingChangeCallback(func(s Setting) {
+		changed = append(changed, s.Key)
+	})
+	var actions []string
+	mc.SetActionCallback(func(action string) {
+		actions = append(actions, action)
+	})
+
+	// Open settings from "Settings", the second main menu item
+	mc.MoveDown()
+	mc.Select()
+	if !mc.InSettings() || mc.SelectionIndex() != 0 {
+		t.Fatalf("expected settings open at the first item, got open=%v idx=%d", mc.InSettings(), mc.SelectionIndex())
+	}
+	items := mc.GetCurrentItems()
+	if len(items) != len(mc.Settings().Items)+2 || items[len(items)-2].Action != ActionControls || items[len(items)-1].Action != ActionBack {
+		t.Fatalf("expected each setting plus Controls and Back, got %d items", len(items))
+	}
+
+	// Fullscreen toggles on select; volume moves with left and right
+	mc.MoveDown()
+	mc.Select()
+	if !mc.SelectedSetting().On {
+		t.Error("Select should flip the fullscreen toggle")
+	}
+	mc.MoveDown()
+	mc.MoveDown()
+	mc.MoveLeft()
+	if got := mc.SelectedSetting().Value; got != 0.75 {
+		t.Errorf("master volume = %v, want 0.75", got)
+	}
+	if len(changed) != 2 || changed[0] != "display.fullscreen" || changed[1] != "audio.master_volume" {
+		t.Errorf("change callback got %v", changed)
+	}
+
+	if !mc.Back() {
+		t.Fatal("Back should close the open settings screen")
+	}
+	if mc.InSettings() || mc.SelectionIndex() != 1 {
+		t.Errorf("expected the main menu with Settings selected, got open=%v idx=%d", mc.InSettings(), mc.SelectionIndex())
+	}
+	if actions[len(actions)-1] != ActionBack {
+		t.Errorf("expected a back action, got %v", actions)
+	}
+	if mc.Back() {
+		t.Error("Back with settings closed should report false")
+	}
+	if mc.SelectedSetting() != nil {
+		t.Error("SelectedSetting should be nil with settings closed")
+	}
+}
+
+func TestMenuController_SettingsWithoutSettings(t *testing.T) {
+	gsm := NewGameStateManager()
+	mc := NewMenuController(gsm)
+
+	mc.MoveDown()
+	mc.Select()
+	if mc.InSettings() {
+		t.Error("settings should not open when none are attached")
+	}
+	mc.MoveRight()
+}
@@ -1,35 +1,6 @@
 // Package ux provides the menu framework, HUD components, and tutorial scaffolding.
 package ux
 
-// HUD holds the state for the heads-up display.
-type HUD struct {
-	genreID string
-	Health  float64
-	Shield  float64
-	Score   int64
-	Wave    int
-	Combo   int
-}
-
-// NewHUD creates a new HUD instance.
-func NewHUD() *HUD {
-	return &HUD{genreID: "scifi"}
-}
-
-// SetGenre switches the HUD visual style to match the given genre.
-func (h *HUD) SetGenre(genreID string) {
-	h.genreID = genreID
-}
-
-// Update refreshes HUD values.
-func (h *HUD) Update(health, shield float64, score int64, wave, combo int) {
-	h.Health = health
-	h.Shield = shield
-	h.Score = score
-	h.Wave = wave
-	h.Combo = combo
-}
-
 // MenuState represents the current menu screen.
 type MenuState int
 
@@ -353,6 +353,49 @@ func TestHUD_Update(t *testing.T) {
 	}
 }
 
+func TestHUD_SetPowerUps(t *testing.T) {
+	hud := NewHUD()
+
+	hud.SetPowerUps([]PowerUpTimer{{Name: "Shield", Remaining: 3, Duration: 12, Stacks: 1}})
+
+	if len(hud.PowerUps) != 1 {
+		t.Fatalf("expected 1 power-up timer, got %d", len(hud.PowerUps))
+	}
+	if f := hud.PowerUps[0].Fraction(); f != 0.25 {
+		t.Errorf("expected fraction 0.25, got %f", f)
+	}
+	if f := (PowerUpTimer{}).Fraction(); f != 0 {
+		t.Errorf("expected zero-duration fraction 0, got %f", f)
+	}
+	if f := (PowerUpTimer{Remaining: 10, Duration: 5}).Fraction(); f != 1 {
+		t.Errorf("expected an extended timer to clamp at 1, got %f", f)
+	}
+}
+
+func TestHUD_SetObjective(t *testing.T) {
+	hud := NewHUD()
+
+	hud.SetObjective(ObjectiveStatus{Description: "Survive for 30 seconds", Remaining: 12.4})
+	if got := hud.Objective.Label(); got != "Survive for 30 seconds (12s)" {
+		t.Errorf("timed label = %q", got)
+	}
+
+	tests := []struct {
+		status ObjectiveStatus
+		want   string
+	}{
+		{ObjectiveStatus{}, ""},
+		{ObjectiveStatus{Description: "Collect 4 runes", Progress: 0.5}, "Collect 4 runes (50%)"},
+		{ObjectiveStatus{Description: "Take no damage", Failed: true}, "Take no damage - FAILED"},
+		{ObjectiveStatus{Description: "Protect the convoy", Completed: true}, "Protect the convoy - COMPLETE"},
+	}
+	for _, tt := range tests {
+		if got := tt.status.Label(); got != tt.want {
+			t.Errorf("Label() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
 func TestHUD_SetGenre(t *testing.T) {
 	hud := NewHUD()
 
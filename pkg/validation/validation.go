@@ -1,7 +1,11 @@
 // Package validation provides input and configuration validation.
 package validation
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
 
 // ValidGenres contains the set of supported genre identifiers.
 var ValidGenres = map[string]bool{
@@ -14,8 +18,90 @@ var ValidGenres = map[string]bool{
 
 // ValidArenaModes contains the set of supported arena modes.
 var ValidArenaModes = map[string]bool{
-	"wrap":    true,
-	"bounded": true,
+	"wrap":       true,
+	"bounded":    true,
+	"scroll":     true,
+	"ring":       true,
+	"portal":     true,
+	"electric":   true,
+	"asymmetric": true,
+	"procedural": true, // Rolled per wave by the generator
+}
+
+// ValidAttenuationCurves contains the set of supported positional audio
+// attenuation curves.
+var ValidAttenuationCurves = map[string]bool{
+	"linear":      true,
+	"inverse":     true,
+	"exponential": true,
+	"none":        true,
+}
+
+// ValidKeys contains the key and gamepad button names controls can be
+// bound to. Gamepad names use the standard layout: PadA to PadY are the
+// face buttons, PadLB/PadRB the bumpers, PadLT/PadRT the triggers, PadLS/PadRS
+// the stick presses and PadUp to PadRight the D-pad.
+var ValidKeys = map[string]bool{
+	"A": true, "B": true, "C": true, "D": true, "E": true, "F": true, "G": true, "H": true, "I": true, "J": true, "K": true, "L": true, "M": true,
+	"N": true, "O": true, "P": true, "Q": true, "R": true, "S": true, "T": true, "U": true, "V": true, "W": true, "X": true, "Y": true, "Z": true,
+	"0": true, "1": true, "2": true, "3": true, "4": true, "5": true, "6": true, "7": true, "8": true, "9": true,
+
+	"Up": true, "Down": true, "Left": true, "Right": true,
+	"Space": true, "Shift": true, "Control": true, "Alt": true,
+	"Enter": true, "Escape": true, "Tab": true, "Backspace": true,
+
+	"PadA": true, "PadB": true, "PadX": true, "PadY": true,
+	"PadLB": true, "PadRB": true, "PadLT": true, "PadRT": true,
+	"PadLS": true, "PadRS": true, "PadBack": true, "PadStart": true,
+	"PadUp": true, "PadDown": true, "PadLeft": true, "PadRight": true,
+}
+
+// MaxBindingsPerAction is the most keys and buttons one action can have.
+const MaxBindingsPerAction = 4
+
+// IsGamepadKey reports whether a key name is a gamepad button.
+func IsGamepadKey(name string) bool {
+	return strings.HasPrefix(name, "Pad")
+}
+
+// ValidateKey returns an error if the key name is not supported.
+func ValidateKey(name string) error {
+	if !ValidKeys[name] {
+		return fmt.Errorf("invalid key %q", name)
+	}
+	return nil
+}
+
+// ValidateControls returns an error if any action has no bindings, too
+// many, an unsupported key name, or a key also bound to another action.
+// controls maps action names to their bound keys.
+func ValidateControls(controls map[string][]string) error {
+	actions := make([]string, 0, len(controls))
+	for action := range controls {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions) // Report the same conflict every time
+
+	boundTo := make(map[string]string)
+	for _, action := range actions {
+		keys := controls[action]
+		if len(keys) == 0 {
+			return fmt.Errorf("invalid controls: %s has no bindings", action)
+		}
+		if len(keys) > MaxBindingsPerAction {
+			return fmt.Errorf("invalid controls: %s has %d bindings, at most %d allowed", action, len(keys), MaxBindingsPerAction)
+		}
+		for _, key := range keys {
+			if err := ValidateKey(key); err != nil {
+				return fmt.Errorf("invalid controls: %s: %w", action, err)
+			}
+			if other, ok := boundTo[key]; ok {
+				return fmt.Errorf("invalid controls: %s is bound to both %s and %s", key, other, action)
+			}
+			boundTo[key] = action
+		}
+	}
+	return nil
 }
 
 // ValidateGenre returns an error if the genre is not supported.
@@ -34,6 +120,38 @@ func ValidateArenaMode(mode string) error {
 	return nil
 }
 
+// ValidateWorldSize returns an error if a configured world dimension is smaller
+// than the display. Zero means "same as the display" and is always valid.
+func ValidateWorldSize(worldWidth, worldHeight, displayWidth, displayHeight int) error {
+	if worldWidth != 0 && worldWidth < displayWidth {
+		return fmt.Errorf("invalid world width %d: smaller than display width %d", worldWidth, displayWidth)
+	}
+	if worldHeight != 0 && worldHeight < displayHeight {
+		return fmt.Errorf("invalid world height %d: smaller than display height %d", worldHeight, displayHeight)
+	}
+	return nil
+}
+
+// ValidateScreenShake returns an error if the screen shake intensity is outside [0, 1].
+func ValidateScreenShake(scale float64) error {
+	if scale < 0 || scale > 1 {
+		return fmt.Errorf("invalid screen shake %v: must be between 0 and 1", scale)
+	}
+	return nil
+}
+
+// ValidateAttenuation returns an error if the attenuation curve is not
+// supported or the hearing range is not positive.
+func ValidateAttenuation(curve string, hearingRange float64) error {
+	if !ValidAttenuationCurves[curve] {
+		return fmt.Errorf("invalid attenuation curve %q", curve)
+	}
+	if hearingRange <= 0 {
+		return fmt.Errorf("invalid hearing range %v: must be positive", hearingRange)
+	}
+	return nil
+}
+
 // ValidatePort returns an error if the port is out of valid range.
 func ValidatePort(port int) error {
 	if port < 1 || port > 65535 {
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -31,7 +32,7 @@ func TestValidateGenre_Invalid(t *testing.T) {
 }
 
 func TestValidateArenaMode_Valid(t *testing.T) {
-	validModes := []string{"wrap", "bounded"}
+	validModes := []string{"wrap", "bounded", "scroll", "ring", "portal", "electric", "asymmetric", "procedural"}
 
 	for _, mode := range validModes {
 		t.Run(mode, func(t *testing.T) {
@@ -44,7 +45,7 @@ func TestValidateArenaMode_Valid(t *testing.T) {
 }
 
 func TestValidateArenaMode_Invalid(t *testing.T) {
-	invalidModes := []string{"", "WRAP", "Bounded", "infinite", "scroll"}
+	invalidModes := []string{"", "WRAP", "Bounded", "infinite", "Scroll"}
 
 	for _, mode := range invalidModes {
 		t.Run(mode, func(t *testing.T) {
@@ -56,6 +57,30 @@ func TestValidateArenaMode_Invalid(t *testing.T) {
 	}
 }
 
+func TestValidateAttenuation(t *testing.T) {
+	tests := []struct {
+		curve        string
+		hearingRange float64
+		wantErr      bool
+	}{
+		{"linear", 900, false},
+		{"inverse", 1, false},
+		{"exponential", 500, false},
+		{"none", 900, false},
+		{"", 900, true},
+		{"Inverse", 900, true},
+		{"quadratic", 900, true},
+		{"inverse", 0, true},
+		{"linear", -10, true},
+	}
+	for _, tt := range tests {
+		err := ValidateAttenuation(tt.curve, tt.hearingRange)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateAttenuation(%q, %v) error = %v, wantErr %v", tt.curve, tt.hearingRange, err, tt.wantErr)
+		}
+	}
+}
+
 func TestValidatePort_Valid(t *testing.T) {
 	validPorts := []int{1, 80, 443, 8080, 27015, 65535}
 
@@ -97,7 +122,7 @@ func TestValidGenres_Contains(t *testing.T) {
 }
 
 func TestValidArenaModes_Contains(t *testing.T) {
-	expected := []string{"wrap", "bounded"}
+	expected := []string{"wrap", "bounded", "scroll", "ring", "portal", "electric", "asymmetric", "procedural"}
 
 	if len(ValidArenaModes) != len(expected) {
 		t.Errorf("expected %d modes, got %d", len(expected), len(ValidArenaModes))
@@ -163,3 +188,87 @@ func BenchmarkValidatePort(b *testing.B) {
 		ValidatePort(8080)
 	}
 }
+
+func TestValidateWorldSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		wantErr       bool
+	}{
+		{"match display", 0, 0, false},
+		{"larger world", 3200, 2400, false},
+		{"equal to display", 800, 600, false},
+		{"too narrow", 640, 0, true},
+		{"too short", 0, 480, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWorldSize(tt.width, tt.height, 800, 600)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWorldSize(%d, %d) error = %v, wantErr %v", tt.width, tt.height, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateScreenShake(t *testing.T) {
+	for _, scale := range []float64{0, 0.5, 1} {
+		if err := ValidateScreenShake(scale); err != nil {
+			t.Errorf("ValidateScreenShake(%v) returned error: %v", scale, err)
+		}
+	}
+	for _, scale := range []float64{-0.1, 1.5} {
+		if err := ValidateScreenShake(scale); err == nil {
+			t.Errorf("ValidateScreenShake(%v) should return error", scale)
+		}
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	for _, key := range []string{"W", "A", "0", "9", "Space", "Shift", "Escape", "Up", "PadA", "PadStart", "PadRight"} {
+		if err := ValidateKey(key); err != nil {
+			t.Errorf("ValidateKey(%q) returned error: %v", key, err)
+		}
+	}
+	for _, key := range []string{"", "w", "space", "F13", "Pad", "PadZ", "ShiftLeft"} {
+		if err := ValidateKey(key); err == nil {
+			t.Errorf("ValidateKey(%q) should return error", key)
+		}
+	}
+}
+
+func TestIsGamepadKey(t *testing.T) {
+	if !IsGamepadKey("PadA") || IsGamepadKey("P") || IsGamepadKey("Space") {
+		t.Error("IsGamepadKey should match only Pad names")
+	}
+}
+
+func TestValidateControls(t *testing.T) {
+	tests := []struct {
+		name     string
+		controls map[string][]string
+		wantErr  string
+	}{
+		{"valid", map[string][]string{"thrust": {"W", "PadUp"}, "fire": {"Space"}}, ""},
+		{"empty action", map[string][]string{"thrust": {"W"}, "fire": nil}, "fire has no bindings"},
+		{"unknown key", map[string][]string{"thrust": {"Q", "Pedal"}}, `invalid key "Pedal"`},
+		{"too many", map[string][]string{"thrust": {"W", "Up", "I", "PadUp", "PadRT"}}, "at most 4"},
+		{"conflict", map[string][]string{"fire": {"Space"}, "thrust": {"W", "Space"}}, "Space is bound to both fire and thrust"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateControls(tt.controls)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateControls() returned error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateControls() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,462 @@
+package world
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/opd-ai/velocity/pkg/combat"
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+// ObjectiveType identifies the goal assigned to a wave.
+type ObjectiveType int
+
+const (
+	ObjectiveEliminate ObjectiveType = iota
+	ObjectiveSurvive
+	ObjectiveProtect
+	ObjectiveAssassinate
+	ObjectiveCollect
+	ObjectiveNoDamage
+)
+
+// ObjectiveRole identifies why an objective entity was spawned or marked.
+type ObjectiveRole int
+
+const (
+	ObjectiveRoleConvoy ObjectiveRole = iota
+	ObjectiveRoleTarget
+	ObjectiveRoleCollectible
+)
+
+// Objective tuning constants.
+const (
+	// ObjectiveBaseReward is the bonus score for completing a wave-1 objective.
+	ObjectiveBaseReward = 500
+	// ObjectiveRewardPerWave is the additional bonus score per wave.
+	ObjectiveRewardPerWave = 100
+	// ConvoyHealth is the hull strength of the escorted convoy.
+	ConvoyHealth = 150.0
+	// ConvoySize is the pixel size of the convoy hitbox.
+	ConvoySize = 24
+	// ConvoyOrbitRadius is the radius of the convoy's patrol circle around the arena center.
+	ConvoyOrbitRadius = 150.0
+	// ConvoyAngularSpeed is how fast the convoy patrols its circle (radians/sec).
+	ConvoyAngularSpeed = 0.25
+	// ConvoyContactDamage is the damage per second an enemy deals while touching the convoy.
+	ConvoyContactDamage = 20.0
+	// ObjectiveLayoutSeedOffset decorrelates objective entity placement from
+	// the enemy spawns sharing the wave seed.
+	ObjectiveLayoutSeedOffset = 3571
+	// AssassinateHealthMultiplier scales the health of the marked target.
+	AssassinateHealthMultiplier = 3.0
+	// CollectibleSize is the pixel size of collectible hitboxes.
+	CollectibleSize = 10
+	// CollectibleMargin keeps collectibles away from the arena edges.
+	CollectibleMargin = 60.0
+)
+
+// collectibleNames holds the genre reskin of collectible items.
+var collectibleNames = map[string]string{
+	"scifi":     "data cores",
+	"fantasy":   "runes",
+	"horror":    "relics",
+	"cyberpunk": "data shards",
+	"postapoc":  "scrap caches",
+}
+
+// AllObjectiveTypes returns every objective type a wave can be assigned.
+func AllObjectiveTypes() []ObjectiveType {
+	return []ObjectiveType{
+		ObjectiveEliminate,
+		ObjectiveSurvive,
+		ObjectiveProtect,
+		ObjectiveAssassinate,
+		ObjectiveCollect,
+		ObjectiveNoDamage,
+	}
+}
+
+// NewWaveObjective builds the objective for a wave. Goal is the survival time
+// in seconds for ObjectiveSurvive, the time limit for ObjectiveAssassinate and
+// the item count for ObjectiveCollect; other types ignore it.
+func NewWaveObjective(t ObjectiveType, goal float64, waveNumber int, genreID string) *Objective {
+	o := &Objective{
+		Type:   t,
+		Goal:   goal,
+		Reward: int64(ObjectiveBaseReward + waveNumber*ObjectiveRewardPerWave),
+	}
+
+	switch t {
+	case ObjectiveSurvive:
+		o.Description = fmt.Sprintf("Survive for %.0f seconds", goal)
+	case ObjectiveProtect:
+		o.Description = "Protect the convoy"
+		o.Goal = ConvoyHealth
+		o.Progress = ConvoyHealth
+	case ObjectiveAssassinate:
+		o.Description = fmt.Sprintf("Destroy the marked target within %.0f seconds", goal)
+		o.TimeLimit = goal
+		o.Goal = 1
+	case ObjectiveCollect:
+		name, ok := collectibleNames[genreID]
+		if !ok {
+			name = collectibleNames["scifi"]
+		}
+		o.Description = fmt.Sprintf("Collect %.0f %s", goal, name)
+	case ObjectiveNoDamage:
+		o.Description = "Take no damage"
+	default:
+		o.Type = ObjectiveEliminate
+		o.Description = "Destroy all enemies"
+		o.Reward = 0
+	}
+	return o
+}
+
+// Collectible component marks an item the player gathers for a collect objective.
+type Collectible struct{}
+
+// ObjectiveSystem tracks progress on the current wave objective.
+type ObjectiveSystem struct {
+	world     *engine.World
+	objective *Objective
+	rng       *rand.Rand
+	width     float64
+	height    float64
+
+	playerEntity engine.Entity
+	playerHealth float64
+	convoy       engine.Entity
+	target       engine.Entity
+	convoyAngle  float64
+
+	toRemove   []engine.Entity
+	onDamage   func(target, source engine.Entity, amount float64)
+	onSpawn    func(e engine.Entity, role ObjectiveRole)
+	onComplete func(o *Objective)
+}
+
+// NewObjectiveSystem creates an objective system for an arena of the given size.
+func NewObjectiveSystem(world *engine.World, width, height int) *ObjectiveSystem {
+	return &ObjectiveSystem{
+		world:    world,
+		rng:      rand.New(rand.NewSource(0)),
+		width:    float64(width),
+		height:   float64(height),
+		toRemove: make([]engine.Entity, 0, 8),
+	}
+}
+
+// SetPlayerEntity sets the player tracked for collection and no-damage objectives.
+func (objs *ObjectiveSystem) SetPlayerEntity(e engine.Entity) {
+	objs.playerEntity = e
+}
+
+// SetDamageCallback sets the callback for contact damage dealt to the convoy.
+func (objs *ObjectiveSystem) SetDamageCallback(fn func(target, source engine.Entity, amount float64)) {
+	objs.onDamage = fn
+}
+
+// SetSpawnCallback sets the callback invoked when an objective entity is spawned
+// or marked, so the caller can attach sprites or other presentation components.
+func (objs *ObjectiveSystem) SetSpawnCallback(fn func(e engine.Entity, role ObjectiveRole)) {
+	objs.onSpawn = fn
+}
+
+// SetCompleteCallback sets the callback invoked when an objective is completed.
+func (objs *ObjectiveSystem) SetCompleteCallback(fn func(o *Objective)) {
+	objs.onComplete = fn
+}
+
+// Current returns the active objective, or nil if none has been started.
+func (objs *ObjectiveSystem) Current() *Objective {
+	return objs.objective
+}
+
+// Convoy returns the convoy entity, and whether one is alive.
+func (objs *ObjectiveSystem) Convoy() (engine.Entity, bool) {
+	if objs.convoy == 0 {
+		return 0, false
+	}
+	_, alive := objs.world.GetComponent(objs.convoy, "health")
+	return objs.convoy, alive
+}
+
+// Target returns the marked assassination target, and whether one is alive.
+func (objs *ObjectiveSystem) Target() (engine.Entity, bool) {
+	if objs.target == 0 {
+		return 0, false
+	}
+	_, alive := objs.world.GetComponent(objs.target, "health")
+	return objs.target, alive
+}
+
+// Start begins tracking an objective for a wave whose enemies have just spawned.
+func (objs *ObjectiveSystem) Start(o *Objective, enemies []engine.Entity, seed int64) {
+	objs.cleanup()
+	objs.objective = o
+	objs.rng = rand.New(rand.NewSource(seed + ObjectiveLayoutSeedOffset))
+	objs.playerHealth = objs.currentPlayerHealth()
+
+	switch o.Type {
+	case ObjectiveProtect:
+		objs.spawnConvoy()
+	case ObjectiveAssassinate:
+		objs.markTarget(enemies)
+	case ObjectiveCollect:
+		for i := 0; i < int(o.Goal); i++ {
+			objs.spawnCollectible()
+		}
+	}
+}
+
+// EndsWave returns true if the objective ends the wave before all enemies are
+// destroyed (a completed survival timer).
+func (objs *ObjectiveSystem) EndsWave() bool {
+	return objs.objective != nil && objs.objective.Type == ObjectiveSurvive && objs.objective.Completed
+}
+
+// Resolve settles the objective at the end of a wave, removes leftover
+// objective entities and fires the completion callback on success.
+func (objs *ObjectiveSystem) Resolve() *Objective {
+	o := objs.objective
+	if o == nil {
+		return nil
+	}
+
+	if !o.Resolved() {
+		switch o.Type {
+		case ObjectiveEliminate, ObjectiveProtect, ObjectiveNoDamage, ObjectiveSurvive:
+			o.Complete()
+		default:
+			o.Fail()
+		}
+	}
+	objs.cleanup()
+
+	if o.Completed && objs.onComplete != nil {
+		objs.onComplete(o)
+	}
+	return o
+}
+
+// Update advances the objective for one tick.
+func (objs *ObjectiveSystem) Update(dt float64) {
+	o := objs.objective
+	if o == nil || o.Resolved() {
+		return
+	}
+	objs.toRemove = objs.toRemove[:0]
+	o.Elapsed += dt
+
+	switch o.Type {
+	case ObjectiveSurvive:
+		o.Progress = math.Min(o.Elapsed, o.Goal)
+		if o.Elapsed >= o.Goal {
+			o.Complete()
+		}
+	case ObjectiveProtect:
+		objs.updateConvoy(dt)
+	case ObjectiveAssassinate:
+		if _, alive := objs.Target(); !alive {
+			o.Progress = 1
+			o.Complete()
+		} else if o.Elapsed > o.TimeLimit {
+			o.Fail()
+		}
+	case ObjectiveCollect:
+		objs.updateCollectibles()
+	case ObjectiveNoDamage:
+		objs.checkPlayerDamage()
+	}
+
+	for _, e := range objs.toRemove {
+		objs.world.RemoveEntity(e)
+	}
+}
+
+// cleanup removes the convoy and any uncollected items.
+func (objs *ObjectiveSystem) cleanup() {
+	if convoy, alive := objs.Convoy(); alive {
+		objs.world.RemoveEntity(convoy)
+	}
+	objs.convoy = 0
+	objs.target = 0
+
+	var leftovers []engine.Entity
+	objs.world.ForEachEntity(func(e engine.Entity) {
+		if _, ok := objs.world.GetComponent(e, "collectible"); ok {
+			leftovers = append(leftovers, e)
+		}
+	})
+	for _, e := range leftovers {
+		objs.world.RemoveEntity(e)
+	}
+}
+
+// currentPlayerHealth returns the player's current health, or 0 if unknown.
+func (objs *ObjectiveSystem) currentPlayerHealth() float64 {
+	if comp, ok := objs.world.GetComponent(objs.playerEntity, "health"); ok {
+		return comp.(*combat.Health).Current
+	}
+	return 0
+}
+
+// convoyPosition returns the point on the patrol circle for the current angle.
+func (objs *ObjectiveSystem) convoyPosition() (float64, float64) {
+	return objs.width/2 + math.Cos(objs.convoyAngle)*ConvoyOrbitRadius,
+		objs.height/2 + math.Sin(objs.convoyAngle)*ConvoyOrbitRadius
+}
+
+// spawnConvoy creates the convoy at a random point on its patrol circle.
+func (objs *ObjectiveSystem) spawnConvoy() {
+	objs.convoyAngle = objs.rng.Float64() * 2 * math.Pi
+	x, y := objs.convoyPosition()
+	half := float64(ConvoySize) / 2
+
+	e := objs.world.CreateEntity()
+	objs.world.AddComponent(e, "position", &engine.Position{X: x, Y: y})
+	objs.world.AddComponent(e, "rotation", &engine.Rotation{Angle: objs.convoyAngle + math.Pi/2})
+	objs.world.AddComponent(e, "health", &combat.Health{Current: ConvoyHealth, Max: ConvoyHealth})
+	objs.world.AddComponent(e, "collisiontag", &combat.CollisionTag{Tag: "convoy"})
+	objs.world.AddComponent(e, "boundingbox", &combat.BoundingBox{
+		X: -half, Y: -half, Width: ConvoySize, Height: ConvoySize,
+	})
+	objs.convoy = e
+
+	if objs.onSpawn != nil {
+		objs.onSpawn(e, ObjectiveRoleConvoy)
+	}
+}
+
+// updateConvoy moves the convoy along its patrol and applies enemy contact damage.
+func (objs *ObjectiveSystem) updateConvoy(dt float64) {
+	convoy, alive := objs.Convoy()
+	if !alive {
+		objs.objective.Progress = 0
+		objs.objective.Fail()
+		return
+	}
+
+	objs.convoyAngle += ConvoyAngularSpeed * dt
+	if posComp, ok := objs.world.GetComponent(convoy, "position"); ok {
+		pos := posComp.(*engine.Position)
+		pos.X, pos.Y = objs.convoyPosition()
+	}
+	if rotComp, ok := objs.world.GetComponent(convoy, "rotation"); ok {
+		rotComp.(*engine.Rotation).Angle = objs.convoyAngle + math.Pi/2
+	}
+	objs.world.MarkChanged(convoy)
+
+	for _, enemy := range objs.overlapping(convoy, "enemy") {
+		if objs.onDamage != nil {
+			objs.onDamage(convoy, enemy, ConvoyContactDamage*dt)
+		}
+	}
+
+	if healthComp, ok := objs.world.GetComponent(convoy, "health"); ok {
+		objs.objective.Progress = healthComp.(*combat.Health).Current
+	}
+}
+
+// markTarget picks one of the wave's enemies as the assassination target.
+func (objs *ObjectiveSystem) markTarget(enemies []engine.Entity) {
+	if len(enemies) == 0 {
+		return
+	}
+	e := enemies[objs.rng.Intn(len(enemies))]
+	if healthComp, ok := objs.world.GetComponent(e, "health"); ok {
+		health := healthComp.(*combat.Health)
+		health.Max *= AssassinateHealthMultiplier
+		health.Current = health.Max
+	}
+	objs.target = e
+
+	if objs.onSpawn != nil {
+		objs.onSpawn(e, ObjectiveRoleTarget)
+	}
+}
+
+// spawnCollectible places a collectible at a random point inside the arena.
+func (objs *ObjectiveSystem) spawnCollectible() {
+	x := CollectibleMargin + objs.rng.Float64()*math.Max(objs.width-2*CollectibleMargin, 0)
+	y := CollectibleMargin + objs.rng.Float64()*math.Max(objs.height-2*CollectibleMargin, 0)
+	half := float64(CollectibleSize) / 2
+
+	e := objs.world.CreateEntity()
+	objs.world.AddComponent(e, "position", &engine.Position{X: x, Y: y})
+	objs.world.AddComponent(e, "collectible", &Collectible{})
+	objs.world.AddComponent(e, "boundingbox", &combat.BoundingBox{
+		X: -half, Y: -half, Width: CollectibleSize, Height: CollectibleSize,
+	})
+
+	if objs.onSpawn != nil {
+		objs.onSpawn(e, ObjectiveRoleCollectible)
+	}
+}
+
+// updateCollectibles gathers any collectibles the player is touching.
+func (objs *ObjectiveSystem) updateCollectibles() {
+	pPos, pBox := boundsOf(objs.world, objs.playerEntity)
+	if pPos == nil {
+		return
+	}
+	objs.world.ForEachEntity(func(e engine.Entity) {
+		if _, ok := objs.world.GetComponent(e, "collectible"); !ok {
+			return
+		}
+		pos, box := boundsOf(objs.world, e)
+		if combat.CheckAABBCollision(
+			pPos.X+pBox.X, pPos.Y+pBox.Y, pBox.Width, pBox.Height,
+			pos.X+box.X, pos.Y+box.Y, box.Width, box.Height,
+		) {
+			objs.toRemove = append(objs.toRemove, e)
+			objs.objective.Progress++
+		}
+	})
+	if objs.objective.Progress >= objs.objective.Goal {
+		objs.objective.Complete()
+	}
+}
+
+// checkPlayerDamage fails a no-damage objective once the player's hull drops.
+// Healing raises the baseline so only new damage counts.
+func (objs *ObjectiveSystem) checkPlayerDamage() {
+	current := objs.currentPlayerHealth()
+	if current < objs.playerHealth {
+		objs.objective.Fail()
+		return
+	}
+	objs.playerHealth = current
+}
+
+// overlapping returns entities with the given collision tag that touch e.
+func (objs *ObjectiveSystem) overlapping(e engine.Entity, tag string) []engine.Entity {
+	ePos, eBox := boundsOf(objs.world, e)
+	if ePos == nil {
+		return nil
+	}
+	var hits []engine.Entity
+	objs.world.ForEachEntity(func(other engine.Entity) {
+		if other == e {
+			return
+		}
+		tagComp, ok := objs.world.GetComponent(other, "collisiontag")
+		if !ok || tagComp.(*combat.CollisionTag).Tag != tag {
+			return
+		}
+		pos, box := boundsOf(objs.world, other)
+		if pos == nil {
+			return
+		}
+		if combat.CheckAABBCollision(
+			ePos.X+eBox.X, ePos.Y+eBox.Y, eBox.Width, eBox.Height,
+			pos.X+box.X, pos.Y+box.Y, box.Width, box.Height,
+		) {
+			hits = append(hits, other)
+		}
+	})
+	return hits
+}
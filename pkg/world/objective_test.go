@@ -0,0 +1,213 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/combat"
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+// addPlayer creates a player ship with health at (x, y).
+func addPlayer(w *engine.World, x, y float64) engine.Entity {
+	e := addShip(w, "player", x, y)
+	w.AddComponent(e, "health", &combat.Health{Current: 100, Max: 100})
+	return e
+}
+
+func TestObjective_FailAndComplete(t *testing.T) {
+	o := &Objective{Goal: 4, Progress: 1}
+	if o.Fraction() != 0.25 {
+		t.Errorf("Fraction() = %f, want 0.25", o.Fraction())
+	}
+
+	o.Fail()
+	o.Complete()
+	if o.Completed || !o.Failed || !o.Resolved() {
+		t.Errorf("failed objective should stay failed, got %+v", o)
+	}
+}
+
+func TestNewWaveObjective(t *testing.T) {
+	o := NewWaveObjective(ObjectiveCollect, 4, 3, "fantasy")
+	if o.Description != "Collect 4 runes" {
+		t.Errorf("Description = %q, want %q", o.Description, "Collect 4 runes")
+	}
+	if o.Reward != ObjectiveBaseReward+3*ObjectiveRewardPerWave {
+		t.Errorf("Reward = %d", o.Reward)
+	}
+
+	if e := NewWaveObjective(ObjectiveEliminate, 0, 1, "scifi"); e.Reward != 0 {
+		t.Errorf("plain elimination should carry no bonus, got %d", e.Reward)
+	}
+}
+
+func TestObjectiveSystem_Survive(t *testing.T) {
+	objs := NewObjectiveSystem(engine.NewWorld(), 800, 600)
+	var completed *Objective
+	objs.SetCompleteCallback(func(o *Objective) { completed = o })
+
+	objs.Start(NewWaveObjective(ObjectiveSurvive, 2, 2, "scifi"), nil, 1)
+	objs.Update(1)
+	if objs.EndsWave() {
+		t.Fatal("wave should not end before the timer elapses")
+	}
+	objs.Update(1.5)
+	if !objs.EndsWave() {
+		t.Fatal("expected survival timer to end the wave")
+	}
+
+	objs.Resolve()
+	if completed == nil || !completed.Completed {
+		t.Error("expected completion callback after surviving")
+	}
+}
+
+func TestObjectiveSystem_ProtectConvoy(t *testing.T) {
+	w := engine.NewWorld()
+	objs := NewObjectiveSystem(w, 800, 600)
+
+	var damaged engine.Entity
+	objs.SetDamageCallback(func(target, source engine.Entity, amount float64) {
+		damaged = target
+	})
+	var spawned []ObjectiveRole
+	objs.SetSpawnCallback(func(e engine.Entity, role ObjectiveRole) {
+		spawned = append(spawned, role)
+	})
+
+	objs.Start(NewWaveObjective(ObjectiveProtect, 0, 2, "scifi"), nil, 1)
+	convoy, ok := objs.Convoy()
+	if !ok {
+		t.Fatal("expected convoy to be spawned")
+	}
+	if len(spawned) != 1 || spawned[0] != ObjectiveRoleConvoy {
+		t.Errorf("expected convoy spawn callback, got %v", spawned)
+	}
+
+	// Park an enemy on the convoy's next position
+	objs.Update(0)
+	posComp, _ := w.GetComponent(convoy, "position")
+	pos := posComp.(*engine.Position)
+	addShip(w, "enemy", pos.X, pos.Y)
+	objs.Update(1.0 / 60.0)
+	if damaged != convoy {
+		t.Error("expected enemy contact to damage the convoy")
+	}
+
+	// Losing the convoy fails the objective
+	w.RemoveEntity(convoy)
+	objs.Update(1.0 / 60.0)
+	if !objs.Current().Failed {
+		t.Error("expected objective to fail once the convoy is destroyed")
+	}
+}
+
+func TestObjectiveSystem_Assassinate(t *testing.T) {
+	w := engine.NewWorld()
+	objs := NewObjectiveSystem(w, 800, 600)
+
+	enemy := addShip(w, "enemy", 100, 100)
+	w.AddComponent(enemy, "health", &combat.Health{Current: 10, Max: 10})
+
+	objs.Start(NewWaveObjective(ObjectiveAssassinate, 30, 2, "scifi"), []engine.Entity{enemy}, 1)
+	target, ok := objs.Target()
+	if !ok || target != enemy {
+		t.Fatal("expected the only enemy to be marked")
+	}
+	healthComp, _ := w.GetComponent(enemy, "health")
+	if healthComp.(*combat.Health).Max != 10*AssassinateHealthMultiplier {
+		t.Error("expected marked target to be toughened")
+	}
+
+	w.RemoveEntity(enemy)
+	objs.Update(1)
+	if !objs.Current().Completed {
+		t.Error("expected objective completed after target destroyed")
+	}
+}
+
+func TestObjectiveSystem_AssassinateTimeout(t *testing.T) {
+	w := engine.NewWorld()
+	objs := NewObjectiveSystem(w, 800, 600)
+	enemy := addShip(w, "enemy", 100, 100)
+	w.AddComponent(enemy, "health", &combat.Health{Current: 10, Max: 10})
+
+	objs.Start(NewWaveObjective(ObjectiveAssassinate, 5, 2, "scifi"), []engine.Entity{enemy}, 1)
+	objs.Update(6)
+	if !objs.Current().Failed {
+		t.Error("expected objective to fail after the time limit")
+	}
+}
+
+func TestObjectiveSystem_Collect(t *testing.T) {
+	w := engine.NewWorld()
+	objs := NewObjectiveSystem(w, 800, 600)
+	player := addPlayer(w, 0, 0)
+	objs.SetPlayerEntity(player)
+
+	var items []engine.Entity
+	objs.SetSpawnCallback(func(e engine.Entity, role ObjectiveRole) {
+		if role == ObjectiveRoleCollectible {
+			items = append(items, e)
+		}
+	})
+
+	objs.Start(NewWaveObjective(ObjectiveCollect, 2, 2, "scifi"), nil, 1)
+	if len(items) != 2 {
+		t.Fatalf("expected 2 collectibles, got %d", len(items))
+	}
+
+	playerPos, _ := w.GetComponent(player, "position")
+	pp := playerPos.(*engine.Position)
+	for _, item := range items {
+		posComp, _ := w.GetComponent(item, "position")
+		pos := posComp.(*engine.Position)
+		pp.X, pp.Y = pos.X, pos.Y
+		objs.Update(1.0 / 60.0)
+	}
+
+	o := objs.Current()
+	if o.Progress != 2 || !o.Completed {
+		t.Errorf("expected both items collected, got %+v", o)
+	}
+}
+
+func TestObjectiveSystem_CollectFailsIfUnfinished(t *testing.T) {
+	w := engine.NewWorld()
+	objs := NewObjectiveSystem(w, 800, 600)
+	objs.Start(NewWaveObjective(ObjectiveCollect, 3, 2, "scifi"), nil, 1)
+
+	o := objs.Resolve()
+	if !o.Failed {
+		t.Error("expected unfinished collection to fail at wave end")
+	}
+	count := 0
+	w.ForEachEntity(func(e engine.Entity) {
+		if _, ok := w.GetComponent(e, "collectible"); ok {
+			count++
+		}
+	})
+	if count != 0 {
+		t.Errorf("expected leftover collectibles removed, found %d", count)
+	}
+}
+
+func TestObjectiveSystem_NoDamage(t *testing.T) {
+	w := engine.NewWorld()
+	objs := NewObjectiveSystem(w, 800, 600)
+	player := addPlayer(w, 0, 0)
+	objs.SetPlayerEntity(player)
+
+	objs.Start(NewWaveObjective(ObjectiveNoDamage, 0, 2, "scifi"), nil, 1)
+	objs.Update(1)
+	if objs.Current().Failed {
+		t.Fatal("objective should not fail without damage")
+	}
+
+	healthComp, _ := w.GetComponent(player, "health")
+	healthComp.(*combat.Health).Current -= 5
+	objs.Update(1)
+	if !objs.Current().Failed {
+		t.Error("expected objective to fail after taking damage")
+	}
+}
@@ -0,0 +1,477 @@
+// Package world provides quest objectives, weather systems, environmental
+// storytelling, loot, economy, and mini-game subsystems.
+package world
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/opd-ai/velocity/pkg/combat"
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+// WeatherType identifies a space weather phenomenon.
+type WeatherType int
+
+const (
+	WeatherClear WeatherType = iota
+	WeatherIonStorm
+	WeatherSolarFlare
+	WeatherNebulaFog
+	WeatherAsteroidShower
+	WeatherGravityWell
+)
+
+// Weather tuning constants.
+const (
+	// IonDriftForce is the acceleration an ion storm applies to ships at full intensity.
+	IonDriftForce = 90.0
+	// IonDriftTurnRate is how fast the ion storm drift direction rotates (radians/sec).
+	IonDriftTurnRate = 0.2
+	// FogMinVisibility is the visible radius in pixels at full fog intensity.
+	FogMinVisibility = 140.0
+	// FogMaxVisibility is the visible radius in pixels at zero fog intensity.
+	FogMaxVisibility = 600.0
+	// MeteorSpawnInterval is the time between meteors at full intensity.
+	MeteorSpawnInterval = 0.4
+	// MeteorSpeed is the travel speed of asteroid shower meteors.
+	MeteorSpeed = 220.0
+	// MeteorDamage is the damage a meteor deals on impact.
+	MeteorDamage = 15.0
+	// MeteorLifetime is how long a meteor lives before burning up.
+	MeteorLifetime = 6.0
+	// MeteorSize is the pixel size of meteor hitboxes.
+	MeteorSize = 8
+	// GravityWellStrength is the pull acceleration near a gravity well at full intensity.
+	GravityWellStrength = 40000.0
+	// GravityWellMinDistance clamps the pull to avoid infinite acceleration at the center.
+	GravityWellMinDistance = 60.0
+	// WeatherParticleRate is the number of weather particles emitted per second at full intensity.
+	WeatherParticleRate = 60.0
+	// WeatherLayoutSeedOffset decorrelates hazard placement from the enemy
+	// spawns sharing the wave seed.
+	WeatherLayoutSeedOffset = 6151
+)
+
+// weatherNames holds the genre reskin of each weather type, indexed by type.
+var weatherNames = map[string][]string{
+	"scifi":     {"Clear", "Ion Storm", "Solar Flare", "Nebula Fog", "Asteroid Shower", "Gravity Well"},
+	"fantasy":   {"Clear", "Blizzard", "Sunfire", "Enchanted Mist", "Hailstorm", "Maelstrom"},
+	"horror":    {"Clear", "Howling Gale", "Blood Moon", "Grave Fog", "Blood Rain", "The Maw"},
+	"cyberpunk": {"Clear", "EMP Storm", "Power Surge", "Smog", "Debris Rain", "Singularity"},
+	"postapoc":  {"Clear", "Dust Storm", "Rad Flare", "Toxic Haze", "Scrap Shower", "Sinkhole"},
+}
+
+// weatherColors holds the particle color of each weather type per genre, indexed by type.
+var weatherColors = map[string][]color.RGBA{
+	"scifi": {
+		{}, {R: 120, G: 200, B: 255, A: 200}, {R: 255, G: 200, B: 80, A: 220},
+		{R: 150, G: 80, B: 200, A: 120}, {R: 180, G: 160, B: 140, A: 255}, {R: 80, G: 40, B: 160, A: 200},
+	},
+	"fantasy": {
+		{}, {R: 230, G: 240, B: 255, A: 220}, {R: 255, G: 180, B: 60, A: 220},
+		{R: 160, G: 220, B: 200, A: 120}, {R: 200, G: 220, B: 255, A: 255}, {R: 60, G: 120, B: 200, A: 200},
+	},
+	"horror": {
+		{}, {R: 120, G: 120, B: 130, A: 180}, {R: 200, G: 20, B: 20, A: 220},
+		{R: 90, G: 100, B: 90, A: 130}, {R: 160, G: 0, B: 10, A: 255}, {R: 30, G: 0, B: 20, A: 220},
+	},
+	"cyberpunk": {
+		{}, {R: 0, G: 255, B: 255, A: 220}, {R: 255, G: 255, B: 0, A: 220},
+		{R: 120, G: 120, B: 90, A: 130}, {R: 255, G: 0, B: 200, A: 255}, {R: 100, G: 0, B: 150, A: 220},
+	},
+	"postapoc": {
+		{}, {R: 200, G: 160, B: 100, A: 200}, {R: 180, G: 255, B: 80, A: 220},
+		{R: 120, G: 160, B: 60, A: 130}, {R: 140, G: 110, B: 80, A: 255}, {R: 60, G: 40, B: 30, A: 220},
+	},
+}
+
+// WeatherName returns the genre-specific display name of a weather type.
+func WeatherName(t WeatherType, genreID string) string {
+	names, ok := weatherNames[genreID]
+	if !ok {
+		names = weatherNames["scifi"]
+	}
+	if t < 0 || int(t) >= len(names) {
+		return names[WeatherClear]
+	}
+	return names[t]
+}
+
+// WeatherColor returns the genre-specific particle color of a weather type.
+func WeatherColor(t WeatherType, genreID string) color.RGBA {
+	colors, ok := weatherColors[genreID]
+	if !ok {
+		colors = weatherColors["scifi"]
+	}
+	if t < 0 || int(t) >= len(colors) {
+		return color.RGBA{}
+	}
+	return colors[t]
+}
+
+// AllWeatherTypes returns every hazardous weather type (excluding clear skies).
+func AllWeatherTypes() []WeatherType {
+	return []WeatherType{
+		WeatherIonStorm,
+		WeatherSolarFlare,
+		WeatherNebulaFog,
+		WeatherAsteroidShower,
+		WeatherGravityWell,
+	}
+}
+
+// Hazard component marks a weather-spawned entity that damages ships on contact.
+type Hazard struct {
+	Damage   float64
+	Lifetime float64
+	VX, VY   float64
+}
+
+// WeatherSystem applies the active weather's gameplay effects to the world.
+type WeatherSystem struct {
+	world   *engine.World
+	weather *Weather
+	rng     *rand.Rand
+	width   float64
+	height  float64
+
+	// Visible area; particles and meteors appear where the player can see them
+	viewX, viewY          float64
+	viewWidth, viewHeight float64
+
+	driftAngle     float64
+	wellX, wellY   float64
+	spawnTimer     float64
+	particleBudget float64
+
+	toRemove      []engine.Entity
+	onDamage      func(target, source engine.Entity, amount float64)
+	onHazardSpawn func(e engine.Entity)
+}
+
+// NewWeatherSystem creates a weather system for an arena of the given size.
+func NewWeatherSystem(world *engine.World, width, height int) *WeatherSystem {
+	return &WeatherSystem{
+		world:      world,
+		weather:    &Weather{Name: WeatherName(WeatherClear, "scifi"), GenreID: "scifi"},
+		rng:        rand.New(rand.NewSource(0)),
+		width:      float64(width),
+		height:     float64(height),
+		viewWidth:  float64(width),
+		viewHeight: float64(height),
+		toRemove:   make([]engine.Entity, 0, 8),
+	}
+}
+
+// SetView sets the visible area in world coordinates. It defaults to the
+// whole arena, which is correct whenever the world fits on screen.
+func (ws *WeatherSystem) SetView(x, y float64, width, height int) {
+	ws.viewX = x
+	ws.viewY = y
+	ws.viewWidth = float64(width)
+	ws.viewHeight = float64(height)
+}
+
+// SetDamageCallback sets the callback for weather damage dealt to ships.
+func (ws *WeatherSystem) SetDamageCallback(fn func(target, source engine.Entity, amount float64)) {
+	ws.onDamage = fn
+}
+
+// SetHazardSpawnCallback sets the callback invoked when a hazard entity is spawned,
+// so the caller can attach sprites or other presentation components.
+func (ws *WeatherSystem) SetHazardSpawnCallback(fn func(e engine.Entity)) {
+	ws.onHazardSpawn = fn
+}
+
+// SetGenre reskins the current weather for the given genre.
+func (ws *WeatherSystem) SetGenre(genreID string) {
+	ws.weather.SetGenre(genreID)
+}
+
+// Start activates a weather type with the given intensity (0-1) and seed.
+func (ws *WeatherSystem) Start(t WeatherType, genreID string, intensity float64, seed int64) {
+	ws.Stop()
+	ws.rng = rand.New(rand.NewSource(seed + WeatherLayoutSeedOffset))
+	ws.weather = &Weather{
+		Type:      t,
+		Name:      WeatherName(t, genreID),
+		GenreID:   genreID,
+		Active:    t != WeatherClear,
+		Intensity: math.Max(0, math.Min(1, intensity)),
+	}
+	ws.driftAngle = ws.rng.Float64() * 2 * math.Pi
+	ws.wellX = ws.width * (0.25 + ws.rng.Float64()*0.5)
+	ws.wellY = ws.height * (0.25 + ws.rng.Float64()*0.5)
+	ws.spawnTimer = 0
+	ws.particleBudget = 0
+}
+
+// Stop clears the active weather and restores any disabled shields.
+func (ws *WeatherSystem) Stop() {
+	ws.weather.Active = false
+	ws.applyShieldState(false)
+}
+
+// Current returns the active weather.
+func (ws *WeatherSystem) Current() *Weather {
+	return ws.weather
+}
+
+// isActive returns true if the given weather type is currently active.
+func (ws *WeatherSystem) isActive(t WeatherType) bool {
+	return ws.weather.Active && ws.weather.Type == t
+}
+
+// ShieldsDisabled returns true while a solar flare suppresses shields.
+func (ws *WeatherSystem) ShieldsDisabled() bool {
+	return ws.isActive(WeatherSolarFlare)
+}
+
+// VisibilityRadius returns how far in pixels ships can see, and whether
+// visibility is limited at all.
+func (ws *WeatherSystem) VisibilityRadius() (float64, bool) {
+	if !ws.isActive(WeatherNebulaFog) {
+		return 0, false
+	}
+	i := ws.weather.Intensity
+	return FogMaxVisibility - (FogMaxVisibility-FogMinVisibility)*i, true
+}
+
+// GravityWell returns the gravity well position, and whether one is active.
+func (ws *WeatherSystem) GravityWell() (float64, float64, bool) {
+	return ws.wellX, ws.wellY, ws.isActive(WeatherGravityWell)
+}
+
+// Update advances the weather and applies its effects for one tick.
+func (ws *WeatherSystem) Update(dt float64) {
+	ws.toRemove = ws.toRemove[:0]
+
+	ws.applyShieldState(ws.ShieldsDisabled())
+	ws.updateHazards(dt)
+
+	if ws.weather.Active {
+		switch ws.weather.Type {
+		case WeatherIonStorm:
+			ws.applyIonDrift(dt)
+		case WeatherAsteroidShower:
+			ws.updateMeteorSpawns(dt)
+		case WeatherGravityWell:
+			ws.applyGravityWell(dt)
+		}
+	}
+
+	for _, e := range ws.toRemove {
+		ws.world.RemoveEntity(e)
+	}
+}
+
+// applyShieldState toggles shield suppression on every power-up component.
+func (ws *WeatherSystem) applyShieldState(disabled bool) {
+	ws.world.ForEachEntity(func(e engine.Entity) {
+		if comp, ok := ws.world.GetComponent(e, "powerups"); ok {
+			comp.(*combat.PowerUpComponent).ShieldsDisabled = disabled
+		}
+	})
+}
+
+// isShip returns true if the entity is a player or enemy ship.
+func (ws *WeatherSystem) isShip(e engine.Entity) bool {
+	tagComp, ok := ws.world.GetComponent(e, "collisiontag")
+	if !ok {
+		return false
+	}
+	tag := tagComp.(*combat.CollisionTag).Tag
+	return tag == "player" || tag == "enemy"
+}
+
+// applyIonDrift pushes every ship along the slowly rotating storm direction.
+func (ws *WeatherSystem) applyIonDrift(dt float64) {
+	ws.driftAngle += IonDriftTurnRate * dt
+	force := IonDriftForce * ws.weather.Intensity * dt
+	ax := math.Cos(ws.driftAngle) * force
+	ay := math.Sin(ws.driftAngle) * force
+
+	ws.world.ForEachEntity(func(e engine.Entity) {
+		if !ws.isShip(e) {
+			return
+		}
+		if velComp, ok := ws.world.GetComponent(e, "velocity"); ok {
+			vel := velComp.(*engine.Velocity)
+			vel.VX += ax
+			vel.VY += ay
+		}
+	})
+}
+
+// applyGravityWell pulls ships and projectiles toward the well center.
+func (ws *WeatherSystem) applyGravityWell(dt float64) {
+	strength := GravityWellStrength * ws.weather.Intensity
+
+	ws.world.ForEachEntity(func(e engine.Entity) {
+		_, isProjectile := ws.world.GetComponent(e, "projectile")
+		if !isProjectile && !ws.isShip(e) {
+			return
+		}
+		posComp, hasPos := ws.world.GetComponent(e, "position")
+		velComp, hasVel := ws.world.GetComponent(e, "velocity")
+		if !hasPos || !hasVel {
+			return
+		}
+		pos := posComp.(*engine.Position)
+		vel := velComp.(*engine.Velocity)
+
+		dx := ws.wellX - pos.X
+		dy := ws.wellY - pos.Y
+		dist := math.Max(math.Sqrt(dx*dx+dy*dy), GravityWellMinDistance)
+		accel := strength / (dist * dist) * dt
+		vel.VX += dx / dist * accel
+		vel.VY += dy / dist * accel
+	})
+}
+
+// updateMeteorSpawns spawns asteroid shower meteors at a rate scaled by intensity.
+func (ws *WeatherSystem) updateMeteorSpawns(dt float64) {
+	ws.spawnTimer -= dt
+	if ws.spawnTimer > 0 {
+		return
+	}
+	ws.spawnTimer = MeteorSpawnInterval / math.Max(ws.weather.Intensity, 0.1)
+	ws.spawnMeteor()
+}
+
+// spawnMeteor creates a meteor falling diagonally from the top of the view.
+func (ws *WeatherSystem) spawnMeteor() engine.Entity {
+	x := ws.viewX + ws.rng.Float64()*ws.viewWidth
+	angle := math.Pi/2 + (ws.rng.Float64()-0.5)*0.6
+	vx := math.Cos(angle) * MeteorSpeed
+	vy := math.Sin(angle) * MeteorSpeed
+	half := float64(MeteorSize) / 2
+
+	e := ws.world.CreateEntity()
+	ws.world.AddComponent(e, "position", &engine.Position{X: x, Y: ws.viewY - half})
+	ws.world.AddComponent(e, "velocity", &engine.Velocity{VX: vx, VY: vy})
+	ws.world.AddComponent(e, "rotation", &engine.Rotation{Angle: angle})
+	ws.world.AddComponent(e, "hazard", &Hazard{Damage: MeteorDamage, Lifetime: MeteorLifetime, VX: vx, VY: vy})
+	ws.world.AddComponent(e, "collisiontag", &combat.CollisionTag{Tag: "hazard"})
+	ws.world.AddComponent(e, "boundingbox", &combat.BoundingBox{
+		X: -half, Y: -half, Width: MeteorSize, Height: MeteorSize,
+	})
+
+	if ws.onHazardSpawn != nil {
+		ws.onHazardSpawn(e)
+	}
+	return e
+}
+
+// updateHazards keeps meteors on course, expires them and checks ship impacts.
+func (ws *WeatherSystem) updateHazards(dt float64) {
+	ws.world.ForEachEntity(func(e engine.Entity) {
+		hazardComp, ok := ws.world.GetComponent(e, "hazard")
+		if !ok {
+			return
+		}
+		hazard := hazardComp.(*Hazard)
+		hazard.Lifetime -= dt
+		if hazard.Lifetime <= 0 {
+			ws.toRemove = append(ws.toRemove, e)
+			return
+		}
+		if velComp, ok := ws.world.GetComponent(e, "velocity"); ok {
+			vel := velComp.(*engine.Velocity)
+			vel.VX, vel.VY = hazard.VX, hazard.VY
+		}
+		if target, hit := ws.findShipHit(e); hit {
+			if ws.onDamage != nil {
+				ws.onDamage(target, e, hazard.Damage)
+			}
+			ws.toRemove = append(ws.toRemove, e)
+		}
+	})
+}
+
+// findShipHit returns the first ship overlapping the hazard.
+func (ws *WeatherSystem) findShipHit(hazard engine.Entity) (engine.Entity, bool) {
+	hPos, hBox := boundsOf(ws.world, hazard)
+	if hPos == nil {
+		return 0, false
+	}
+	var target engine.Entity
+	found := false
+	ws.world.ForEachEntity(func(e engine.Entity) {
+		if found || e == hazard || !ws.isShip(e) {
+			return
+		}
+		pos, box := boundsOf(ws.world, e)
+		if pos == nil {
+			return
+		}
+		if combat.CheckAABBCollision(
+			hPos.X+hBox.X, hPos.Y+hBox.Y, hBox.Width, hBox.Height,
+			pos.X+box.X, pos.Y+box.Y, box.Width, box.Height,
+		) {
+			target = e
+			found = true
+		}
+	})
+	return target, found
+}
+
+// boundsOf returns an entity's position and bounding box, defaulting to a 16px box.
+func boundsOf(w *engine.World, e engine.Entity) (*engine.Position, *combat.BoundingBox) {
+	posComp, ok := w.GetComponent(e, "position")
+	if !ok {
+		return nil, nil
+	}
+	if boxComp, ok := w.GetComponent(e, "boundingbox"); ok {
+		return posComp.(*engine.Position), boxComp.(*combat.BoundingBox)
+	}
+	return posComp.(*engine.Position), &combat.BoundingBox{X: -8, Y: -8, Width: 16, Height: 16}
+}
+
+// WeatherParticle describes a single weather particle to emit.
+type WeatherParticle struct {
+	X, Y  float64
+	Angle float64
+	Color color.RGBA
+}
+
+// Particles returns the weather particles to emit for this tick. The caller
+// feeds them to the particle system so weather renders like other effects.
+func (ws *WeatherSystem) Particles(dt float64) []WeatherParticle {
+	if !ws.weather.Active {
+		return nil
+	}
+	ws.particleBudget += WeatherParticleRate * ws.weather.Intensity * dt
+	count := int(ws.particleBudget)
+	ws.particleBudget -= float64(count)
+
+	c := WeatherColor(ws.weather.Type, ws.weather.GenreID)
+	particles := make([]WeatherParticle, 0, count)
+	for i := 0; i < count; i++ {
+		p := ws.particleFor(ws.weather.Type)
+		p.Color = c
+		particles = append(particles, p)
+	}
+	return particles
+}
+
+// particleFor places a particle according to the visual style of the weather type.
+func (ws *WeatherSystem) particleFor(t WeatherType) WeatherParticle {
+	x := ws.viewX + ws.rng.Float64()*ws.viewWidth
+	y := ws.viewY + ws.rng.Float64()*ws.viewHeight
+
+	switch t {
+	case WeatherIonStorm:
+		return WeatherParticle{X: x, Y: y, Angle: ws.driftAngle}
+	case WeatherSolarFlare:
+		return WeatherParticle{X: x, Y: ws.viewY, Angle: math.Pi / 2}
+	case WeatherAsteroidShower:
+		return WeatherParticle{X: x, Y: ws.viewY, Angle: math.Pi/2 + (ws.rng.Float64()-0.5)*0.6}
+	case WeatherGravityWell:
+		return WeatherParticle{X: x, Y: y, Angle: math.Atan2(ws.wellY-y, ws.wellX-x)}
+	default:
+		return WeatherParticle{X: x, Y: y, Angle: ws.rng.Float64() * 2 * math.Pi}
+	}
+}
@@ -0,0 +1,195 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/opd-ai/velocity/pkg/combat"
+	"github.com/opd-ai/velocity/pkg/engine"
+)
+
+// addShip creates a ship entity with the given collision tag at (x, y).
+func addShip(w *engine.World, tag string, x, y float64) engine.Entity {
+	e := w.CreateEntity()
+	w.AddComponent(e, "position", &engine.Position{X: x, Y: y})
+	w.AddComponent(e, "velocity", &engine.Velocity{})
+	w.AddComponent(e, "collisiontag", &combat.CollisionTag{Tag: tag})
+	w.AddComponent(e, "boundingbox", &combat.BoundingBox{X: -8, Y: -8, Width: 16, Height: 16})
+	return e
+}
+
+func TestWeatherName_GenreReskin(t *testing.T) {
+	if got := WeatherName(WeatherIonStorm, "fantasy"); got != "Blizzard" {
+		t.Errorf("fantasy ion storm = %q, want Blizzard", got)
+	}
+	if got := WeatherName(WeatherAsteroidShower, "horror"); got != "Blood Rain" {
+		t.Errorf("horror asteroid shower = %q, want Blood Rain", got)
+	}
+	if got := WeatherName(WeatherGravityWell, "unknown"); got != "Gravity Well" {
+		t.Errorf("unknown genre should fall back to scifi, got %q", got)
+	}
+}
+
+func TestWeather_SetGenreRenamesTypedWeather(t *testing.T) {
+	w := &Weather{Type: WeatherNebulaFog, Name: WeatherName(WeatherNebulaFog, "scifi"), GenreID: "scifi"}
+	w.SetGenre("cyberpunk")
+	if w.Name != "Smog" {
+		t.Errorf("Name = %q, want Smog", w.Name)
+	}
+}
+
+func TestWeatherSystem_StartStop(t *testing.T) {
+	ws := NewWeatherSystem(engine.NewWorld(), 800, 600)
+
+	ws.Start(WeatherSolarFlare, "scifi", 0.5, 1)
+	if !ws.Current().Active || ws.Current().Name != "Solar Flare" {
+		t.Errorf("expected active Solar Flare, got %+v", ws.Current())
+	}
+	if !ws.ShieldsDisabled() {
+		t.Error("expected solar flare to disable shields")
+	}
+
+	ws.Stop()
+	if ws.Current().Active || ws.ShieldsDisabled() {
+		t.Error("expected weather to stop")
+	}
+}
+
+func TestWeatherSystem_SolarFlareDisablesShields(t *testing.T) {
+	w := engine.NewWorld()
+	ws := NewWeatherSystem(w, 800, 600)
+	player := addShip(w, "player", 100, 100)
+	pc := combat.NewPowerUpComponent()
+	pc.Apply(combat.PowerUpShield)
+	w.AddComponent(player, "powerups", pc)
+
+	ws.Start(WeatherSolarFlare, "scifi", 1, 1)
+	ws.Update(1.0 / 60.0)
+
+	if got := pc.AbsorbDamage(10); got != 10 {
+		t.Errorf("expected shield bypassed during flare, %f damage reached hull", got)
+	}
+
+	ws.Stop()
+	if got := pc.AbsorbDamage(10); got != 0 {
+		t.Errorf("expected shield restored after flare, %f damage reached hull", got)
+	}
+}
+
+func TestWeatherSystem_IonStormDriftsShips(t *testing.T) {
+	w := engine.NewWorld()
+	ws := NewWeatherSystem(w, 800, 600)
+	ship := addShip(w, "enemy", 400, 300)
+
+	ws.Start(WeatherIonStorm, "scifi", 1, 3)
+	ws.Update(0.5)
+
+	velComp, _ := w.GetComponent(ship, "velocity")
+	vel := velComp.(*engine.Velocity)
+	if vel.VX == 0 && vel.VY == 0 {
+		t.Error("expected ion storm to push the ship")
+	}
+}
+
+func TestWeatherSystem_GravityWellPullsProjectiles(t *testing.T) {
+	w := engine.NewWorld()
+	ws := NewWeatherSystem(w, 800, 600)
+	ws.Start(WeatherGravityWell, "scifi", 1, 5)
+	wx, wy, active := ws.GravityWell()
+	if !active {
+		t.Fatal("expected gravity well to be active")
+	}
+
+	proj := w.CreateEntity()
+	w.AddComponent(proj, "position", &engine.Position{X: wx - 100, Y: wy})
+	w.AddComponent(proj, "velocity", &engine.Velocity{})
+	w.AddComponent(proj, "projectile", &combat.Projectile{})
+
+	ws.Update(1.0 / 60.0)
+
+	velComp, _ := w.GetComponent(proj, "velocity")
+	if vx := velComp.(*engine.Velocity).VX; vx <= 0 {
+		t.Errorf("expected projectile pulled toward well (VX > 0), got %f", vx)
+	}
+}
+
+func TestWeatherSystem_NebulaFogLimitsVisibility(t *testing.T) {
+	ws := NewWeatherSystem(engine.NewWorld(), 800, 600)
+
+	if _, limited := ws.VisibilityRadius(); limited {
+		t.Error("expected unlimited visibility in clear weather")
+	}
+
+	ws.Start(WeatherNebulaFog, "horror", 1, 1)
+	radius, limited := ws.VisibilityRadius()
+	if !limited || radius != FogMinVisibility {
+		t.Errorf("expected visibility %f at full fog, got %f (limited=%v)", FogMinVisibility, radius, limited)
+	}
+}
+
+func TestWeatherSystem_AsteroidShowerDamagesShips(t *testing.T) {
+	w := engine.NewWorld()
+	ws := NewWeatherSystem(w, 800, 600)
+	player := addShip(w, "player", 0, 0)
+
+	var spawned []engine.Entity
+	ws.SetHazardSpawnCallback(func(e engine.Entity) {
+		spawned = append(spawned, e)
+	})
+	var damaged engine.Entity
+	ws.SetDamageCallback(func(target, source engine.Entity, amount float64) {
+		damaged = target
+	})
+
+	ws.Start(WeatherAsteroidShower, "scifi", 1, 9)
+	ws.Update(1.0 / 60.0)
+	if len(spawned) != 1 {
+		t.Fatalf("expected one meteor spawned, got %d", len(spawned))
+	}
+
+	// Move the meteor onto the player and tick again
+	posComp, _ := w.GetComponent(spawned[0], "position")
+	pos := posComp.(*engine.Position)
+	pos.X, pos.Y = 0, 0
+	ws.Update(1.0 / 60.0)
+
+	if damaged != player {
+		t.Error("expected meteor to damage the player")
+	}
+	if _, ok := w.GetComponent(spawned[0], "hazard"); ok {
+		t.Error("expected meteor removed after impact")
+	}
+}
+
+func TestWeatherSystem_Particles(t *testing.T) {
+	ws := NewWeatherSystem(engine.NewWorld(), 800, 600)
+	if p := ws.Particles(1); len(p) != 0 {
+		t.Errorf("expected no particles in clear weather, got %d", len(p))
+	}
+
+	ws.Start(WeatherIonStorm, "fantasy", 1, 1)
+	particles := ws.Particles(1)
+	if len(particles) != int(WeatherParticleRate) {
+		t.Fatalf("expected %d particles, got %d", int(WeatherParticleRate), len(particles))
+	}
+	if particles[0].Color != WeatherColor(WeatherIonStorm, "fantasy") {
+		t.Error("expected particles to use the genre weather color")
+	}
+}
+
+func TestWeatherSystem_SetViewPlacesParticles(t *testing.T) {
+	ws := NewWeatherSystem(engine.NewWorld(), 4000, 3000)
+	ws.SetView(1000, 2000, 800, 600)
+	ws.Start(WeatherIonStorm, "scifi", 1, 1)
+
+	for _, p := range ws.Particles(1) {
+		if p.X < 1000 || p.X > 1800 || p.Y < 2000 || p.Y > 2600 {
+			t.Fatalf("expected particle inside the view, got (%.0f, %.0f)", p.X, p.Y)
+		}
+	}
+
+	meteor := ws.spawnMeteor()
+	posComp, _ := ws.world.GetComponent(meteor, "position")
+	if pos := posComp.(*engine.Position); pos.X < 1000 || pos.X > 1800 || pos.Y > 2000 {
+		t.Errorf("expected meteor to enter from the top of the view, got (%.0f, %.0f)", pos.X, pos.Y)
+	}
+}
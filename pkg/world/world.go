@@ -2,8 +2,11 @@
 // storytelling, loot, economy, and mini-game subsystems.
 package world
 
+import "math"
+
 // Weather represents a space weather phenomenon.
 type Weather struct {
+	Type      WeatherType
 	Name      string
 	GenreID   string
 	Active    bool
@@ -16,14 +19,25 @@ func NewWeather(name, genreID string) *Weather {
 }
 
 // SetGenre switches weather visuals to match the given genre.
+// Typed weather is also renamed to the genre's reskin.
 func (w *Weather) SetGenre(genreID string) {
 	w.GenreID = genreID
+	if w.Type != WeatherClear {
+		w.Name = WeatherName(w.Type, genreID)
+	}
 }
 
 // Objective represents a wave objective.
 type Objective struct {
+	Type        ObjectiveType
 	Description string
 	Completed   bool
+	Failed      bool
+	Goal        float64
+	Progress    float64
+	TimeLimit   float64
+	Elapsed     float64
+	Reward      int64
 }
 
 // NewObjective creates a new wave objective.
@@ -32,8 +46,32 @@ func NewObjective(description string) *Objective {
 }
 
 // Complete marks the objective as finished.
+// Failed objectives cannot be completed.
 func (o *Objective) Complete() {
-	o.Completed = true
+	if !o.Failed {
+		o.Completed = true
+	}
+}
+
+// Fail marks the objective as failed.
+// Completed objectives cannot be failed.
+func (o *Objective) Fail() {
+	if !o.Completed {
+		o.Failed = true
+	}
+}
+
+// Resolved returns true once the objective has been completed or failed.
+func (o *Objective) Resolved() bool {
+	return o.Completed || o.Failed
+}
+
+// Fraction returns progress toward the goal in [0, 1].
+func (o *Objective) Fraction() float64 {
+	if o.Goal <= 0 {
+		return 0
+	}
+	return math.Min(o.Progress/o.Goal, 1)
 }
 
 // LootDrop represents a pickup dropped by a destroyed enemy.